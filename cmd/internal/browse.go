@@ -3,6 +3,7 @@ package internal
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -13,6 +14,7 @@ import (
 	execIO "github.com/flowexec/flow/internal/io/executable"
 	"github.com/flowexec/flow/internal/io/library"
 	"github.com/flowexec/flow/internal/logger"
+	"github.com/flowexec/flow/internal/services/search"
 	"github.com/flowexec/flow/types/executable"
 )
 
@@ -111,7 +113,7 @@ func executableLibrary(ctx *context.Context, cmd *cobra.Command, _ []string) {
 		logger.Log().FatalErr(err)
 	}
 
-	runFunc := func(ref string) error { return runByRef(ctx, cmd, ref) }
+	runFunc := func(ref string, args, params []string) error { return runByRef(ctx, cmd, ref, args, params) }
 	libraryModel := library.NewLibraryView(
 		ctx, allWs, allExecs,
 		library.Filter{
@@ -159,11 +161,15 @@ func listExecutables(ctx *context.Context, cmd *cobra.Command, _ []string) {
 		FilterByWorkspace(wsFilter).
 		FilterByNamespace(nsFilter).
 		FilterByVerb(executable.Verb(verbFilter)).
-		FilterByTags(tagsFilter).
-		FilterBySubstring(substr)
+		FilterByTags(tagsFilter)
+	usage, err := cache.LoadUsageData()
+	if err != nil {
+		logger.Log().Warnx("unable to load executable usage data", "err", err)
+	}
+	filteredExec = search.Rank(filteredExec, substr, usage, time.Now())
 
 	if TUIEnabled(ctx, cmd) {
-		runFunc := func(ref string) error { return runByRef(ctx, cmd, ref) }
+		runFunc := func(ref string, args, params []string) error { return runByRef(ctx, cmd, ref, args, params) }
 		view := execIO.NewExecutableListView(ctx, filteredExec, runFunc)
 		SetView(ctx, cmd, view)
 	} else {
@@ -208,7 +214,7 @@ func viewExecutable(ctx *context.Context, cmd *cobra.Command, args []string) {
 
 	outputFormat := flags.ValueFor[string](cmd, *flags.OutputFormatFlag, false)
 	if TUIEnabled(ctx, cmd) {
-		runFunc := func(ref string) error { return runByRef(ctx, cmd, ref) }
+		runFunc := func(ref string, args, params []string) error { return runByRef(ctx, cmd, ref, args, params) }
 		view := execIO.NewExecutableView(ctx, exec, runFunc)
 		SetView(ctx, cmd, view)
 	} else {
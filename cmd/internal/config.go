@@ -77,6 +77,7 @@ func registerSetConfigCmd(ctx *context.Context, configCmd *cobra.Command) {
 		Short:   "Set a global configuration value.",
 	}
 	registerSetNamespaceCmd(ctx, setCmd)
+	registerSetProfileCmd(ctx, setCmd)
 	registerSetWorkspaceModeCmd(ctx, setCmd)
 	registerSetLogModeCmd(ctx, setCmd)
 	registerSetTUICmd(ctx, setCmd)
@@ -107,6 +108,36 @@ func setNamespaceFunc(ctx *context.Context, _ *cobra.Command, args []string) {
 	logger.Log().PlainTextSuccess("Namespace set to " + namespace)
 }
 
+func registerSetProfileCmd(ctx *context.Context, setCmd *cobra.Command) {
+	profileCmd := &cobra.Command{
+		Use:   "profile [NAME]",
+		Short: "Change the profile used when running executables. Omit the name to clear it.",
+		Args:  cobra.MaximumNArgs(1),
+		Run:   func(cmd *cobra.Command, args []string) { setProfileFunc(ctx, cmd, args) },
+	}
+	setCmd.AddCommand(profileCmd)
+}
+
+func setProfileFunc(ctx *context.Context, _ *cobra.Command, args []string) {
+	var profile string
+	if len(args) > 0 {
+		profile = args[0]
+	}
+	userConfig := ctx.Config
+	userConfig.CurrentProfile = profile
+	if err := filesystem.WriteConfig(userConfig); err != nil {
+		logger.Log().FatalErr(err)
+	}
+	if profile == "" {
+		logger.Log().PlainTextSuccess("Profile cleared")
+		return
+	}
+	if _, found := ctx.CurrentWorkspace.Profiles[profile]; !found {
+		logger.Log().Warnf("Profile %s is not defined in the current workspace", profile)
+	}
+	logger.Log().PlainTextSuccess("Profile set to " + profile)
+}
+
 func registerSetWorkspaceModeCmd(ctx *context.Context, setCmd *cobra.Command) {
 	workspaceModeCmd := &cobra.Command{
 		Use:       "workspace-mode [fixed|dynamic]",
@@ -4,18 +4,27 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
 	tuikitIO "github.com/flowexec/tuikit/io"
 	"github.com/flowexec/tuikit/views"
 	"github.com/gen2brain/beeep"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"golang.org/x/term"
 
 	"github.com/flowexec/flow/cmd/internal/flags"
+	"github.com/flowexec/flow/cmd/internal/version"
 	"github.com/flowexec/flow/internal/cache"
 	"github.com/flowexec/flow/internal/context"
+	"github.com/flowexec/flow/internal/filesystem"
 	"github.com/flowexec/flow/internal/io"
+	"github.com/flowexec/flow/internal/io/dashboard"
+	execIO "github.com/flowexec/flow/internal/io/executable"
 	"github.com/flowexec/flow/internal/logger"
 	"github.com/flowexec/flow/internal/runner"
 	"github.com/flowexec/flow/internal/runner/engine"
@@ -23,11 +32,14 @@ import (
 	"github.com/flowexec/flow/internal/runner/launch"
 	"github.com/flowexec/flow/internal/runner/parallel"
 	"github.com/flowexec/flow/internal/runner/render"
+	"github.com/flowexec/flow/internal/runner/report"
 	"github.com/flowexec/flow/internal/runner/request"
 	"github.com/flowexec/flow/internal/runner/serial"
+	"github.com/flowexec/flow/internal/runner/tracing"
 	"github.com/flowexec/flow/internal/services/store"
+	"github.com/flowexec/flow/internal/utils/env"
 	"github.com/flowexec/flow/internal/vault"
-	vaultV2 "github.com/flowexec/flow/internal/vault/v2"
+	"github.com/flowexec/flow/types/config"
 	"github.com/flowexec/flow/types/executable"
 )
 
@@ -45,6 +57,13 @@ func RegisterExecCmd(ctx *context.Context, rootCmd *cobra.Command) {
 		),
 		Args: cobra.ArbitraryArgs,
 		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				e := completionTarget(ctx, cmd, args[0])
+				if e == nil {
+					return nil, cobra.ShellCompDirectiveNoFileComp
+				}
+				return completeExecArgs(e, args[1:], toComplete)
+			}
 			execList, err := ctx.ExecutableCache.GetExecutableList()
 			if err != nil {
 				return nil, cobra.ShellCompDirectiveError
@@ -65,11 +84,39 @@ func RegisterExecCmd(ctx *context.Context, rootCmd *cobra.Command) {
 		Run: func(cmd *cobra.Command, args []string) {
 			verbStr := cmd.CalledAs()
 			verb := executable.Verb(verbStr)
-			execFunc(ctx, cmd, verb, args)
+			if err := execFunc(ctx, cmd, verb, args); err != nil {
+				logger.Log().FatalErr(err)
+			}
 		},
 	}
 	RegisterFlag(ctx, subCmd, *flags.ParameterValueFlag)
 	RegisterFlag(ctx, subCmd, *flags.LogModeFlag)
+	RegisterFlag(ctx, subCmd, *flags.NoInputFlag)
+	RegisterFlag(ctx, subCmd, *flags.ProfileFlag)
+	RegisterFlag(ctx, subCmd, *flags.YesFlag)
+	RegisterFlag(ctx, subCmd, *flags.ReportFlag)
+	err := subCmd.RegisterFlagCompletionFunc(
+		flags.ParameterValueFlag.Name,
+		func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) == 0 || strings.Contains(toComplete, "=") {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			e := completionTarget(ctx, cmd, args[0])
+			if e == nil {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			var keys []string
+			for _, key := range paramKeys(ctx, e, make(map[executable.Ref]bool)) {
+				if strings.HasPrefix(key, toComplete) {
+					keys = append(keys, key+"=")
+				}
+			}
+			return slices.Compact(keys), cobra.ShellCompDirectiveNoSpace | cobra.ShellCompDirectiveNoFileComp
+		},
+	)
+	if err != nil {
+		logger.Log().FatalErr(err)
+	}
 	rootCmd.AddCommand(subCmd)
 }
 
@@ -85,14 +132,23 @@ func execPreRun(_ *context.Context, _ *cobra.Command, _ []string) {
 // TODO: refactor this function to simplify the logic
 //
 //nolint:funlen,gocognit
-func execFunc(ctx *context.Context, cmd *cobra.Command, verb executable.Verb, args []string) {
+func execFunc(ctx *context.Context, cmd *cobra.Command, verb executable.Verb, args []string) error {
 	logMode := flags.ValueFor[string](cmd, *flags.LogModeFlag, false)
 	if logMode != "" {
 		logger.Log().SetMode(tuikitIO.LogMode(logMode))
 	}
 
 	if err := verb.Validate(); err != nil {
-		logger.Log().FatalErr(err)
+		return err
+	}
+	reports, err := report.ParseTargets(flags.ValueFor[[]string](cmd, *flags.ReportFlag, false))
+	if err != nil {
+		return err
+	}
+	if ctx.Config.LogRetention != nil {
+		if _, err := filesystem.PruneLogs(filesystem.LogsDir(), ctx.Config.LogRetention, false); err != nil {
+			logger.Log().Debugf("unable to prune logs - %v", err)
+		}
 	}
 
 	// populate args for environment handling ignoring arg 0 (the ref)
@@ -112,32 +168,32 @@ func execFunc(ctx *context.Context, cmd *cobra.Command, verb executable.Verb, ar
 	if err != nil && errors.Is(cache.NewExecutableNotFoundError(ref.String()), err) {
 		logger.Log().Debugf("Executable %s not found in cache, syncing cache", ref)
 		if err := ctx.ExecutableCache.Update(); err != nil {
-			logger.Log().FatalErr(err)
+			return err
 		}
 		e, err = ctx.ExecutableCache.GetExecutableByRef(ref)
 	}
 	if err != nil {
-		logger.Log().FatalErr(err)
+		return err
 	}
 
 	if err := e.Validate(); err != nil {
-		logger.Log().FatalErr(err)
+		return err
 	}
 
 	if !e.IsExecutableFromWorkspace(ctx.CurrentWorkspace.AssignedName()) {
-		logger.Log().FatalErr(fmt.Errorf(
+		return fmt.Errorf(
 			"e '%s' cannot be executed from workspace %s",
 			ref,
 			ctx.Config.CurrentWorkspace,
-		))
+		)
 	}
 
 	s, err := store.NewStore(store.Path())
 	if err != nil {
-		logger.Log().FatalErr(err)
+		return err
 	}
 	if _, err = s.CreateAndSetBucket(ref.String()); err != nil {
-		logger.Log().FatalErr(err)
+		return err
 	}
 	_ = s.Close()
 
@@ -146,28 +202,85 @@ func execFunc(ctx *context.Context, cmd *cobra.Command, verb executable.Verb, ar
 	paramOverrides := flags.ValueFor[[]string](cmd, *flags.ParameterValueFlag, false)
 	applyParameterOverrides(paramOverrides, envMap)
 
+	// add values from the selected workspace profile to the env map
+	noInput := flags.ValueFor[bool](cmd, *flags.NoInputFlag, false)
+	ctx.SkipConfirmations = flags.ValueFor[bool](cmd, *flags.YesFlag, false)
+	ctx.DisableInput = noInput
+	if err := applyProfile(ctx, cmd, e, envMap, noInput); err != nil {
+		return err
+	}
+	// the executable is confirmed before prompting for its params. Its steps and hooks are confirmed by the runner.
+	if err := runner.Confirm(ctx, e, envMap); err != nil {
+		return err
+	}
+
 	// add values from the prompt param type to the env map
-	textInputs := pendingFormFields(ctx, e, envMap)
-	if len(textInputs) > 0 {
-		form, err := views.NewForm(io.Theme(ctx.Config.Theme.String()), ctx.StdIn(), ctx.StdOut(), textInputs...)
-		if err != nil {
-			logger.Log().FatalErr(err)
-		}
-		if err := form.Run(ctx.Ctx); err != nil {
-			logger.Log().FatalErr(err)
+	if prompts := execIO.PromptParams(ctx, e, envMap); len(prompts) > 0 {
+		if noInput {
+			if err := applyPromptDefaults(prompts, envMap); err != nil {
+				return err
+			}
+		} else {
+			textInputs := make([]*views.FormField, 0, len(prompts))
+			for _, param := range prompts {
+				textInputs = append(textInputs, execIO.PromptFormField(param))
+			}
+			form, err := views.NewForm(io.Theme(ctx.Config.Theme.String()), ctx.StdIn(), ctx.StdOut(), textInputs...)
+			if err != nil {
+				return err
+			}
+			if err := form.Run(ctx.Ctx); err != nil {
+				return err
+			}
+			for key, val := range form.ValueMap() {
+				envMap[key] = fmt.Sprintf("%v", val)
+			}
 		}
-		for key, val := range form.ValueMap() {
-			envMap[key] = fmt.Sprintf("%v", val)
+		for _, param := range prompts {
+			if err := param.ValidatePromptValue(envMap[param.EnvKey]); err != nil {
+				return fmt.Errorf("invalid value for parameter %s: %w", param.EnvKey, err)
+			}
 		}
 	}
 
-	if ctx.Config.CurrentVault == nil || *ctx.Config.CurrentVault == vaultV2.LegacyVaultReservedName {
-		setAuthEnv(ctx, cmd, e, false)
+	if err := validateArgs(ctx, e, envMap); err != nil {
+		return err
+	}
+
+	if isLegacyVault(ctx) {
+		if noInput {
+			logger.Log().Debugf("input disabled, skipping vault encryption key prompt")
+		} else {
+			setAuthEnv(ctx, cmd, e, false)
+		}
 	}
 	startTime := time.Now()
+	runner.LogExecutionStarted(e.Ref().String(), startTime)
+	if err := cache.RecordExecutableRun(e.Ref()); err != nil {
+		logger.Log().Debugf("unable to record executable usage - %v", err)
+	}
 	eng := engine.NewExecEngine()
-	if err := runner.Exec(ctx, e, eng, envMap); err != nil {
-		logger.Log().FatalErr(err)
+	var wsHooks *executable.Hooks
+	if ws := executableWorkspace(ctx, e); ws != nil {
+		wsHooks = ws.Hooks
+	}
+	run := func() error {
+		return runner.ExecWithGlobalHooks(ctx, e, eng, envMap, ctx.Config.Hooks, wsHooks)
+	}
+	if len(reports) > 0 {
+		run = execWithReports(e, reports, run)
+	}
+	if ctx.Config.Tracing != nil && ctx.Config.Tracing.Enabled {
+		run = execWithTracing(ctx.Config.Tracing, e, run)
+	}
+	if dashboardEnabled(ctx, cmd, e, ctx.Config.Hooks, wsHooks) {
+		err = execWithDashboard(ctx, e, run)
+	} else {
+		err = run()
+	}
+	runner.LogExecutionFinished(e.Ref().String(), startTime, err)
+	if err != nil {
+		return err
 	}
 	dur := time.Since(startTime)
 	processStore, err := store.NewStore(store.Path())
@@ -189,12 +302,93 @@ func execFunc(ctx *context.Context, cmd *cobra.Command, verb executable.Verb, ar
 			_ = beeep.Notify("Flow", "Flow completed", "")
 		}
 	}
+	return nil
+}
+
+// dashboardEnabled reports whether the progress of the steps of a serial or parallel executable is shown in the
+// execution dashboard. Steps that prompt for input and debug logs would be written over the view, so the dashboard
+// is not used for them. It is also not used when the executable is run from another view.
+func dashboardEnabled(
+	ctx *context.Context, cmd *cobra.Command, e *executable.Executable, hooks ...*executable.Hooks,
+) bool {
+	if !TUIEnabled(ctx, cmd) || (e.Serial == nil && e.Parallel == nil) {
+		return false
+	} else if ctx.TUIContainer == nil || ctx.TUIContainer.Program().Started() {
+		return false
+	} else if !term.IsTerminal(int(ctx.StdOut().Fd())) || !term.IsTerminal(int(ctx.StdIn().Fd())) {
+		return false
+	} else if flags.ValueFor[string](cmd.Root(), *flags.LogLevel, true) == "debug" {
+		return false
+	}
+	// confirmations of the steps and serial step reviews are prompted for outside the dashboard
+	return !runner.ConfirmationRequired(ctx, e, hooks...)
 }
 
-func runByRef(ctx *context.Context, cmd *cobra.Command, argsStr string) error {
-	s := strings.Split(argsStr, " ")
+// execWithDashboard runs fn while the execution dashboard is shown. The dashboard exits with the final state of the
+// steps once fn returns.
+func execWithDashboard(ctx *context.Context, e *executable.Executable, fn func() error) error {
+	if err := ctx.TUIContainer.Start(); err != nil {
+		return err
+	}
+	d := dashboard.NewDashboard(ctx.TUIContainer.RenderState(), e.Ref().String(), logger.Log())
+	defer runner.AddStepObserver(d)()
+	if err := ctx.SetView(d); err != nil {
+		return err
+	}
+
+	err := fn()
+	d.Finish()
+	ctx.TUIContainer.Send(tea.QuitMsg{}, 0)
+	ctx.TUIContainer.WaitForExit()
+	return err
+}
+
+// execWithReports returns fn with the results of the executable and its steps written to the report targets once it
+// returns. A report that can't be written fails a successful execution.
+func execWithReports(e *executable.Executable, targets []report.Target, fn func() error) func() error {
+	return func() error {
+		collector := report.NewCollector(e.Ref().String())
+		remove := runner.AddStepObserver(collector)
+		err := fn()
+		remove()
+		result := collector.Finish(err)
+		for _, target := range targets {
+			if wErr := report.Write(target, result); wErr != nil {
+				if err != nil {
+					logger.Log().Error(wErr, "unable to write report")
+					continue
+				}
+				err = wErr
+			}
+		}
+		return err
+	}
+}
+
+// execWithTracing returns fn with the trace of the executable exported once it returns. A trace that can't be
+// exported does not fail the execution.
+func execWithTracing(cfg *config.Tracing, e *executable.Executable, fn func() error) func() error {
+	return func() error {
+		tracer := tracing.NewTracer(e.Ref(), version.Version())
+		remove := runner.AddStepObserver(tracer)
+		err := fn()
+		remove()
+		trace := tracer.Finish(err)
+		if location, tErr := tracing.Export(cfg, trace); tErr != nil {
+			logger.Log().Warnf("unable to export trace - %v", tErr)
+		} else {
+			logger.Log().Debugf("trace %s exported to %s", trace.ID, location)
+		}
+		return err
+	}
+}
+
+// runByRef runs the executable with the `VERB ID` reference string like `flow exec`. The args are passed as the
+// executable's arguments and the params as `--param` values.
+func runByRef(ctx *context.Context, cmd *cobra.Command, refStr string, args, params []string) error {
+	s := strings.Split(refStr, " ")
 	if len(s) != 2 {
-		return fmt.Errorf("invalid reference string %s", argsStr)
+		return fmt.Errorf("invalid reference string %s", refStr)
 	}
 	verbStr := s[0]
 	verb := executable.Verb(verbStr)
@@ -212,14 +406,26 @@ func runByRef(ctx *context.Context, cmd *cobra.Command, argsStr string) error {
 	if execCmd == nil {
 		return errors.New("exec command not found")
 	}
-	execCmd.SetArgs([]string{verbStr, id})
+	execArgs := append([]string{id}, args...)
+	execCmd.SetArgs(append([]string{verbStr}, execArgs...))
 	execCmd.SetOut(ctx.StdOut())
 	execCmd.SetErr(ctx.StdOut())
 	execCmd.SetIn(ctx.StdIn())
-	execPreRun(ctx, execCmd, []string{id})
-	execFunc(ctx, execCmd, verb, []string{id})
-	ctx.CancelFunc()
-	return nil
+	// the values of a previous run are cleared since the executable can be run several times from the same view
+	if f, ok := execCmd.Flags().Lookup(flags.ParameterValueFlag.Name).Value.(pflag.SliceValue); ok {
+		if err := f.Replace(nil); err != nil {
+			return err
+		}
+	}
+	for _, param := range params {
+		if err := execCmd.Flags().Set(flags.ParameterValueFlag.Name, param); err != nil {
+			return fmt.Errorf("invalid parameter %s - %w", param, err)
+		}
+	}
+	ctx.Args = nil
+	ctx.ResetConfirmations()
+	execPreRun(ctx, execCmd, execArgs)
+	return execFunc(ctx, execCmd, verb, execArgs)
 }
 
 func setAuthEnv(ctx *context.Context, _ *cobra.Command, executable *executable.Executable, force bool) {
@@ -256,6 +462,14 @@ func authRequired(ctx *context.Context, rootExec *executable.Executable) bool {
 	if os.Getenv(vault.EncryptionKeyEnvVar) != "" {
 		return false
 	}
+	if execEnv := rootExec.Env(); execEnv != nil {
+		// includes the params inherited from the flow file and workspace
+		for _, param := range execEnv.Params {
+			if param.SecretRef != "" {
+				return true
+			}
+		}
+	}
 	switch {
 	case rootExec.Exec != nil:
 		for _, param := range rootExec.Exec.Params {
@@ -319,86 +533,204 @@ func authRequired(ctx *context.Context, rootExec *executable.Executable) bool {
 	return false
 }
 
+// applyPromptDefaults sets the default value of each prompt parameter in the env map. An error listing
+// every parameter without a usable default is returned.
+func applyPromptDefaults(params executable.ParameterList, envMap map[string]string) error {
+	var missing []string
+	for _, param := range params {
+		val, ok := param.PromptDefault()
+		if !ok {
+			missing = append(missing, fmt.Sprintf("%s (%s)", param.EnvKey, param.Prompt))
+			continue
+		}
+		envMap[param.EnvKey] = val
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf(
+			"input is disabled and the following parameters have no value; set them with --param KEY=value:\n  %s",
+			strings.Join(missing, "\n  "),
+		)
+	}
+	return nil
+}
+
+// validateArgs resolves the arguments of the root executable so that invalid or missing values are reported
+// before anything is executed.
+func validateArgs(ctx *context.Context, rootExec *executable.Executable, envMap map[string]string) error {
+	execEnv := rootExec.Env()
+	if execEnv == nil || len(execEnv.Args) == 0 {
+		return nil
+	}
+	if _, err := env.BuildArgsEnvMap(execEnv.Args, slices.Clone(ctx.Args), envMap); err != nil {
+		return fmt.Errorf("invalid arguments for %s: %w", rootExec.Ref(), err)
+	}
+	return nil
+}
+
+func applyParameterOverrides(overrides []string, envMap map[string]string) {
+	for _, override := range overrides {
+		parts := strings.SplitN(override, "=", 2)
+		if len(parts) != 2 {
+			continue // skip invalid overrides
+		}
+		key, value := parts[0], parts[1]
+		envMap[key] = value
+	}
+}
+
+// CompletedAsAnnotation is set on the command that completions are requested for to the name or alias that was
+// used to call it. Cobra only records the alias of executed commands.
+const CompletedAsAnnotation = "flow_annotation_completed_as"
+
+// completionTarget looks up the executable being completed. The verb is the alias the command is completed for.
+func completionTarget(ctx *context.Context, cmd *cobra.Command, id string) *executable.Executable {
+	verb := executable.Verb(cmd.Annotations[CompletedAsAnnotation])
+	if verb == "" {
+		verb = executable.Verb(cmd.Name())
+	}
+	ref := context.ExpandRef(ctx, executable.NewRef(id, verb))
+	e, err := ctx.ExecutableCache.GetExecutableByRef(ref)
+	if err != nil {
+		return nil
+	}
+	return e
+}
+
+// completeExecArgs returns the completions for the arguments of the given executable. Flags are completed as
+// `flag=` and values are completed for enum, bool and path arguments.
+//
 //nolint:gocognit
-func pendingFormFields(
-	ctx *context.Context, rootExec *executable.Executable, envMap map[string]string,
-) []*views.FormField {
-	pending := make([]*views.FormField, 0)
-	switch {
-	case rootExec.Exec != nil:
-		for _, param := range rootExec.Exec.Params {
-			_, exists := envMap[param.EnvKey]
-			if param.Prompt != "" && !exists {
-				pending = append(pending, &views.FormField{Key: param.EnvKey, Title: param.Prompt})
+func completeExecArgs(
+	e *executable.Executable, prevArgs []string, toComplete string,
+) ([]string, cobra.ShellCompDirective) {
+	execEnv := e.Env()
+	if execEnv == nil || len(execEnv.Args) == 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	if flag, partial, found := strings.Cut(toComplete, "="); found {
+		for _, arg := range execEnv.Args {
+			if arg.Flag == flag {
+				return completeArgValue(arg, flag+"=", partial)
 			}
 		}
-	case rootExec.Launch != nil:
-		for _, param := range rootExec.Launch.Params {
-			_, exists := envMap[param.EnvKey]
-			if param.Prompt != "" && !exists {
-				pending = append(pending, &views.FormField{Key: param.EnvKey, Title: param.Prompt})
-			}
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	setFlags := make(map[string]bool)
+	posCount := 0
+	for _, a := range prevArgs {
+		if flag, _, found := strings.Cut(a, "="); found {
+			setFlags[flag] = true
+		} else {
+			posCount++
 		}
-	case rootExec.Request != nil:
-		for _, param := range rootExec.Request.Params {
-			_, exists := envMap[param.EnvKey]
-			if param.Prompt != "" && !exists {
-				pending = append(pending, &views.FormField{Key: param.EnvKey, Title: param.Prompt})
+	}
+
+	completions := make([]string, 0)
+	directive := cobra.ShellCompDirectiveNoFileComp
+	for _, arg := range execEnv.Args {
+		switch {
+		case arg.Flag != "":
+			if setFlags[arg.Flag] && arg.Type != executable.ArgumentTypeList {
+				continue
 			}
-		}
-	case rootExec.Render != nil:
-		for _, param := range rootExec.Render.Params {
-			_, exists := envMap[param.EnvKey]
-			if param.Prompt != "" && !exists {
-				pending = append(pending, &views.FormField{Key: param.EnvKey, Title: param.Prompt})
+			if strings.HasPrefix(arg.Flag, toComplete) {
+				completions = append(completions, arg.Flag+"=")
+				directive |= cobra.ShellCompDirectiveNoSpace
 			}
+		case arg.Pos != nil && *arg.Pos == posCount+1:
+			values, valuesDirective := completeArgValue(arg, "", toComplete)
+			completions = append(completions, values...)
+			directive |= valuesDirective
 		}
-	case rootExec.Serial != nil:
-		for _, param := range rootExec.Serial.Params {
-			_, exists := envMap[param.EnvKey]
-			if param.Prompt != "" && !exists {
-				pending = append(pending, &views.FormField{Key: param.EnvKey, Title: param.Prompt})
-			}
+	}
+	return completions, directive
+}
+
+func completeArgValue(arg executable.Argument, prefix, partial string) ([]string, cobra.ShellCompDirective) {
+	var values []string
+	switch arg.Type {
+	case executable.ArgumentTypeEnum:
+		values = arg.Options
+	case executable.ArgumentTypeBool:
+		values = []string{"true", "false"}
+	case executable.ArgumentTypePath:
+		return completePath(arg, prefix, partial), cobra.ShellCompDirectiveNoSpace | cobra.ShellCompDirectiveNoFileComp
+	default:
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	completions := make([]string, 0, len(values))
+	for _, v := range values {
+		if strings.HasPrefix(v, partial) {
+			completions = append(completions, prefix+v)
 		}
-		for _, child := range rootExec.Serial.Execs {
-			if child.Ref != "" {
-				childExec, err := ctx.ExecutableCache.GetExecutableByRef(child.Ref)
-				if err != nil {
-					continue
-				}
-				childPending := pendingFormFields(ctx, childExec, envMap)
-				pending = append(pending, childPending...)
-			}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completePath lists the files and directories matching the partial path. The shell's file completion can't be
+// used since the path may be prefixed with the argument flag and is relative to the flow file directory.
+func completePath(arg executable.Argument, prefix, partial string) []string {
+	dir, base := filepath.Split(partial)
+	entries, err := os.ReadDir(arg.ExpandPath(dir))
+	if err != nil {
+		return nil
+	}
+	completions := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasPrefix(name, base) || (strings.HasPrefix(name, ".") && !strings.HasPrefix(base, ".")) {
+			continue
 		}
-	case rootExec.Parallel != nil:
-		for _, param := range rootExec.Parallel.Params {
-			if param.Prompt != "" {
-				pending = append(pending, &views.FormField{Key: param.EnvKey, Title: param.Prompt})
-			}
+		if entry.IsDir() {
+			name += string(filepath.Separator)
 		}
-		for _, child := range rootExec.Parallel.Execs {
-			if child.Ref != "" {
-				childExec, err := ctx.ExecutableCache.GetExecutableByRef(child.Ref)
-				if err != nil {
-					continue
-				}
-				childPending := pendingFormFields(ctx, childExec, envMap)
-				pending = append(pending, childPending...)
+		completions = append(completions, prefix+dir+name)
+	}
+	return completions
+}
+
+// paramKeys returns the env keys of all parameters that can be overridden for the executable, including the
+// parameters of any referenced executables.
+func paramKeys(ctx *context.Context, e *executable.Executable, visited map[executable.Ref]bool) []string {
+	if visited[e.Ref()] {
+		return nil
+	}
+	visited[e.Ref()] = true
+
+	keys := make([]string, 0)
+	if execEnv := e.Env(); execEnv != nil {
+		for _, param := range execEnv.Params {
+			if param.EnvKey != "" {
+				keys = append(keys, param.EnvKey)
 			}
 		}
 	}
-	return pending
-}
 
-func applyParameterOverrides(overrides []string, envMap map[string]string) {
-	for _, override := range overrides {
-		parts := strings.SplitN(override, "=", 2)
-		if len(parts) != 2 {
-			continue // skip invalid overrides
+	var childRefs []executable.Ref
+	switch {
+	case e.Serial != nil:
+		for _, child := range e.Serial.Execs {
+			childRefs = append(childRefs, child.Ref)
 		}
-		key, value := parts[0], parts[1]
-		envMap[key] = value
+	case e.Parallel != nil:
+		for _, child := range e.Parallel.Execs {
+			childRefs = append(childRefs, child.Ref)
+		}
+	}
+	for _, ref := range childRefs {
+		if ref == "" {
+			continue
+		}
+		childExec, err := ctx.ExecutableCache.GetExecutableByRef(ref)
+		if err != nil {
+			continue
+		}
+		keys = append(keys, paramKeys(ctx, childExec, visited)...)
 	}
+	slices.Sort(keys)
+	return keys
 }
 
 var (
@@ -35,7 +35,7 @@ var SyncCacheFlag = &Metadata{
 var FilterExecSubstringFlag = &Metadata{
 	Name:      "filter",
 	Shorthand: "f",
-	Usage:     "Filter executable by reference substring.",
+	Usage:     "Fuzzy search executables by name, aliases, tags or description.",
 	Default:   "",
 	Required:  false,
 }
@@ -141,6 +141,77 @@ var LastLogEntryFlag = &Metadata{
 	Required: false,
 }
 
+var LogRefFlag = &Metadata{
+	Name:     "ref",
+	Usage:    "Filter log entries by executable reference substring.",
+	Default:  "",
+	Required: false,
+}
+
+var LogSinceFlag = &Metadata{
+	Name:     "since",
+	Usage:    "Only show log entries created after this time. Accepts a duration (e.g. 24h) or a date (e.g. 2006-01-02).",
+	Default:  "",
+	Required: false,
+}
+
+var LogUntilFlag = &Metadata{
+	Name:     "until",
+	Usage:    "Only show log entries created before this time. Accepts a duration (e.g. 24h) or a date (e.g. 2006-01-02).",
+	Default:  "",
+	Required: false,
+}
+
+var LogStatusFlag = &Metadata{
+	Name:     "status",
+	Usage:    "Filter log entries by execution status. One of: success, failure, running.",
+	Default:  "",
+	Required: false,
+}
+
+var LogGrepFlag = &Metadata{
+	Name:     "grep",
+	Usage:    "Only show log entries with lines matching the regular expression. The matching lines are included in the output.",
+	Default:  "",
+	Required: false,
+}
+
+var LogFollowFlag = &Metadata{
+	Name:      "follow",
+	Shorthand: "f",
+	Usage:     "Follow the log of the most recent execution until it finishes.",
+	Default:   false,
+	Required:  false,
+}
+
+var PruneMaxAgeFlag = &Metadata{
+	Name:     "max-age",
+	Usage:    "Remove log entries older than this duration (e.g. 168h). Overrides the logRetention config.",
+	Default:  "",
+	Required: false,
+}
+
+var PruneMaxCountFlag = &Metadata{
+	Name:     "max-count",
+	Usage:    "Keep at most this number of log entries. Overrides the logRetention config.",
+	Default:  -1,
+	Required: false,
+}
+
+var PruneMaxSizeFlag = &Metadata{
+	Name:     "max-size",
+	Usage:    "Keep the newest log entries up to this total size (e.g. 100MB). Overrides the logRetention config.",
+	Default:  "",
+	Required: false,
+}
+
+var DryRunFlag = &Metadata{
+	Name:     "dry-run",
+	Usage:    "Print what would be done without making any changes.",
+	Default:  false,
+	Required: false,
+}
+
 var TemplateWorkspaceFlag = &Metadata{
 	Name:      "workspace",
 	Shorthand: "w",
@@ -173,6 +244,80 @@ var TemplateFilePathFlag = &Metadata{
 	Required:  false,
 }
 
+var TemplateSourceFlag = &Metadata{
+	Name: "from",
+	Usage: "Install the template from a git repository (git+URL[//SUBDIR][@REF]) or archive URL (URL[//SUBDIR]) " +
+		"instead of a local path.",
+	Default:  "",
+	Required: false,
+}
+
+var TemplateRefFlag = &Metadata{
+	Name:     "ref",
+	Usage:    "Branch, tag or commit to move the git template to.",
+	Default:  "",
+	Required: false,
+}
+
+var TemplateSetFlag = &Metadata{
+	Name: "set",
+	Usage: "Set a template form field value by key. (i.e. KEY=value) Use multiple times to set multiple fields. " +
+		"These values take precedence over the --values file.",
+	Default: []string{},
+}
+
+var TemplateValuesFlag = &Metadata{
+	Name:     "values",
+	Usage:    "Path to a YAML or JSON file with template form field values keyed by field key.",
+	Default:  "",
+	Required: false,
+}
+
+var TemplateNoInputFlag = &Metadata{
+	Name: "no-input",
+	Usage: "Disable the interactive form. Fields that are not set with --set or --values will use their default " +
+		"value and generation will fail if a required field has no value.",
+	Default:  false,
+	Required: false,
+}
+
+var TemplateOverwriteFlag = &Metadata{
+	Name:     "overwrite",
+	Usage:    "Overwrite the flow file and artifacts if they already exist. This is the default behavior.",
+	Default:  false,
+	Required: false,
+}
+
+var TemplateSkipFlag = &Metadata{
+	Name:     "skip",
+	Usage:    "Skip writing the flow file and artifacts that already exist.",
+	Default:  false,
+	Required: false,
+}
+
+var TemplateFailFlag = &Metadata{
+	Name:     "fail",
+	Usage:    "Fail if the flow file or any artifact already exists.",
+	Default:  false,
+	Required: false,
+}
+
+var TemplatePreviewFlag = &Metadata{
+	Name: "preview",
+	Usage: "Show the files that would be created or modified, with a diff against existing files, without writing " +
+		"anything. Pre-run and post-run executables are not run.",
+	Default:  false,
+	Required: false,
+}
+
+var TemplateTransactionalFlag = &Metadata{
+	Name: "transactional",
+	Usage: "Remove created files and restore overwritten files if an artifact can't be written or a post-run " +
+		"executable fails.",
+	Default:  false,
+	Required: false,
+}
+
 var SetSoundNotificationFlag = &Metadata{
 	Name:    "sound",
 	Usage:   "Update completion sound notification setting",
@@ -193,6 +338,38 @@ var ParameterValueFlag = &Metadata{
 	Default: []string{},
 }
 
+var NoInputFlag = &Metadata{
+	Name: "no-input",
+	Usage: "Disable interactive prompts. Prompt parameters will use their default value and the execution will fail " +
+		"if a value can't be resolved. Values can be provided with --param.",
+	Default:  false,
+	Required: false,
+}
+
+var YesFlag = &Metadata{
+	Name:      "yes",
+	Shorthand: "y",
+	Usage: "Skip the confirmations required by protected executables, profiles and serial steps. " +
+		"Use with --no-input to confirm runs without prompting.",
+	Default:  false,
+	Required: false,
+}
+
+var ReportFlag = &Metadata{
+	Name: "report",
+	Usage: "Write a report of the results of the executable and its serial and parallel steps. " +
+		"(i.e. junit=results.xml or json=results.json) Use multiple times to write multiple reports.",
+	Default: []string{},
+}
+
+var ProfileFlag = &Metadata{
+	Name: "profile",
+	Usage: "Name of the workspace profile to run the executable with. " +
+		"This overrides the currentProfile config setting.",
+	Default:  "",
+	Required: false,
+}
+
 var VaultSetFlag = &Metadata{
 	Name:      "set",
 	Shorthand: "s",
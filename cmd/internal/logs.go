@@ -2,9 +2,11 @@ package internal
 
 import (
 	"fmt"
+	"regexp"
+	"time"
 
+	"github.com/flowexec/tuikit"
 	tuikitIO "github.com/flowexec/tuikit/io"
-	"github.com/flowexec/tuikit/views"
 	"github.com/spf13/cobra"
 
 	"github.com/flowexec/flow/cmd/internal/flags"
@@ -12,6 +14,8 @@ import (
 	"github.com/flowexec/flow/internal/filesystem"
 	"github.com/flowexec/flow/internal/io/logs"
 	"github.com/flowexec/flow/internal/logger"
+	"github.com/flowexec/flow/internal/runner"
+	"github.com/flowexec/flow/types/config"
 )
 
 func RegisterLogsCmd(ctx *context.Context, rootCmd *cobra.Command) {
@@ -20,43 +24,203 @@ func RegisterLogsCmd(ctx *context.Context, rootCmd *cobra.Command) {
 		Aliases: []string{"log"},
 		Short:   "View execution history and logs.",
 		Args:    cobra.NoArgs,
-		PreRun:  func(cmd *cobra.Command, args []string) { StartTUI(ctx, cmd) },
-		PostRun: func(cmd *cobra.Command, args []string) { WaitForTUI(ctx, cmd) },
+		PreRun:  func(cmd *cobra.Command, args []string) { startLogsTUI(ctx, cmd) },
+		PostRun: func(cmd *cobra.Command, args []string) { waitForLogsTUI(ctx, cmd) },
 		Run: func(cmd *cobra.Command, args []string) {
 			logFunc(ctx, cmd, args)
 		},
 	}
 	RegisterFlag(ctx, subCmd, *flags.LastLogEntryFlag)
 	RegisterFlag(ctx, subCmd, *flags.OutputFormatFlag)
+	RegisterFlag(ctx, subCmd, *flags.LogRefFlag)
+	RegisterFlag(ctx, subCmd, *flags.LogSinceFlag)
+	RegisterFlag(ctx, subCmd, *flags.LogUntilFlag)
+	RegisterFlag(ctx, subCmd, *flags.LogStatusFlag)
+	RegisterFlag(ctx, subCmd, *flags.LogGrepFlag)
+	RegisterFlag(ctx, subCmd, *flags.LogFollowFlag)
+	MarkFlagMutuallyExclusive(subCmd, flags.LastLogEntryFlag.Name, flags.LogFollowFlag.Name)
+	registerPruneLogsCmd(ctx, subCmd)
 	rootCmd.AddCommand(subCmd)
 }
 
+func registerPruneLogsCmd(ctx *context.Context, logsCmd *cobra.Command) {
+	subCmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Remove log entries that exceed the retention limits.",
+		Long: "Remove the oldest log entries that exceed the logRetention limits set in the user config. " +
+			"The limits can be overridden with flags.",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			pruneLogsFunc(ctx, cmd, args)
+		},
+	}
+	RegisterFlag(ctx, subCmd, *flags.PruneMaxAgeFlag)
+	RegisterFlag(ctx, subCmd, *flags.PruneMaxCountFlag)
+	RegisterFlag(ctx, subCmd, *flags.PruneMaxSizeFlag)
+	RegisterFlag(ctx, subCmd, *flags.DryRunFlag)
+	logsCmd.AddCommand(subCmd)
+}
+
+// logsTUIEnabled reports whether the log archive view is shown. The view does not support filtering, so the entries
+// are printed instead when a filter is set.
+func logsTUIEnabled(ctx *context.Context, cmd *cobra.Command) bool {
+	if !TUIEnabled(ctx, cmd) {
+		return false
+	}
+	for _, f := range []*flags.Metadata{
+		flags.LogRefFlag, flags.LogSinceFlag, flags.LogUntilFlag, flags.LogStatusFlag, flags.LogGrepFlag,
+		flags.LogFollowFlag,
+	} {
+		if cmd.Flags().Changed(f.Name) {
+			return false
+		}
+	}
+	return true
+}
+
+func startLogsTUI(ctx *context.Context, cmd *cobra.Command) {
+	if logsTUIEnabled(ctx, cmd) {
+		StartTUI(ctx, cmd)
+	}
+}
+
+func waitForLogsTUI(ctx *context.Context, cmd *cobra.Command) {
+	if logsTUIEnabled(ctx, cmd) {
+		WaitForTUI(ctx, cmd)
+	}
+}
+
 func logFunc(ctx *context.Context, cmd *cobra.Command, _ []string) {
 	lastEntry := flags.ValueFor[bool](cmd, *flags.LastLogEntryFlag, false)
+	follow := flags.ValueFor[bool](cmd, *flags.LogFollowFlag, false)
 	outputFormat := flags.ValueFor[string](cmd, *flags.OutputFormatFlag, false)
 	if err := filesystem.EnsureLogsDir(); err != nil {
 		logger.Log().FatalErr(err)
 	}
-	if TUIEnabled(ctx, cmd) {
-		view := views.NewLogArchiveView(ctx.TUIContainer.RenderState(), filesystem.LogsDir(), lastEntry)
+	if logsTUIEnabled(ctx, cmd) {
+		var view tuikit.View = logs.NewArchiveView(ctx, filesystem.LogsDir())
+		if lastEntry {
+			entries, err := tuikitIO.ListArchiveEntries(filesystem.LogsDir())
+			if err != nil {
+				logger.Log().FatalErr(err)
+			} else if len(entries) == 0 {
+				logger.Log().Fatalf("No log entries found")
+			}
+			view = logs.NewEntryView(ctx.TUIContainer.RenderState(), entries[len(entries)-1])
+		}
 		SetView(ctx, cmd, view)
 		return
 	}
+	filter, err := logsFilter(cmd, time.Now())
+	if err != nil {
+		logger.Log().FatalErr(err)
+	}
 	entries, err := tuikitIO.ListArchiveEntries(filesystem.LogsDir())
 	if err != nil {
 		logger.Log().FatalErr(err)
 	}
 
-	if lastEntry {
-		if len(entries) == 0 {
+	switch {
+	case follow:
+		entry, found := logs.LatestExecution(logs.FilterEntries(entries, filter))
+		if !found {
+			logger.Log().Fatalf("No executions found")
+		}
+		if err := logs.Follow(ctx.Ctx, entry.Path, ctx.StdOut()); err != nil {
+			logger.Log().FatalErr(err)
+		}
+	case lastEntry:
+		entry, found := logs.LatestExecution(logs.FilterEntries(entries, filter))
+		if !found {
 			logger.Log().Fatalf("No log entries found")
 		}
-		data, err := entries[0].Read()
+		data, err := entry.Read()
 		if err != nil {
 			logger.Log().FatalErr(err)
 		}
 		_, _ = fmt.Fprint(ctx.StdOut(), data)
-	} else {
-		logs.PrintEntries(outputFormat, entries)
+	default:
+		logs.PrintEntries(outputFormat, entries, filter)
+	}
+}
+
+func logsFilter(cmd *cobra.Command, now time.Time) (logs.Filter, error) {
+	filter := logs.Filter{
+		Ref:    flags.ValueFor[string](cmd, *flags.LogRefFlag, false),
+		Status: flags.ValueFor[string](cmd, *flags.LogStatusFlag, false),
+	}
+	switch filter.Status {
+	case "", runner.StatusSuccess, runner.StatusFailure, logs.StatusRunning:
+	default:
+		return filter, fmt.Errorf("invalid status %s - must be one of: success, failure, running", filter.Status)
+	}
+	var err error
+	if filter.Since, err = parseLogTime(flags.ValueFor[string](cmd, *flags.LogSinceFlag, false), now); err != nil {
+		return filter, err
+	}
+	if filter.Until, err = parseLogTime(flags.ValueFor[string](cmd, *flags.LogUntilFlag, false), now); err != nil {
+		return filter, err
+	}
+	if pattern := flags.ValueFor[string](cmd, *flags.LogGrepFlag, false); pattern != "" {
+		if filter.Grep, err = regexp.Compile(pattern); err != nil {
+			return filter, fmt.Errorf("invalid grep pattern - %w", err)
+		}
+	}
+	return filter, nil
+}
+
+// parseLogTime parses a --since or --until value. Durations are subtracted from now.
+func parseLogTime(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(-d), nil
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02 15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %s - must be a duration (e.g. 24h) or a date (e.g. 2006-01-02)", value)
+}
+
+func pruneLogsFunc(ctx *context.Context, cmd *cobra.Command, _ []string) {
+	retention := config.LogRetention{}
+	if ctx.Config.LogRetention != nil {
+		retention = *ctx.Config.LogRetention
+	}
+	if maxAge := flags.ValueFor[string](cmd, *flags.PruneMaxAgeFlag, false); maxAge != "" {
+		d, err := time.ParseDuration(maxAge)
+		if err != nil {
+			logger.Log().FatalErr(fmt.Errorf("invalid max age %s - %w", maxAge, err))
+		}
+		retention.MaxAge = &d
+	}
+	if maxCount := flags.ValueFor[int](cmd, *flags.PruneMaxCountFlag, false); maxCount >= 0 {
+		retention.MaxCount = &maxCount
+	}
+	if maxSize := flags.ValueFor[string](cmd, *flags.PruneMaxSizeFlag, false); maxSize != "" {
+		retention.MaxSize = &maxSize
+	}
+	if retention.MaxAge == nil && retention.MaxCount == nil && retention.MaxSize == nil {
+		logger.Log().Fatalf("No log retention limits set - configure logRetention or use the prune flags")
+	}
+
+	dryRun := flags.ValueFor[bool](cmd, *flags.DryRunFlag, false)
+	if err := filesystem.EnsureLogsDir(); err != nil {
+		logger.Log().FatalErr(err)
+	}
+	removed, err := filesystem.PruneLogs(filesystem.LogsDir(), &retention, dryRun)
+	if err != nil {
+		logger.Log().FatalErr(err)
+	}
+	if dryRun {
+		for _, e := range removed {
+			logger.Log().PlainTextInfo(fmt.Sprintf("would remove %s (%s)", e.Args, e.Time.Format(time.DateTime)))
+		}
+		logger.Log().PlainTextInfo(fmt.Sprintf("%d log entries would be removed", len(removed)))
+		return
 	}
+	logger.Log().PlainTextSuccess(fmt.Sprintf("Removed %d log entries", len(removed)))
 }
@@ -0,0 +1,104 @@
+//nolint:testpackage
+package internal
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/flowexec/flow/cmd/internal/flags"
+)
+
+func TestInternal(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Cmd Internal Suite")
+}
+
+var _ = Describe("logs", func() {
+	now := time.Date(2024, 1, 10, 12, 0, 0, 0, time.Local)
+
+	Describe("parseLogTime", func() {
+		It("returns the zero time for empty values", func() {
+			t, err := parseLogTime("", now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(t.IsZero()).To(BeTrue())
+		})
+
+		It("subtracts durations from now", func() {
+			t, err := parseLogTime("90m", now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(t).To(Equal(now.Add(-90 * time.Minute)))
+		})
+
+		It("parses dates and times in the local time zone", func() {
+			t, err := parseLogTime("2024-01-02", now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(t).To(Equal(time.Date(2024, 1, 2, 0, 0, 0, 0, time.Local)))
+
+			t, err = parseLogTime("2024-01-02 15:04", now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(t).To(Equal(time.Date(2024, 1, 2, 15, 4, 0, 0, time.Local)))
+
+			t, err = parseLogTime("2024-01-02T15:04:05Z", now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(t.Equal(time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC))).To(BeTrue())
+		})
+
+		It("returns an error for invalid values", func() {
+			_, err := parseLogTime("yesterday", now)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("logsFilter", func() {
+		newLogsCmd := func(args ...string) *cobra.Command {
+			cmd := &cobra.Command{Use: "logs"}
+			RegisterFlag(nil, cmd, *flags.LogRefFlag)
+			RegisterFlag(nil, cmd, *flags.LogSinceFlag)
+			RegisterFlag(nil, cmd, *flags.LogUntilFlag)
+			RegisterFlag(nil, cmd, *flags.LogStatusFlag)
+			RegisterFlag(nil, cmd, *flags.LogGrepFlag)
+			Expect(cmd.ParseFlags(args)).To(Succeed())
+			return cmd
+		}
+
+		It("returns an empty filter when no flags are set", func() {
+			cmd := newLogsCmd()
+			filter, err := logsFilter(cmd, now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(filter.Ref).To(BeEmpty())
+			Expect(filter.Status).To(BeEmpty())
+			Expect(filter.Since.IsZero()).To(BeTrue())
+			Expect(filter.Until.IsZero()).To(BeTrue())
+			Expect(filter.Grep).To(BeNil())
+		})
+
+		It("sets the filter fields from the flags", func() {
+			cmd := newLogsCmd(
+				"--ref", "ws/app", "--status", "failure", "--since", "24h", "--until", "2024-01-10", "--grep", "err.*",
+			)
+			filter, err := logsFilter(cmd, now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(filter.Ref).To(Equal("ws/app"))
+			Expect(filter.Status).To(Equal("failure"))
+			Expect(filter.Since).To(Equal(now.Add(-24 * time.Hour)))
+			Expect(filter.Until).To(Equal(time.Date(2024, 1, 10, 0, 0, 0, 0, time.Local)))
+			Expect(filter.Grep.String()).To(Equal("err.*"))
+		})
+
+		It("returns an error for invalid flag values", func() {
+			for _, args := range [][]string{
+				{"--status", "done"},
+				{"--since", "yesterday"},
+				{"--until", "tomorrow"},
+				{"--grep", "("},
+			} {
+				_, err := logsFilter(newLogsCmd(args...), now)
+				Expect(err).To(HaveOccurred(), args)
+			}
+		})
+	})
+})
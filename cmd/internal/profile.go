@@ -0,0 +1,118 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"github.com/flowexec/flow/cmd/internal/flags"
+	"github.com/flowexec/flow/internal/context"
+	"github.com/flowexec/flow/internal/filesystem"
+	"github.com/flowexec/flow/internal/logger"
+	"github.com/flowexec/flow/internal/runner"
+	"github.com/flowexec/flow/internal/utils/env"
+	"github.com/flowexec/flow/internal/vault"
+	vaultV2 "github.com/flowexec/flow/internal/vault/v2"
+	"github.com/flowexec/flow/types/executable"
+	"github.com/flowexec/flow/types/workspace"
+)
+
+// applyProfile selects the profile that the executable is run with. The profile's vault becomes the current vault
+// and its values are added to the env map without replacing the values that are already set (e.g. `--param`).
+func applyProfile(
+	ctx *context.Context,
+	cmd *cobra.Command,
+	e *executable.Executable,
+	envMap map[string]string,
+	noInput bool,
+) error {
+	name := flags.ValueFor[string](cmd, *flags.ProfileFlag, false)
+	explicit := name != ""
+	if !explicit {
+		name = ctx.Config.CurrentProfile
+	}
+	if name == "" {
+		return nil
+	}
+
+	ws := executableWorkspace(ctx, e)
+	if !hasProfile(ws, name) {
+		if explicit {
+			return fmt.Errorf("profile %s is not defined in workspace %s", name, e.Workspace())
+		}
+		logger.Log().Debugf("profile %s is not defined in workspace %s, skipping", name, e.Workspace())
+		return nil
+	}
+	profile, err := ws.Profile(name)
+	if err != nil {
+		return err
+	}
+
+	if profile.Confirm {
+		msg := fmt.Sprintf("Run %s with the %s profile?", e.Ref(), name)
+		if err := runner.ConfirmRun(ctx, msg, ""); err != nil {
+			return err
+		}
+	}
+	if profile.Vault != "" {
+		v := profile.Vault
+		ctx.Config.CurrentVault = &v
+	}
+	ctx.CurrentProfile = name
+
+	params := profile.MergedParams()
+	if isLegacyVault(ctx) && os.Getenv(vault.EncryptionKeyEnvVar) == "" && !noInput &&
+		slices.ContainsFunc(params, func(p executable.Parameter) bool { return p.SecretRef != "" }) {
+		setAuthEnv(ctx, cmd, e, true)
+	}
+	files := slices.Clone(profile.EnvFile)
+	files.SetContext(ws.Location(), filepath.Join(ws.Location(), filesystem.WorkspaceConfigFileName))
+	profileEnv, err := env.BuildEnvMap(
+		ctx.Config.CurrentVaultName(),
+		&executable.ExecutableEnvironment{Params: params, EnvFiles: files},
+		nil, envMap, nil,
+	)
+	if err != nil {
+		return fmt.Errorf("unable to resolve values for profile %s - %w", name, err)
+	}
+	for key, val := range profileEnv {
+		if _, exists := envMap[key]; !exists {
+			envMap[key] = val
+		}
+	}
+	logger.Log().Debugf("running %s with profile %s", e.Ref(), name)
+	return nil
+}
+
+func hasProfile(ws *workspace.Workspace, name string) bool {
+	if ws == nil {
+		return false
+	}
+	_, found := ws.Profiles[name]
+	return found
+}
+
+// executableWorkspace returns the config of the workspace that the executable is defined in.
+func executableWorkspace(ctx *context.Context, e *executable.Executable) *workspace.Workspace {
+	if e.Workspace() == ctx.CurrentWorkspace.AssignedName() {
+		return ctx.CurrentWorkspace
+	}
+	wsData, err := ctx.WorkspacesCache.GetLatestData()
+	if err != nil {
+		logger.Log().Errorx("unable to load workspace cache data", "err", err)
+		return nil
+	}
+	ws, found := wsData.Workspaces[e.Workspace()]
+	if !found {
+		return nil
+	}
+	ws.SetContext(e.Workspace(), wsData.WorkspaceLocations[e.Workspace()])
+	return ws
+}
+
+func isLegacyVault(ctx *context.Context) bool {
+	return ctx.Config.CurrentVault == nil || *ctx.Config.CurrentVault == vaultV2.LegacyVaultReservedName
+}
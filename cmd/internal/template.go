@@ -2,10 +2,14 @@ package internal
 
 import (
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/flowexec/flow/cmd/internal/flags"
+	"github.com/flowexec/flow/cmd/internal/version"
 	"github.com/flowexec/flow/internal/context"
 	"github.com/flowexec/flow/internal/filesystem"
 	"github.com/flowexec/flow/internal/io/executable"
@@ -13,6 +17,7 @@ import (
 	"github.com/flowexec/flow/internal/runner"
 	"github.com/flowexec/flow/internal/runner/exec"
 	"github.com/flowexec/flow/internal/templates"
+	"github.com/flowexec/flow/internal/templates/registry"
 )
 
 func RegisterTemplateCmd(ctx *context.Context, rootCmd *cobra.Command) {
@@ -23,6 +28,7 @@ func RegisterTemplateCmd(ctx *context.Context, rootCmd *cobra.Command) {
 	}
 	registerGenerateTemplateCmd(ctx, templateCmd)
 	registerAddTemplateCmd(ctx, templateCmd)
+	registerUpdateTemplateCmd(ctx, templateCmd)
 	registerListTemplateCmd(ctx, templateCmd)
 	registerGetTemplateCmd(ctx, templateCmd)
 	rootCmd.AddCommand(templateCmd)
@@ -42,10 +48,23 @@ func registerGenerateTemplateCmd(ctx *context.Context, templateCmd *cobra.Comman
 	RegisterFlag(ctx, generateCmd, *flags.TemplateFlag)
 	RegisterFlag(ctx, generateCmd, *flags.TemplateFilePathFlag)
 	RegisterFlag(ctx, generateCmd, *flags.TemplateWorkspaceFlag)
+	RegisterFlag(ctx, generateCmd, *flags.TemplateSetFlag)
+	RegisterFlag(ctx, generateCmd, *flags.TemplateValuesFlag)
+	RegisterFlag(ctx, generateCmd, *flags.TemplateNoInputFlag)
+	RegisterFlag(ctx, generateCmd, *flags.TemplateOverwriteFlag)
+	RegisterFlag(ctx, generateCmd, *flags.TemplateSkipFlag)
+	RegisterFlag(ctx, generateCmd, *flags.TemplateFailFlag)
+	RegisterFlag(ctx, generateCmd, *flags.TemplatePreviewFlag)
+	RegisterFlag(ctx, generateCmd, *flags.TemplateTransactionalFlag)
+	RegisterFlag(ctx, generateCmd, *flags.YesFlag)
 	MarkFlagMutuallyExclusive(generateCmd, flags.TemplateFlag.Name, flags.TemplateFilePathFlag.Name)
+	MarkFlagMutuallyExclusive(
+		generateCmd, flags.TemplateOverwriteFlag.Name, flags.TemplateSkipFlag.Name, flags.TemplateFailFlag.Name,
+	)
 	MarkOneFlagRequired(generateCmd, flags.TemplateFlag.Name, flags.TemplateFilePathFlag.Name)
 	MarkFlagFilename(ctx, generateCmd, flags.TemplateFilePathFlag.Name)
 	MarkFlagFilename(ctx, generateCmd, flags.TemplateOutputPathFlag.Name)
+	MarkFlagFilename(ctx, generateCmd, flags.TemplateValuesFlag.Name)
 	templateCmd.AddCommand(generateCmd)
 }
 
@@ -69,34 +88,102 @@ func generateTemplateFunc(ctx *context.Context, cmd *cobra.Command, args []strin
 	if len(args) == 1 {
 		flowFilename = args[0]
 	}
-	if err := templates.ProcessTemplate(ctx, tmpl, ws, flowFilename, outputPath); err != nil {
+	opts, err := templateOptions(cmd)
+	if err != nil {
+		logger.Log().FatalErr(err)
+	}
+	// protected executables that are run by the template's steps are confirmed by the runner
+	ctx.SkipConfirmations = flags.ValueFor[bool](cmd, *flags.YesFlag, false)
+	ctx.DisableInput = opts.NoInput
+	if flags.ValueFor[bool](cmd, *flags.TemplatePreviewFlag, false) {
+		preview, err := templates.PreviewTemplate(ctx, tmpl, ws, flowFilename, outputPath, opts)
+		if err != nil {
+			logger.Log().FatalErr(err)
+		}
+		logger.Log().Println(preview.String())
+		return
+	}
+	if err := templates.ProcessTemplate(ctx, tmpl, ws, flowFilename, outputPath, opts); err != nil {
 		logger.Log().FatalErr(err)
 	}
 
 	logger.Log().PlainTextSuccess(fmt.Sprintf("Template '%s' rendered successfully", flowFilename))
 }
 
+func templateOptions(cmd *cobra.Command) (templates.Options, error) {
+	opts := templates.Options{
+		Values:        make(map[string]string),
+		NoInput:       flags.ValueFor[bool](cmd, *flags.TemplateNoInputFlag, false),
+		Overwrite:     templates.OverwritePolicyOverwrite,
+		Transactional: flags.ValueFor[bool](cmd, *flags.TemplateTransactionalFlag, false),
+	}
+	switch {
+	case flags.ValueFor[bool](cmd, *flags.TemplateSkipFlag, false):
+		opts.Overwrite = templates.OverwritePolicySkip
+	case flags.ValueFor[bool](cmd, *flags.TemplateFailFlag, false):
+		opts.Overwrite = templates.OverwritePolicyFail
+	}
+
+	if valuesFile := flags.ValueFor[string](cmd, *flags.TemplateValuesFlag, false); valuesFile != "" {
+		values, err := templates.LoadValuesFile(valuesFile)
+		if err != nil {
+			return opts, err
+		}
+		maps.Copy(opts.Values, values)
+	}
+	for _, set := range flags.ValueFor[[]string](cmd, *flags.TemplateSetFlag, false) {
+		key, value, found := strings.Cut(set, "=")
+		if !found || key == "" {
+			return opts, fmt.Errorf("invalid --set value %q, expected KEY=value", set)
+		}
+		opts.Values[key] = value
+	}
+	return opts, nil
+}
+
 func registerAddTemplateCmd(ctx *context.Context, templateCmd *cobra.Command) {
 	addCmd := &cobra.Command{
-		Use:     "add NAME DEFINITION_TEMPLATE_PATH",
+		Use:     "add NAME [DEFINITION_TEMPLATE_PATH] [--from SOURCE]",
 		Aliases: []string{"register", "new"},
 		Short:   "Register a flowfile template by name.",
-		Args:    cobra.ExactArgs(2),
+		Long:    templateAddLong,
+		Args:    cobra.RangeArgs(1, 2),
 		Run:     func(cmd *cobra.Command, args []string) { addTemplateFunc(ctx, cmd, args) },
 	}
+	RegisterFlag(ctx, addCmd, *flags.TemplateSourceFlag)
 	templateCmd.AddCommand(addCmd)
 }
 
-func addTemplateFunc(ctx *context.Context, _ *cobra.Command, args []string) {
+func addTemplateFunc(ctx *context.Context, cmd *cobra.Command, args []string) {
 	name := args[0]
-	flowFilePath := args[1]
-	loadedTemplates, err := filesystem.LoadFlowFileTemplate(name, flowFilePath)
-	if err != nil {
-		logger.Log().FatalErr(err)
-	}
-	if err := loadedTemplates.Validate(); err != nil {
-		logger.Log().FatalErr(err)
+	source := flags.ValueFor[string](cmd, *flags.TemplateSourceFlag, false)
+	var flowFilePath string
+	switch {
+	case len(args) == 2 && source != "":
+		logger.Log().Fatalf("either a template path or --from can be provided, not both")
+	case len(args) == 2:
+		flowFilePath = args[1]
+		loadedTemplates, err := filesystem.LoadFlowFileTemplate(name, flowFilePath)
+		if err != nil {
+			logger.Log().FatalErr(err)
+		}
+		if err := loadedTemplates.Validate(); err != nil {
+			logger.Log().FatalErr(err)
+		}
+	case source != "":
+		src, err := registry.ParseSource(source)
+		if err != nil {
+			logger.Log().FatalErr(err)
+		}
+		lock, err := registry.Install(name, src, version.Version())
+		if err != nil {
+			logger.Log().FatalErr(err)
+		}
+		flowFilePath = lock.Template
+	default:
+		logger.Log().Fatalf("a template path or --from source must be provided")
 	}
+
 	userConfig := ctx.Config
 	if userConfig.Templates == nil {
 		userConfig.Templates = map[string]string{}
@@ -108,6 +195,65 @@ func addTemplateFunc(ctx *context.Context, _ *cobra.Command, args []string) {
 	logger.Log().PlainTextSuccess(fmt.Sprintf("Template %s set to %s", name, flowFilePath))
 }
 
+func registerUpdateTemplateCmd(ctx *context.Context, templateCmd *cobra.Command) {
+	updateCmd := &cobra.Command{
+		Use:     "update [NAME...] [--ref REF]",
+		Aliases: []string{"upgrade"},
+		Short:   "Update templates that were installed from a git repository or archive.",
+		Long: "Fetch the latest version of templates that were registered with `flow template add --from`. " +
+			"If no NAME is provided, all installed templates are updated. Use --ref to move a git template to a " +
+			"different branch, tag or commit.",
+		Run: func(cmd *cobra.Command, args []string) { updateTemplateFunc(ctx, cmd, args) },
+	}
+	RegisterFlag(ctx, updateCmd, *flags.TemplateRefFlag)
+	templateCmd.AddCommand(updateCmd)
+}
+
+func updateTemplateFunc(ctx *context.Context, cmd *cobra.Command, args []string) {
+	ref := flags.ValueFor[string](cmd, *flags.TemplateRefFlag, false)
+	names := args
+	if len(names) == 0 {
+		for name := range ctx.Config.Templates {
+			if lock, err := registry.LoadLock(name); err == nil && lock != nil {
+				names = append(names, name)
+			}
+		}
+		slices.Sort(names)
+	}
+	if ref != "" && len(names) != 1 {
+		logger.Log().Fatalf("--ref can only be used when updating a single template")
+	}
+	if len(names) == 0 {
+		logger.Log().PlainTextInfo("No installed templates to update")
+		return
+	}
+
+	for _, name := range names {
+		prev, err := registry.LoadLock(name)
+		if err != nil {
+			logger.Log().FatalErr(err)
+		}
+		lock, err := registry.Update(name, ref, version.Version())
+		if err != nil {
+			logger.Log().FatalErr(err)
+		}
+		if ctx.Config.Templates[name] != lock.Template {
+			if ctx.Config.Templates == nil {
+				ctx.Config.Templates = map[string]string{}
+			}
+			ctx.Config.Templates[name] = lock.Template
+			if err := filesystem.WriteConfig(ctx.Config); err != nil {
+				logger.Log().FatalErr(err)
+			}
+		}
+		if prev != nil && prev.Resolved == lock.Resolved {
+			logger.Log().PlainTextInfo(fmt.Sprintf("Template %s is up to date (%s)", name, lock.Resolved))
+		} else {
+			logger.Log().PlainTextSuccess(fmt.Sprintf("Template %s updated to %s", name, lock.Resolved))
+		}
+	}
+}
+
 func registerListTemplateCmd(ctx *context.Context, templateCmd *cobra.Command) {
 	listCmd := &cobra.Command{
 		Use:     "list",
@@ -140,7 +286,7 @@ func listTemplateFunc(ctx *context.Context, cmd *cobra.Command, _ []string) {
 				}
 				ws := ctx.CurrentWorkspace
 				// TODO: support specifying a path/name
-				if err := templates.ProcessTemplate(ctx, tmpl, ws, tmpl.Name(), "//"); err != nil {
+				if err := templates.ProcessTemplate(ctx, tmpl, ws, tmpl.Name(), "//", templates.Options{}); err != nil {
 					return err
 				}
 				logger.Log().PlainTextSuccess("Template rendered successfully")
@@ -180,7 +326,10 @@ func getTemplateFunc(ctx *context.Context, cmd *cobra.Command, _ []string) {
 
 	outputFormat := flags.ValueFor[string](cmd, *flags.OutputFormatFlag, false)
 	if TUIEnabled(ctx, cmd) {
-		runFunc := func(ref string) error { return runByRef(ctx, cmd, ref) }
+		runFunc := func(ref string) error {
+			defer ctx.CancelFunc()
+			return runByRef(ctx, cmd, ref, nil, nil)
+		}
 		view := executable.NewTemplateView(ctx, tmpl, runFunc)
 		SetView(ctx, cmd, view)
 	} else {
@@ -195,3 +344,13 @@ The FLOWFILE_NAME is the name to give the flowfile (if applicable) when renderin
 
 One one of -f or -t must be provided and must point to a valid flowfile template.
 The -o flag can be used to specify an output path within the workspace to create the flowfile and its artifacts in.`
+
+var templateAddLong = `Register a flowfile template by name.
+
+The template can either be a local DEFINITION_TEMPLATE_PATH or be installed from a remote SOURCE with --from.
+Remote templates are cached in the flow cache directory and pinned to the fetched revision until they are updated
+with ` + "`flow template update`" + `.
+
+Supported sources:
+  git+URL[//SUBDIR][@REF]   A git repository, optionally with a subdirectory and branch, tag or commit
+  URL[//SUBDIR]             A .tar.gz, .tgz or .zip archive, optionally with a subdirectory`
@@ -53,3 +53,8 @@ OS / Arch : %s
 func String() string {
 	return generateOutput()
 }
+
+// Version returns the version number of the binary, or an empty string if it's unknown.
+func Version() string {
+	return strings.TrimSpace(version)
+}
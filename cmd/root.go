@@ -22,6 +22,9 @@ func NewRootCmd(ctx *context.Context) *cobra.Command {
 			"It's driven by executables organized across workspaces and namespaces defined in a workspace.\n\n" +
 			"See https://flowexec.io for more information.",
 		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			if cmd.Name() == cobra.ShellCompRequestCmd {
+				annotateCompletedCmd(cmd.Root(), args)
+			}
 			level := flags.ValueFor[string](cmd.Root(), *flags.LogLevel, true)
 			// TODO: make the tuikit less ambiguous about the log level
 			switch level {
@@ -47,6 +50,27 @@ func NewRootCmd(ctx *context.Context) *cobra.Command {
 	return rootCmd
 }
 
+// annotateCompletedCmd records the name or alias used to call the command that completions are requested for, so
+// that the verb of the executable being completed is known.
+func annotateCompletedCmd(rootCmd *cobra.Command, args []string) {
+	if len(args) == 0 {
+		return
+	}
+	completedCmd, _, err := rootCmd.Find(args[:len(args)-1])
+	if err != nil || completedCmd == rootCmd {
+		return
+	}
+	for _, arg := range args {
+		if arg == completedCmd.Name() || completedCmd.HasAlias(arg) {
+			if completedCmd.Annotations == nil {
+				completedCmd.Annotations = make(map[string]string)
+			}
+			completedCmd.Annotations[internal.CompletedAsAnnotation] = arg
+			return
+		}
+	}
+}
+
 func Execute(ctx *context.Context, rootCmd *cobra.Command) error {
 	if ctx == nil {
 		panic("current context is not initialized")
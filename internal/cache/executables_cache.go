@@ -181,6 +181,7 @@ func (c *ExecutableCacheImpl) GetExecutableByRef(ref executable.Ref) (*executabl
 	}
 
 	cfg.SetDefaults()
+	cfg.SetWorkspaceDefaults(c.workspaceDefaults()[wsInfo.WorkspaceName])
 	cfg.SetContext(wsInfo.WorkspaceName, wsInfo.WorkspacePath, cfgPath)
 
 	generated, err := fileparser.ExecutablesFromImports(wsInfo.WorkspaceName, cfg)
@@ -215,6 +216,7 @@ func (c *ExecutableCacheImpl) GetExecutableList() (executable.ExecutableList, er
 	}
 
 	list := make(executable.ExecutableList, 0)
+	wsDefaults := c.workspaceDefaults()
 	for cfgPath := range c.Data.ConfigMap {
 		cfg, err := filesystem.LoadFlowFile(cfgPath)
 		if err != nil {
@@ -227,6 +229,7 @@ func (c *ExecutableCacheImpl) GetExecutableList() (executable.ExecutableList, er
 			continue
 		}
 		cfg.SetDefaults()
+		cfg.SetWorkspaceDefaults(wsDefaults[wsInfo.WorkspaceName])
 		cfg.SetContext(wsInfo.WorkspaceName, wsInfo.WorkspacePath, cfgPath)
 
 		generated, err := fileparser.ExecutablesFromImports(wsInfo.WorkspaceName, cfg)
@@ -244,6 +247,23 @@ func (c *ExecutableCacheImpl) GetExecutableList() (executable.ExecutableList, er
 	return list, nil
 }
 
+// workspaceDefaults returns the default parameters of each cached workspace keyed by the workspace name.
+func (c *ExecutableCacheImpl) workspaceDefaults() map[string]executable.ParameterList {
+	defaults := make(map[string]executable.ParameterList)
+	if c.WorkspaceCache == nil {
+		return defaults
+	}
+	wsCacheData, err := c.WorkspaceCache.GetLatestData()
+	if err != nil {
+		logger.Log().Warnx("unable to load workspace defaults", "err", err)
+		return defaults
+	}
+	for name, wsCfg := range wsCacheData.Workspaces {
+		defaults[name] = wsCfg.DefaultParams()
+	}
+	return defaults
+}
+
 func (c *ExecutableCacheImpl) initExecutableCacheData() error {
 	cacheData, err := filesystem.LoadLatestCachedData(execCacheKey)
 	if err != nil {
@@ -0,0 +1,138 @@
+package cache
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/flowexec/flow/internal/filesystem"
+	"github.com/flowexec/flow/types/executable"
+)
+
+const (
+	usageCacheKey = "usage"
+
+	// maxTrackedRuns is the number of executables that the run usage is kept for. The least recently run
+	// executables are dropped first.
+	maxTrackedRuns = 200
+)
+
+// ExecutableUsage is the locally tracked run usage of an executable.
+type ExecutableUsage struct {
+	Count   int       `json:"count"   yaml:"count"`
+	LastRun time.Time `json:"lastRun" yaml:"lastRun"`
+}
+
+// UsageData is the locally tracked usage of executables. It is used to rank executables in the browse views.
+type UsageData struct {
+	// List of executable refs that are pinned as favorites, in the order that they were pinned
+	Favorites []executable.Ref `json:"favorites,omitempty" yaml:"favorites,omitempty"`
+	// Map of executable ref to its run usage
+	Runs map[executable.Ref]*ExecutableUsage `json:"runs,omitempty" yaml:"runs,omitempty"`
+}
+
+// LoadUsageData returns the tracked usage of executables. Empty usage data is returned when nothing was tracked yet.
+func LoadUsageData() (*UsageData, error) {
+	data := &UsageData{Runs: make(map[executable.Ref]*ExecutableUsage)}
+	raw, err := filesystem.LoadLatestCachedData(usageCacheKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to load usage data")
+	} else if len(raw) == 0 {
+		return data, nil
+	}
+
+	if err := yaml.Unmarshal(raw, data); err != nil {
+		return nil, errors.Wrap(err, "unable to decode usage data")
+	}
+	if data.Runs == nil {
+		data.Runs = make(map[executable.Ref]*ExecutableUsage)
+	}
+	return data, nil
+}
+
+func (d *UsageData) Save() error {
+	raw, err := yaml.Marshal(d)
+	if err != nil {
+		return errors.Wrap(err, "unable to encode usage data")
+	}
+	if err := filesystem.WriteLatestCachedData(usageCacheKey, raw); err != nil {
+		return errors.Wrap(err, "unable to write usage data")
+	}
+	return nil
+}
+
+// RecordRun records a run of the executable.
+func (d *UsageData) RecordRun(ref executable.Ref, at time.Time) {
+	if d.Runs == nil {
+		d.Runs = make(map[executable.Ref]*ExecutableUsage)
+	}
+	usage, found := d.Runs[ref]
+	if !found {
+		usage = &ExecutableUsage{}
+		d.Runs[ref] = usage
+	}
+	usage.Count++
+	usage.LastRun = at
+
+	if len(d.Runs) <= maxTrackedRuns {
+		return
+	}
+	refs := make([]executable.Ref, 0, len(d.Runs))
+	for r := range d.Runs {
+		refs = append(refs, r)
+	}
+	sort.Slice(refs, func(i, j int) bool { return d.Runs[refs[i]].LastRun.After(d.Runs[refs[j]].LastRun) })
+	for _, r := range refs[maxTrackedRuns:] {
+		delete(d.Runs, r)
+	}
+}
+
+func (d *UsageData) IsFavorite(ref executable.Ref) bool {
+	return slices.Contains(d.Favorites, ref)
+}
+
+// ToggleFavorite pins or unpins the executable as a favorite. It returns whether the executable is now a favorite.
+func (d *UsageData) ToggleFavorite(ref executable.Ref) bool {
+	if i := slices.Index(d.Favorites, ref); i >= 0 {
+		d.Favorites = slices.Delete(d.Favorites, i, i+1)
+		return false
+	}
+	d.Favorites = append(d.Favorites, ref)
+	return true
+}
+
+// Frecency returns a score for how often and how recently the executable was run. Executables that were never run
+// have a score of 0.
+func (d *UsageData) Frecency(ref executable.Ref, now time.Time) float64 {
+	usage, found := d.Runs[ref]
+	if !found || usage.Count == 0 {
+		return 0
+	}
+
+	var weight float64
+	switch age := now.Sub(usage.LastRun); {
+	case age < 24*time.Hour:
+		weight = 4
+	case age < 7*24*time.Hour:
+		weight = 2
+	case age < 30*24*time.Hour:
+		weight = 1
+	default:
+		weight = 0.5
+	}
+	return weight * math.Log2(float64(usage.Count)+1)
+}
+
+// RecordExecutableRun records a run of the executable in the tracked usage data.
+func RecordExecutableRun(ref executable.Ref) error {
+	data, err := LoadUsageData()
+	if err != nil {
+		return err
+	}
+	data.RecordRun(ref, time.Now())
+	return data.Save()
+}
@@ -0,0 +1,67 @@
+package cache_test
+
+import (
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/flowexec/flow/internal/cache"
+	"github.com/flowexec/flow/internal/filesystem"
+	"github.com/flowexec/flow/types/executable"
+)
+
+var _ = Describe("UsageData", func() {
+	var cacheDir string
+
+	BeforeEach(func() {
+		var err error
+		cacheDir, err = os.MkdirTemp("", "flow-cache-test")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.Setenv(filesystem.FlowCacheDirEnvVar, cacheDir)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(cacheDir)).To(Succeed())
+		Expect(os.Unsetenv(filesystem.FlowCacheDirEnvVar)).To(Succeed())
+	})
+
+	It("should return empty usage data when nothing was tracked", func() {
+		data, err := cache.LoadUsageData()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data.Favorites).To(BeEmpty())
+		Expect(data.Runs).To(BeEmpty())
+	})
+
+	It("should save and load runs and favorites", func() {
+		ref := executable.Ref("run ws/ns:test")
+		Expect(cache.RecordExecutableRun(ref)).To(Succeed())
+		Expect(cache.RecordExecutableRun(ref)).To(Succeed())
+
+		data, err := cache.LoadUsageData()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data.Runs[ref].Count).To(Equal(2))
+		Expect(data.ToggleFavorite(ref)).To(BeTrue())
+		Expect(data.Save()).To(Succeed())
+
+		data, err = cache.LoadUsageData()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data.IsFavorite(ref)).To(BeTrue())
+		Expect(data.ToggleFavorite(ref)).To(BeFalse())
+		Expect(data.IsFavorite(ref)).To(BeFalse())
+	})
+
+	It("should score recent and frequent runs higher", func() {
+		now := time.Now()
+		data := &cache.UsageData{}
+		data.RecordRun("run ws/recent", now.Add(-time.Hour))
+		data.RecordRun("run ws/old", now.Add(-60*24*time.Hour))
+		data.RecordRun("run ws/frequent", now.Add(-time.Hour))
+		data.RecordRun("run ws/frequent", now.Add(-time.Hour))
+
+		Expect(data.Frecency("run ws/never", now)).To(BeZero())
+		Expect(data.Frecency("run ws/recent", now)).To(BeNumerically(">", data.Frecency("run ws/old", now)))
+		Expect(data.Frecency("run ws/frequent", now)).To(BeNumerically(">", data.Frecency("run ws/recent", now)))
+	})
+})
@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync"
 
 	"github.com/flowexec/tuikit"
 	"github.com/flowexec/tuikit/themes"
@@ -35,6 +36,18 @@ type Context struct {
 	WorkspacesCache  cache.WorkspaceCache
 	ExecutableCache  cache.ExecutableCache
 
+	// CurrentProfile is the name of the workspace profile selected for the current run. It is only set by the exec
+	// command when the executable's workspace defines the profile.
+	CurrentProfile string
+
+	// SkipConfirmations is set by the exec command's --yes flag. Confirmations required by executables, profiles and
+	// serial steps are skipped when it is set.
+	SkipConfirmations bool
+
+	// DisableInput is set by the --no-input flags. Confirmations that are required while running executables fail
+	// instead of prompting for input when it is set.
+	DisableInput bool
+
 	// Args includes the command line arguments passed to the exec command. It is only populated when that command is used.
 	Args []string
 
@@ -44,6 +57,9 @@ type Context struct {
 
 	stdOut, stdIn *os.File
 	callbacks     []func(*Context) error
+
+	confirmedMu   sync.Mutex
+	confirmedRefs map[executable.Ref]struct{}
 }
 
 func NewContext(ctx context.Context, stdIn, stdOut *os.File) *Context {
@@ -147,6 +163,33 @@ func (ctx *Context) AddCallback(callback func(*Context) error) {
 	ctx.callbacks = append(ctx.callbacks, callback)
 }
 
+// MarkConfirmed records that the run of the executable was confirmed, so that an executable that is reached more
+// than once (e.g. through its inline cmd steps) is only confirmed once.
+func (ctx *Context) MarkConfirmed(ref executable.Ref) {
+	ctx.confirmedMu.Lock()
+	defer ctx.confirmedMu.Unlock()
+	if ctx.confirmedRefs == nil {
+		ctx.confirmedRefs = make(map[executable.Ref]struct{})
+	}
+	ctx.confirmedRefs[ref] = struct{}{}
+}
+
+// IsConfirmed reports whether the run of the executable was already confirmed.
+func (ctx *Context) IsConfirmed(ref executable.Ref) bool {
+	ctx.confirmedMu.Lock()
+	defer ctx.confirmedMu.Unlock()
+	_, found := ctx.confirmedRefs[ref]
+	return found
+}
+
+// ResetConfirmations forgets the confirmed executables, so that they're confirmed again when the context is used
+// for another run.
+func (ctx *Context) ResetConfirmations() {
+	ctx.confirmedMu.Lock()
+	defer ctx.confirmedMu.Unlock()
+	ctx.confirmedRefs = nil
+}
+
 func (ctx *Context) Finalize() {
 	_ = ctx.stdIn.Close()
 	_ = ctx.stdOut.Close()
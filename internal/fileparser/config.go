@@ -289,7 +289,7 @@ func parseParams(value string) (executable.ParameterList, error) {
 			return nil, fmt.Errorf("invalid parameter type: %s (expected secretRef, prompt, or text)", paramType)
 		}
 
-		if err := param.Validate(); err != nil {
+		if err := param.ValidateConfig(); err != nil {
 			return nil, fmt.Errorf("error validating parameter %d: %w", i+1, err)
 		}
 
@@ -341,7 +341,7 @@ func parseArgs(value string) (executable.ArgumentList, error) {
 			return nil, fmt.Errorf("invalid argument type: %s (expected flag or pos)", argType)
 		}
 
-		if err := arg.Validate(); err != nil {
+		if err := arg.ValidateConfig(); err != nil {
 			return nil, fmt.Errorf("error validating argument %d: %w", i+1, err)
 		}
 
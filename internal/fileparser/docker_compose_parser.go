@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 
@@ -11,76 +14,204 @@ import (
 )
 
 type composeFile struct {
-	Services map[string]any `yaml:"services"`
+	Services map[string]*composeService `yaml:"services"`
 }
 
-var composeTags = []string{generatedTag, "docker-compose"}
+type composeService struct {
+	Build    any      `yaml:"build"`
+	Profiles []string `yaml:"profiles"`
+}
+
+var (
+	composeTags = []string{generatedTag, "docker-compose"}
+
+	// composeFileName matches compose.yaml, docker-compose.yml and their variants (e.g. compose.prod.yaml)
+	composeFileName = regexp.MustCompile(`^(docker-)?compose(\.[\w-]+)*\.ya?ml$`)
+)
+
+// IsDockerComposeFile returns true if the file name follows one of the docker compose file naming conventions.
+// Override files are not compose files on their own; they are merged into the executables of their base file.
+func IsDockerComposeFile(name string) bool {
+	base := strings.ToLower(filepath.Base(name))
+	return composeFileName.MatchString(base) && !isComposeOverrideFile(base)
+}
+
+func isComposeOverrideFile(path string) bool {
+	return strings.HasSuffix(strings.TrimSuffix(path, filepath.Ext(path)), ".override")
+}
+
+// composeCmd returns a command that runs docker compose with the args. The v2 `docker compose` plugin is preferred;
+// the legacy `docker-compose` binary is only used when the plugin isn't available. The CLI is chosen when the
+// command is run so that it doesn't depend on the CLI that was installed when the executables were generated.
+func composeCmd(args string) string {
+	return fmt.Sprintf(
+		"if docker compose version >/dev/null 2>&1; then docker compose %[1]s; else docker-compose %[1]s; fi", args,
+	)
+}
 
-// ExecutablesFromDockerCompose parses a docker-compose.yml and returns list of Executables for the services
+// ExecutablesFromDockerCompose parses a docker compose file and returns list of Executables for the services.
+// If an override file (e.g. docker-compose.override.yml) exists next to the file, its services are merged in and
+// it's included in the generated commands.
 func ExecutablesFromDockerCompose(wsPath, path string) (executable.ExecutableList, error) {
-	f, err := os.Open(filepath.Clean(path))
+	cf, err := decodeComposeFile(path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open docker compose file: %w", err)
+		return nil, err
 	}
-	defer f.Close()
 
-	var cf composeFile
-	dec := yaml.NewDecoder(f)
-	if err := dec.Decode(&cf); err != nil {
-		return nil, fmt.Errorf("failed to decode docker compose file: %w", err)
+	files := []string{filepath.Base(path)}
+	if overridePath := composeOverridePath(path); overridePath != "" {
+		override, err := decodeComposeFile(overridePath)
+		if err != nil {
+			return nil, err
+		}
+		cf.merge(override)
+		files = append(files, filepath.Base(overridePath))
 	}
 
+	fileArgs := make([]string, 0, 2*len(files))
+	for _, f := range files {
+		fileArgs = append(fileArgs, "-f", f)
+	}
+	baseArgs := strings.Join(fileArgs, " ")
+
 	execs := make(executable.ExecutableList, 0)
 	dir := executable.Directory(shortenWsPath(wsPath, filepath.Dir(path)))
-	// Per-service start/build
-	for svc, data := range cf.Services {
-		execs = append(execs, &executable.Executable{
-			Name:        svc,
-			Verb:        executable.VerbStart,
-			Tags:        composeTags,
-			Description: fmt.Sprintf("Start service %s via docker-compose", svc),
+	newExec := func(verb executable.Verb, name, desc, cmd string, tags []string) *executable.Executable {
+		return &executable.Executable{
+			Verb:        verb,
+			Name:        name,
+			Tags:        tags,
+			Description: desc,
 			Exec: &executable.ExecExecutableType{
 				Dir: dir,
-				Cmd: fmt.Sprintf("docker-compose up %s", svc),
+				Cmd: cmd,
 			},
-		})
-
-		dataMap, ok := data.(map[string]any)
-		if ok && dataMap["build"] != nil {
-			execs = append(execs, &executable.Executable{
-				Name:        svc,
-				Verb:        executable.VerbBuild,
-				Tags:        composeTags,
-				Description: fmt.Sprintf("Build service %s via docker-compose", svc),
-				Exec: &executable.ExecExecutableType{
-					Dir: dir,
-					Cmd: fmt.Sprintf("docker-compose build %s", svc),
-				},
-			})
 		}
 	}
 
-	// start and stop all
-	execs = append(execs, &executable.Executable{
-		Verb:        executable.VerbStart,
-		Aliases:     []string{"all", "services"},
-		Tags:        composeTags,
-		Description: "Start all services via docker-compose",
-		Exec: &executable.ExecExecutableType{
-			Dir: dir,
-			Cmd: "docker-compose up",
-		},
-	})
-	execs = append(execs, &executable.Executable{
-		Verb:        executable.VerbStop,
-		Aliases:     []string{"all", "services"},
-		Tags:        composeTags,
-		Description: "Stop all services via docker-compose",
-		Exec: &executable.ExecExecutableType{
-			Dir: dir,
-			Cmd: "docker-compose down",
-		},
-	})
+	svcNames := make([]string, 0, len(cf.Services))
+	for svc := range cf.Services {
+		svcNames = append(svcNames, svc)
+	}
+	slices.Sort(svcNames)
+
+	profiles := make([]string, 0)
+	for _, svc := range svcNames {
+		data := cf.Services[svc]
+		tags := composeTags
+		svcArgs := baseArgs
+		if data != nil && len(data.Profiles) > 0 {
+			tags = slices.Clone(composeTags)
+			for _, p := range data.Profiles {
+				tags = append(tags, "profile:"+p)
+				svcArgs += " --profile " + p
+				if !slices.Contains(profiles, p) {
+					profiles = append(profiles, p)
+				}
+			}
+		}
+
+		execs = append(execs,
+			newExec(executable.VerbStart, svc,
+				fmt.Sprintf("Start service %s via docker compose", svc),
+				composeCmd(fmt.Sprintf("%s up %s", svcArgs, svc)), tags),
+			newExec(executable.VerbStop, svc,
+				fmt.Sprintf("Stop service %s via docker compose", svc),
+				composeCmd(fmt.Sprintf("%s stop %s", svcArgs, svc)), tags),
+			newExec(executable.VerbRestart, svc,
+				fmt.Sprintf("Restart service %s via docker compose", svc),
+				composeCmd(fmt.Sprintf("%s restart %s", svcArgs, svc)), tags),
+			newExec(executable.VerbWatch, svc,
+				fmt.Sprintf("Follow the logs of service %s via docker compose", svc),
+				composeCmd(fmt.Sprintf("%s logs -f %s", svcArgs, svc)), tags),
+			newExec(executable.VerbConnect, svc,
+				fmt.Sprintf("Open a shell in the running container of service %s via docker compose", svc),
+				composeCmd(fmt.Sprintf("%s exec %s sh", svcArgs, svc)), tags),
+		)
+
+		if data != nil && data.Build != nil {
+			execs = append(execs, newExec(executable.VerbBuild, svc,
+				fmt.Sprintf("Build service %s via docker compose", svc),
+				composeCmd(fmt.Sprintf("%s build %s", svcArgs, svc)), tags))
+		}
+	}
+
+	// start and stop all services that are not behind a profile
+	startAll := newExec(executable.VerbStart, "",
+		"Start all services via docker compose", composeCmd(baseArgs+" up"), composeTags)
+	startAll.Aliases = []string{"all", "services"}
+	stopAll := newExec(executable.VerbStop, "",
+		"Stop and remove all services via docker compose", composeCmd(baseArgs+" down"), composeTags)
+	stopAll.Aliases = []string{"all", "services"}
+	execs = append(execs, startAll, stopAll)
+
+	// start and stop all services of each profile
+	for _, p := range profiles {
+		name := "profile-" + p
+		tags := append(slices.Clone(composeTags), "profile:"+p)
+		execs = append(execs,
+			newExec(executable.VerbStart, name,
+				fmt.Sprintf("Start all services of profile %s via docker compose", p),
+				composeCmd(fmt.Sprintf("%s --profile %s up", baseArgs, p)), tags),
+			newExec(executable.VerbStop, name,
+				fmt.Sprintf("Stop and remove all services of profile %s via docker compose", p),
+				composeCmd(fmt.Sprintf("%s --profile %s down", baseArgs, p)), tags),
+		)
+	}
 
 	return execs, nil
 }
+
+func decodeComposeFile(path string) (*composeFile, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open docker compose file: %w", err)
+	}
+	defer f.Close()
+
+	var cf composeFile
+	dec := yaml.NewDecoder(f)
+	if err := dec.Decode(&cf); err != nil {
+		return nil, fmt.Errorf("failed to decode docker compose file: %w", err)
+	}
+	return &cf, nil
+}
+
+// composeOverridePath returns the path of the override file that docker compose would load alongside the
+// given file, or an empty string if there is none.
+func composeOverridePath(path string) string {
+	if isComposeOverrideFile(path) {
+		return ""
+	}
+	ext := filepath.Ext(path)
+	stem := strings.TrimSuffix(path, ext)
+	for _, e := range []string{ext, ".yaml", ".yml"} {
+		candidate := stem + ".override" + e
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate
+		}
+	}
+	return ""
+}
+
+func (cf *composeFile) merge(override *composeFile) {
+	if cf.Services == nil {
+		cf.Services = make(map[string]*composeService)
+	}
+	for name, svc := range override.Services {
+		existing, found := cf.Services[name]
+		if !found || existing == nil {
+			cf.Services[name] = svc
+			continue
+		}
+		if svc == nil {
+			continue
+		}
+		if svc.Build != nil {
+			existing.Build = svc.Build
+		}
+		if len(svc.Profiles) > 0 {
+			existing.Profiles = svc.Profiles
+		}
+	}
+}
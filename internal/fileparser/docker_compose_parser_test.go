@@ -8,6 +8,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/flowexec/flow/internal/fileparser"
+	"github.com/flowexec/flow/types/executable"
 )
 
 var _ = Describe("ExecutablesFromDockerCompose", func() {
@@ -16,20 +17,26 @@ var _ = Describe("ExecutablesFromDockerCompose", func() {
 	It("should parse docker-compose.yml", func() {
 		execs, err := fileparser.ExecutablesFromDockerCompose("", composePath)
 		Expect(err).NotTo(HaveOccurred())
-		Expect(execs).To(HaveLen(6))
+		Expect(execs).To(HaveLen(18))
 
 		found := map[string]bool{
-			"start":       false,
-			"stop":        false,
-			"start app":   false,
-			"start db":    false,
-			"start redis": false,
-			"build app":   false,
+			"start":         false,
+			"stop":          false,
+			"start app":     false,
+			"start db":      false,
+			"start redis":   false,
+			"build app":     false,
+			"stop redis":    false,
+			"restart db":    false,
+			"watch app":     false,
+			"connect redis": false,
 		}
 
 		for _, e := range execs {
 			Expect(e.Exec).NotTo(BeNil())
-			Expect(e.Exec.Cmd).To(ContainSubstring("docker-compose"))
+			Expect(e.Exec.Cmd).To(HavePrefix("if docker compose version >/dev/null 2>&1; then docker compose " +
+				"-f docker-compose.yml "))
+			Expect(e.Exec.Cmd).To(ContainSubstring("; else docker-compose -f docker-compose.yml "))
 
 			shortRef := strings.TrimSpace(fmt.Sprintf("%s %s", e.Verb, e.Name))
 			if _, ok := found[shortRef]; ok {
@@ -40,4 +47,39 @@ var _ = Describe("ExecutablesFromDockerCompose", func() {
 			Expect(found).To(BeTrue(), "executable %s not found", ref)
 		}
 	})
+
+	It("should merge the override file and respect profiles", func() {
+		execs, err := fileparser.ExecutablesFromDockerCompose("", "testdata/compose/compose.yaml")
+		Expect(err).NotTo(HaveOccurred())
+
+		byRef := make(map[string]*executable.Executable)
+		for _, e := range execs {
+			Expect(e.Exec.Cmd).To(ContainSubstring("-f compose.yaml -f compose.override.yaml"))
+			byRef[strings.TrimSpace(fmt.Sprintf("%s %s", e.Verb, e.Name))] = e
+		}
+
+		Expect(byRef).To(HaveKey("build api"))
+		Expect(byRef).To(HaveKey("build db"))
+		Expect(byRef).To(HaveKey("start mailer"))
+		Expect(byRef).NotTo(HaveKey("build debugger"))
+
+		Expect(byRef["start debugger"].Exec.Cmd).To(HaveSuffix("--profile debug up debugger; fi"))
+		Expect(byRef["start debugger"].Tags).To(ContainElement("profile:debug"))
+		Expect(byRef["start api"].Exec.Cmd).NotTo(ContainSubstring("--profile"))
+		Expect(byRef["start profile-dev"].Exec.Cmd).To(HaveSuffix("--profile dev up; fi"))
+		Expect(byRef["stop profile-debug"].Exec.Cmd).To(HaveSuffix("--profile debug down; fi"))
+	})
 })
+
+var _ = DescribeTable("IsDockerComposeFile",
+	func(name string, expected bool) {
+		Expect(fileparser.IsDockerComposeFile(name)).To(Equal(expected))
+	},
+	Entry("legacy name", "docker-compose.yml", true),
+	Entry("v2 name", "compose.yaml", true),
+	Entry("override file", "docker-compose.override.yml", false),
+	Entry("environment override file", "compose.prod.override.yaml", false),
+	Entry("environment file", "compose.prod.yml", true),
+	Entry("unrelated yaml", "config.yaml", false),
+	Entry("package.json", "package.json", false),
+)
@@ -34,8 +34,8 @@ func ExecutablesFromImports(
 			continue
 		}
 
-		switch strings.ToLower(fn) {
-		case "package.json":
+		switch name := strings.ToLower(fn); {
+		case name == "package.json":
 			execs, err := ExecutablesFromPackageJSON(wsPath, expandedFile)
 			if err != nil {
 				logger.Log().Error(err, fmt.Sprintf("unable to import executables from file (%s)", file))
@@ -45,7 +45,7 @@ func ExecutablesFromImports(
 				exec.SetInheritedFields(flowFile)
 				executables = append(executables, exec)
 			}
-		case "makefile":
+		case name == "makefile":
 			execs, err := ExecutablesFromMakefile(wsPath, expandedFile)
 			if err != nil {
 				logger.Log().Error(err, fmt.Sprintf("unable to import executables from file (%s)", file))
@@ -55,7 +55,7 @@ func ExecutablesFromImports(
 				exec.SetInheritedFields(flowFile)
 				executables = append(executables, exec)
 			}
-		case "docker-compose.yml", "docker-compose.yaml":
+		case IsDockerComposeFile(name):
 			execs, err := ExecutablesFromDockerCompose(wsPath, expandedFile)
 			if err != nil {
 				logger.Log().Error(err, fmt.Sprintf("unable to import executables from file (%s)", file))
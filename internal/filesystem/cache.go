@@ -31,6 +31,11 @@ func LatestCachedDataFilePath(cacheKey string) string {
 	return filepath.Join(LatestCachedDataDir(), cacheKey)
 }
 
+// TracesDir is the directory that execution traces are written to when they are not exported to a collector.
+func TracesDir() string {
+	return CachedDataDirPath() + "/traces"
+}
+
 func EnsureCachedDataDir() error {
 	if _, err := os.Stat(LatestCachedDataDir()); os.IsNotExist(err) {
 		err = os.MkdirAll(LatestCachedDataDir(), 0750)
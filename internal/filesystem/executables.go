@@ -59,6 +59,235 @@ func LoadFlowFile(cfgFile string) (*executable.FlowFile, error) {
 	return cfg, nil
 }
 
+// LoadExecutableDefinition returns the YAML definition of the executable as it's written in its flow file, including
+// its comments and blank lines.
+func LoadExecutableDefinition(exec *executable.Executable) (string, error) {
+	root, data, err := loadFlowFileNode(exec.FlowFilePath())
+	if err != nil {
+		return "", err
+	}
+	execs, i, err := findExecutableNode(root, exec.Verb, exec.Name)
+	if err != nil {
+		return "", err
+	}
+	lines := strings.Split(string(data), "\n")
+	r, err := findDefinitionRange(lines, root, execs, i)
+	if err != nil {
+		return "", err
+	}
+	definition := make([]string, 0, r.end-r.start)
+	for n, line := range lines[r.start:r.end] {
+		if r.start+n == r.line {
+			// the dash of the sequence item is dropped so that the definition is a mapping
+			line = strings.Repeat(" ", r.indent) + line[r.indent:]
+		}
+		definition = append(definition, line[min(r.indent, leadingSpaces(line)):])
+	}
+	return strings.Join(definition, "\n") + "\n", nil
+}
+
+// ParseExecutableDefinition decodes the YAML definition of the executable. Unknown fields are rejected and the
+// executable is validated with its defaults and the context of the current definition set.
+func ParseExecutableDefinition(exec *executable.Executable, definition string) (*executable.Executable, error) {
+	decoder := yaml.NewDecoder(strings.NewReader(definition))
+	decoder.KnownFields(true)
+	e := &executable.Executable{}
+	if err := decoder.Decode(e); err != nil {
+		return nil, errors.Wrap(err, "invalid executable definition")
+	}
+	e.SetDefaults()
+	e.SetContext(exec.Workspace(), exec.WorkspacePath(), exec.Namespace(), exec.FlowFilePath())
+	if err := e.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid executable definition")
+	}
+	return e, nil
+}
+
+// WriteExecutableDefinition replaces the definition of the executable in its flow file with the YAML definition and
+// returns the updated executable. Only the lines of the executable's entry are replaced, so the rest of the flow file
+// is kept as it's written.
+func WriteExecutableDefinition(exec *executable.Executable, definition string) (*executable.Executable, error) {
+	cfgFile := exec.FlowFilePath()
+	updated, err := ParseExecutableDefinition(exec, definition)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(filepath.Clean(cfgFile))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to stat flow file")
+	}
+	root, original, err := loadFlowFileNode(cfgFile)
+	if err != nil {
+		return nil, err
+	}
+	execs, i, err := findExecutableNode(root, exec.Verb, exec.Name)
+	if err != nil {
+		return nil, err
+	}
+	if _, j, err := findExecutableNode(root, updated.Verb, updated.Name); err == nil && j != i {
+		ref := executable.NewRef(updated.Name, updated.Verb)
+		return nil, fmt.Errorf("executable %s is already defined in %s", ref, cfgFile)
+	}
+	lines := strings.Split(string(original), "\n")
+	r, err := findDefinitionRange(lines, root, execs, i)
+	if err != nil {
+		return nil, err
+	}
+	item, err := definitionItemLines(definition, r)
+	if err != nil {
+		return nil, err
+	}
+
+	spliced := make([]string, 0, len(lines)-(r.end-r.start)+len(item))
+	spliced = append(spliced, lines[:r.start]...)
+	spliced = append(spliced, item...)
+	spliced = append(spliced, lines[r.end:]...)
+	data := []byte(strings.Join(spliced, "\n"))
+
+	var updatedRoot yaml.Node
+	if err := yaml.Unmarshal(data, &updatedRoot); err != nil {
+		return nil, errors.Wrap(err, "unable to decode updated flow file")
+	}
+	if err := updatedRoot.Decode(&executable.FlowFile{}); err != nil {
+		return nil, errors.Wrap(err, "unable to decode updated flow file")
+	}
+	if _, _, err := findExecutableNode(&updatedRoot, updated.Verb, updated.Name); err != nil {
+		return nil, errors.Wrap(err, "unable to decode updated flow file")
+	}
+	if err := os.WriteFile(filepath.Clean(cfgFile), data, info.Mode().Perm()); err != nil {
+		return nil, errors.Wrap(err, "unable to write flow file")
+	}
+	return updated, nil
+}
+
+func loadFlowFileNode(cfgFile string) (*yaml.Node, []byte, error) {
+	data, err := os.ReadFile(filepath.Clean(cfgFile))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "unable to read flow file")
+	}
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, nil, errors.Wrap(err, "unable to decode flow file")
+	}
+	return &root, data, nil
+}
+
+// findExecutableNode returns the executables sequence node of the flow file and the index of the executable in it.
+func findExecutableNode(root *yaml.Node, verb executable.Verb, name string) (*yaml.Node, int, error) {
+	notFound := fmt.Errorf("executable %s is not defined in the flow file", executable.NewRef(name, verb))
+	if root.Kind != yaml.DocumentNode || len(root.Content) == 0 || root.Content[0].Kind != yaml.MappingNode {
+		return nil, 0, notFound
+	}
+	doc := root.Content[0]
+	for i := 0; i+1 < len(doc.Content); i += 2 {
+		if doc.Content[i].Value != "executables" || doc.Content[i+1].Kind != yaml.SequenceNode {
+			continue
+		}
+		execs := doc.Content[i+1]
+		for j, node := range execs.Content {
+			e := &executable.Executable{}
+			if err := node.Decode(e); err != nil {
+				continue
+			}
+			e.SetDefaults()
+			if e.Verb.String() == verb.String() && e.Name == name {
+				return execs, j, nil
+			}
+		}
+	}
+	return nil, 0, notFound
+}
+
+// definitionRange is the range of lines [start, end) of an executable's entry in its flow file, including the
+// comments above it. line is the line of the entry's sequence dash, dash is the column of the dash and indent is the
+// column of the entry's keys.
+type definitionRange struct {
+	start, end, line int
+	dash, indent     int
+}
+
+// findDefinitionRange returns the lines of the i-th executable of the executables sequence node. Only block style
+// entries, where the first key is on the line of the dash, can be found.
+func findDefinitionRange(lines []string, root, execs *yaml.Node, i int) (definitionRange, error) {
+	item := execs.Content[i]
+	unsupported := errors.New("only block style executable definitions can be edited")
+	if execs.Style&yaml.FlowStyle != 0 || item.Kind != yaml.MappingNode || item.Style&yaml.FlowStyle != 0 {
+		return definitionRange{}, unsupported
+	}
+	r := definitionRange{line: item.Line - 1, indent: item.Column - 1}
+	if r.line >= len(lines) || r.indent > len(lines[r.line]) {
+		return definitionRange{}, unsupported
+	}
+	prefix := strings.TrimRight(lines[r.line][:r.indent], " ")
+	r.dash = len(prefix) - 1
+	if !strings.HasSuffix(prefix, "-") || strings.TrimLeft(prefix, " ") != "-" {
+		return definitionRange{}, unsupported
+	}
+
+	r.start = r.line
+	for r.start > 0 && isComment(lines[r.start-1]) && leadingSpaces(lines[r.start-1]) == r.dash {
+		r.start--
+	}
+	r.end = len(lines)
+	if i+1 < len(execs.Content) {
+		r.end = execs.Content[i+1].Line - 1
+	} else {
+		for _, node := range root.Content[0].Content {
+			if node.Line > item.Line && node.Line-1 < r.end {
+				r.end = node.Line - 1
+			}
+		}
+	}
+	// blank lines and the comments of the next entry or key are kept in place
+	for r.end > r.line+1 {
+		last := lines[r.end-1]
+		if strings.TrimSpace(last) != "" && !(isComment(last) && leadingSpaces(last) <= r.dash) {
+			break
+		}
+		r.end--
+	}
+	return r, nil
+}
+
+// definitionItemLines returns the lines of the definition as an entry of the executables sequence with the dash and
+// indentation of the given range.
+func definitionItemLines(definition string, r definitionRange) ([]string, error) {
+	lines := strings.Split(strings.TrimRight(definition, "\n"), "\n")
+	first := -1
+	for n, line := range lines {
+		if strings.TrimSpace(line) != "" && !isComment(line) {
+			first = n
+			break
+		}
+	}
+	if first < 0 {
+		return nil, errors.New("invalid executable definition: the definition is empty")
+	}
+	item := make([]string, 0, len(lines))
+	for n, line := range lines {
+		switch {
+		case strings.TrimSpace(line) == "":
+			item = append(item, "")
+		case n < first:
+			item = append(item, strings.Repeat(" ", r.dash)+line)
+		case n == first:
+			item = append(item, strings.Repeat(" ", r.dash)+"-"+strings.Repeat(" ", r.indent-r.dash-1)+line)
+		default:
+			item = append(item, strings.Repeat(" ", r.indent)+line)
+		}
+	}
+	return item, nil
+}
+
+func isComment(line string) bool {
+	return strings.HasPrefix(strings.TrimSpace(line), "#")
+}
+
+func leadingSpaces(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
 func LoadWorkspaceFlowFiles(
 	workspaceCfg *workspace.Workspace,
 ) (executable.FlowFileList, error) {
@@ -75,6 +304,7 @@ func LoadWorkspaceFlowFiles(
 			continue
 		}
 		cfg.SetDefaults()
+		cfg.SetWorkspaceDefaults(workspaceCfg.DefaultParams())
 		cfg.SetContext(workspaceCfg.AssignedName(), workspaceCfg.Location(), cfgFile)
 		cfgs = append(cfgs, cfg)
 	}
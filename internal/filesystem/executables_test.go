@@ -3,6 +3,7 @@ package filesystem_test
 import (
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/flowexec/tuikit/io/mocks"
 	. "github.com/onsi/ginkgo/v2"
@@ -154,4 +155,134 @@ var _ = Describe("Executables", func() {
 			Expect(definitions).To(BeEmpty())
 		})
 	})
+
+	Describe("LoadExecutableDefinition and WriteExecutableDefinition", func() {
+		var (
+			definitionFile string
+			buildExec      *executable.Executable
+		)
+
+		BeforeEach(func() {
+			definitionFile = filepath.Join(tmpDir, "test"+executable.FlowFileExt)
+			content := `# flow file comment
+namespace: test
+executables:
+  # build comment
+  - verb: build
+    name: app
+    exec:
+      cmd: make build # inline comment
+  - verb: test
+    name: app
+    exec:
+      cmd: make test
+`
+			Expect(os.WriteFile(definitionFile, []byte(content), 0600)).To(Succeed())
+			buildExec = &executable.Executable{Verb: "build", Name: "app"}
+			buildExec.SetContext("test", tmpDir, "test", definitionFile)
+		})
+
+		It("returns the definition of the executable with its comments", func() {
+			definition, err := filesystem.LoadExecutableDefinition(buildExec)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(definition).To(ContainSubstring("cmd: make build # inline comment"))
+			Expect(definition).NotTo(ContainSubstring("make test"))
+		})
+
+		It("writes an unchanged definition without changing the flow file", func() {
+			original, err := os.ReadFile(definitionFile)
+			Expect(err).NotTo(HaveOccurred())
+			definition, err := filesystem.LoadExecutableDefinition(buildExec)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = filesystem.WriteExecutableDefinition(buildExec, definition)
+			Expect(err).NotTo(HaveOccurred())
+
+			data, err := os.ReadFile(definitionFile)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal(string(original)))
+		})
+
+		It("replaces the definition and keeps the rest of the flow file", func() {
+			definition, err := filesystem.LoadExecutableDefinition(buildExec)
+			Expect(err).NotTo(HaveOccurred())
+			definition += "description: Builds the app\n"
+			updated, err := filesystem.WriteExecutableDefinition(buildExec, definition)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updated.Description).To(Equal("Builds the app"))
+
+			data, err := os.ReadFile(definitionFile)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(ContainSubstring("# flow file comment"))
+			Expect(string(data)).To(ContainSubstring("  # build comment\n  - verb: build"))
+			Expect(string(data)).To(ContainSubstring("cmd: make build # inline comment"))
+			Expect(string(data)).To(ContainSubstring("  - verb: test"))
+
+			flowFile, err := filesystem.LoadFlowFile(definitionFile)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(flowFile.Executables).To(HaveLen(2))
+			Expect(flowFile.Executables[0].Description).To(Equal("Builds the app"))
+			Expect(flowFile.Executables[1].Exec.Cmd).To(Equal("make test"))
+		})
+
+		It("keeps the formatting of the rest of the flow file", func() {
+			content := `namespace: test
+tags:
+- ci
+
+executables:
+# build comment
+- verb: build
+  name: app
+  exec:
+    cmd: make build
+
+# test comment
+- verb: test
+  name: app
+  tags:
+  - unit
+  exec:
+    cmd: make test
+
+visibility: private
+`
+			Expect(os.WriteFile(definitionFile, []byte(content), 0600)).To(Succeed())
+			testExec := &executable.Executable{Verb: "test", Name: "app"}
+			testExec.SetContext("test", tmpDir, "test", definitionFile)
+
+			definition, err := filesystem.LoadExecutableDefinition(testExec)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(definition).To(Equal(
+				"# test comment\nverb: test\nname: app\ntags:\n- unit\nexec:\n  cmd: make test\n",
+			))
+			definition = strings.Replace(definition, "- unit", "- unit\n- fast", 1)
+			_, err = filesystem.WriteExecutableDefinition(testExec, definition)
+			Expect(err).NotTo(HaveOccurred())
+
+			data, err := os.ReadFile(definitionFile)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal(strings.Replace(content, "  - unit", "  - unit\n  - fast", 1)))
+		})
+
+		It("rejects invalid definitions", func() {
+			for _, definition := range []string{
+				"verb: build\nname: app\nunknown: value\nexec:\n  cmd: make build\n",
+				"verb: build\nname: app\n",
+				"verb: test\nname: app\nexec:\n  cmd: make build\n",
+			} {
+				_, err := filesystem.WriteExecutableDefinition(buildExec, definition)
+				Expect(err).To(HaveOccurred(), definition)
+			}
+
+			flowFile, err := filesystem.LoadFlowFile(definitionFile)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(flowFile.Executables[0].Exec.Cmd).To(Equal("make build"))
+		})
+
+		It("returns an error for executables that are not in the flow file", func() {
+			buildExec.Name = "other"
+			_, err := filesystem.LoadExecutableDefinition(buildExec)
+			Expect(err).To(HaveOccurred())
+		})
+	})
 })
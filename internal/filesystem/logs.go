@@ -2,8 +2,12 @@ package filesystem
 
 import (
 	"os"
+	"time"
 
+	tuikitIO "github.com/flowexec/tuikit/io"
 	"github.com/pkg/errors"
+
+	"github.com/flowexec/flow/types/config"
 )
 
 func LogsDir() string {
@@ -21,3 +25,57 @@ func EnsureLogsDir() error {
 	}
 	return nil
 }
+
+// PruneLogs removes the log archive entries in dir that exceed any of the retention limits, starting with the
+// oldest. The removed entries are returned. If dryRun is true, the entries are returned without being removed.
+func PruneLogs(dir string, retention *config.LogRetention, dryRun bool) ([]tuikitIO.ArchiveEntry, error) {
+	if retention == nil {
+		return nil, nil
+	}
+	maxSize, err := retention.MaxSizeBytes()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := tuikitIO.ListArchiveEntries(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list log entries")
+	}
+
+	remove := make([]bool, len(entries))
+	if retention.MaxAge != nil && *retention.MaxAge > 0 {
+		cutoff := time.Now().Add(-*retention.MaxAge)
+		for i, e := range entries {
+			remove[i] = remove[i] || e.Time.Before(cutoff)
+		}
+	}
+	if retention.MaxCount != nil && len(entries) > *retention.MaxCount {
+		for i := range len(entries) - *retention.MaxCount {
+			remove[i] = true
+		}
+	}
+	if maxSize > 0 {
+		var total uint64
+		for i := len(entries) - 1; i >= 0; i-- {
+			info, err := os.Stat(entries[i].Path)
+			if err != nil {
+				return nil, errors.Wrap(err, "unable to stat log entry")
+			}
+			total += uint64(info.Size()) //nolint:gosec
+			remove[i] = remove[i] || total > maxSize
+		}
+	}
+
+	var removed []tuikitIO.ArchiveEntry
+	for i, e := range entries {
+		if !remove[i] {
+			continue
+		}
+		if !dryRun {
+			if err := tuikitIO.DeleteArchiveEntry(e.Path); err != nil {
+				return removed, errors.Wrap(err, "unable to remove log entry")
+			}
+		}
+		removed = append(removed, e)
+	}
+	return removed, nil
+}
@@ -1,12 +1,18 @@
 package filesystem_test
 
 import (
+	"fmt"
 	"os"
+	"path/filepath"
+	"time"
+
+	tuikitIO "github.com/flowexec/tuikit/io"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	"github.com/flowexec/flow/internal/filesystem"
+	"github.com/flowexec/flow/types/config"
 )
 
 var _ = Describe("Logs", func() {
@@ -24,4 +30,52 @@ var _ = Describe("Logs", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 	})
+
+	Describe("PruneLogs", func() {
+		var dir string
+
+		BeforeEach(func() {
+			dir = GinkgoT().TempDir()
+			for i, age := range []time.Duration{72 * time.Hour, 48 * time.Hour, time.Hour} {
+				name := fmt.Sprintf("exec+test-%d__%s.log", i, time.Now().Add(-age).Format(tuikitIO.LogEntryTimeFormat))
+				Expect(os.WriteFile(filepath.Join(dir, name), []byte("0123456789"), 0600)).To(Succeed())
+			}
+		})
+
+		It("removes the entries older than the max age", func() {
+			maxAge := 24 * time.Hour
+			removed, err := filesystem.PruneLogs(dir, &config.LogRetention{MaxAge: &maxAge}, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(removed).To(HaveLen(2))
+			entries, err := tuikitIO.ListArchiveEntries(dir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(entries).To(HaveLen(1))
+			Expect(entries[0].Args).To(Equal("exec test-2"))
+		})
+
+		It("keeps the newest entries up to the max count", func() {
+			maxCount := 2
+			removed, err := filesystem.PruneLogs(dir, &config.LogRetention{MaxCount: &maxCount}, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(removed).To(HaveLen(1))
+			Expect(removed[0].Args).To(Equal("exec test-0"))
+		})
+
+		It("keeps the newest entries up to the max size", func() {
+			maxSize := "15B"
+			removed, err := filesystem.PruneLogs(dir, &config.LogRetention{MaxSize: &maxSize}, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(removed).To(HaveLen(2))
+		})
+
+		It("does not remove entries on a dry run", func() {
+			maxCount := 0
+			removed, err := filesystem.PruneLogs(dir, &config.LogRetention{MaxCount: &maxCount}, true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(removed).To(HaveLen(3))
+			entries, err := tuikitIO.ListArchiveEntries(dir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(entries).To(HaveLen(3))
+		})
+	})
 })
@@ -38,3 +38,8 @@ func LoadFlowFileTemplates(templatePaths map[string]string) (executable.Template
 	}
 	return templates, nil
 }
+
+// TemplatesCacheDir returns the directory where templates installed from a remote source are cached.
+func TemplatesCacheDir() string {
+	return filepath.Join(CachedDataDirPath(), "templates")
+}
@@ -0,0 +1,416 @@
+package dashboard
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/flowexec/tuikit/io"
+	"github.com/flowexec/tuikit/themes"
+	"github.com/flowexec/tuikit/types"
+
+	"github.com/flowexec/flow/internal/logger"
+	"github.com/flowexec/flow/internal/runner"
+)
+
+const (
+	ViewType = "execution-dashboard"
+
+	// maxLogLines is the number of output lines that are kept for each step.
+	maxLogLines = 1000
+	// tailLines is the number of output lines that are shown below the running and selected steps.
+	tailLines = 3
+)
+
+type State string
+
+const (
+	StatePending   State = "pending"
+	StateRunning   State = "running"
+	StateRetrying  State = "retrying"
+	StateSucceeded State = "succeeded"
+	StateFailed    State = "failed"
+	StateSkipped   State = "skipped"
+)
+
+type stepKey struct {
+	parent string
+	index  int
+}
+
+type step struct {
+	*runner.Step
+
+	depth      int
+	state      State
+	attempt    int
+	start, end time.Time
+	err        error
+	lines      []string
+	partial    string
+}
+
+func (s *step) elapsed() time.Duration {
+	switch {
+	case s.start.IsZero():
+		return 0
+	case s.end.IsZero():
+		return time.Since(s.start)
+	default:
+		return s.end.Sub(s.start)
+	}
+}
+
+// Dashboard is a view of the progress of the serial and parallel steps of an executable. It is notified of the
+// progress as the runner.StepObserver and captures the output of the steps so that it is not written over the view.
+type Dashboard struct {
+	ref    string
+	base   io.Logger
+	theme  themes.Theme
+	width  int
+	height int
+
+	mu       sync.RWMutex
+	steps    []*step
+	index    map[stepKey]*step
+	selected int
+	focused  bool
+	finished bool
+	logView  viewport.Model
+}
+
+func NewDashboard(state *types.RenderState, ref string, base io.Logger) *Dashboard {
+	return &Dashboard{
+		ref:     ref,
+		base:    base,
+		theme:   state.Theme,
+		width:   state.ContentWidth,
+		height:  state.ContentHeight,
+		index:   make(map[stepKey]*step),
+		logView: viewport.New(state.ContentWidth, state.ContentHeight),
+	}
+}
+
+func (d *Dashboard) StepAdded(s *runner.Step) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.add(&step{Step: s, state: StatePending})
+}
+
+func (d *Dashboard) StepSkipped(s *runner.Step) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.add(&step{Step: s, state: StateSkipped})
+}
+
+// add inserts the step after the last step of its parent executable so that nested steps are listed below the step
+// that runs them. It must be called with the lock held.
+func (d *Dashboard) add(s *step) {
+	key := stepKey{parent: s.Parent, index: s.Index}
+	if _, found := d.index[key]; found {
+		return
+	}
+	d.index[key] = s
+	pos := len(d.steps)
+	for i := len(d.steps) - 1; i >= 0; i-- {
+		if d.steps[i].Parent == s.Parent {
+			pos = i + 1
+			s.depth = d.steps[i].depth
+			break
+		}
+		if d.steps[i].ID == s.Parent && d.steps[i].state != StatePending && d.steps[i].state != StateSkipped {
+			pos = i + 1
+			s.depth = d.steps[i].depth + 1
+			break
+		}
+	}
+	d.steps = append(d.steps[:pos], append([]*step{s}, d.steps[pos:]...)...)
+	if pos <= d.selected && len(d.steps) > 1 {
+		d.selected++
+	}
+}
+
+func (d *Dashboard) StepStarted(s *runner.Step, attempt int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	st, found := d.index[stepKey{parent: s.Parent, index: s.Index}]
+	if !found {
+		return
+	}
+	st.attempt = attempt
+	st.err = nil
+	if attempt == 1 {
+		st.state = StateRunning
+		st.start = time.Now()
+	} else {
+		st.state = StateRetrying
+	}
+}
+
+func (d *Dashboard) StepFinished(s *runner.Step, attempt int, err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	st, found := d.index[stepKey{parent: s.Parent, index: s.Index}]
+	if !found {
+		return
+	}
+	st.err = err
+	switch {
+	case err == nil:
+		st.state = StateSucceeded
+		st.end = time.Now()
+	case attempt <= s.Retries:
+		st.state = StateRetrying
+	default:
+		st.state = StateFailed
+		st.end = time.Now()
+	}
+}
+
+// StepLogger returns a logger that captures the output of the step instead of writing it to l. The output is also
+// written to the log archive as debug messages.
+func (d *Dashboard) StepLogger(s *runner.Step, _ io.Logger) io.Logger {
+	key := stepKey{parent: s.Parent, index: s.Index}
+	return logger.NewCaptureLogger(d.base, func(out logger.Output) {
+		text := out.Text()
+		d.appendOutput(key, text)
+		d.base.Debugx(strings.TrimRight(text, "\n"), "step", s.Label)
+	})
+}
+
+func (d *Dashboard) appendOutput(key stepKey, data string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	st, found := d.index[key]
+	if !found {
+		return
+	}
+	data = st.partial + data
+	lines := strings.Split(data, "\n")
+	st.partial = lines[len(lines)-1]
+	for _, line := range lines[:len(lines)-1] {
+		st.lines = append(st.lines, strings.TrimRight(line, "\r"))
+	}
+	if len(st.lines) > maxLogLines {
+		st.lines = st.lines[len(st.lines)-maxLogLines:]
+	}
+}
+
+// Finish marks the steps that were not run as skipped. The view no longer accepts cancellations.
+func (d *Dashboard) Finish() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.finished = true
+	for _, st := range d.steps {
+		switch st.state {
+		case StatePending:
+			st.state = StateSkipped
+		case StateRunning, StateRetrying:
+			st.state = StateFailed
+			st.end = time.Now()
+		case StateSucceeded, StateFailed, StateSkipped:
+		}
+	}
+}
+
+// CancelAll cancels the steps that have not completed.
+func (d *Dashboard) CancelAll() {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	for _, st := range d.steps {
+		if st.Step != nil && !d.finished {
+			st.Cancel()
+		}
+	}
+}
+
+func (d *Dashboard) Init() tea.Cmd {
+	return nil
+}
+
+//nolint:gocognit
+func (d *Dashboard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case *types.RenderState:
+		d.mu.Lock()
+		d.width, d.height = msg.ContentWidth, msg.ContentHeight
+		d.logView.Width, d.logView.Height = d.width, d.height
+		d.mu.Unlock()
+	case tea.QuitMsg:
+		d.CancelAll()
+	case tea.KeyMsg:
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		switch msg.String() {
+		case "up", "k":
+			if d.focused {
+				d.logView.ScrollUp(1)
+			} else if d.selected > 0 {
+				d.selected--
+			}
+		case "down", "j":
+			if d.focused {
+				d.logView.ScrollDown(1)
+			} else if d.selected < len(d.steps)-1 {
+				d.selected++
+			}
+		case "enter", "l":
+			if len(d.steps) > 0 {
+				d.focused = !d.focused
+				d.setLogContent()
+				d.logView.GotoBottom()
+			}
+		case "x":
+			if st := d.selectedStep(); st != nil && !d.finished && st.end.IsZero() && st.ID != "" {
+				st.Cancel()
+			}
+		}
+	}
+	return d, nil
+}
+
+func (d *Dashboard) selectedStep() *step {
+	if d.selected < 0 || d.selected >= len(d.steps) {
+		return nil
+	}
+	return d.steps[d.selected]
+}
+
+// setLogContent must be called with the lock held.
+func (d *Dashboard) setLogContent() {
+	st := d.selectedStep()
+	if st == nil {
+		return
+	}
+	atBottom := d.logView.AtBottom()
+	lines := st.lines
+	if st.partial != "" {
+		lines = append(lines[:len(lines):len(lines)], st.partial)
+	}
+	content := strings.Join(lines, "\n")
+	if content == "" {
+		content = d.theme.RenderUnknown("no output")
+	}
+	d.logView.SetContent(content)
+	if atBottom {
+		d.logView.GotoBottom()
+	}
+}
+
+func (d *Dashboard) View() string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.focused {
+		d.setLogContent()
+		st := d.selectedStep()
+		header := d.theme.RenderBold(fmt.Sprintf("%s %s", d.stateIcon(st.state), st.Label))
+		if st.err != nil {
+			header += " " + d.theme.RenderError(ansi.Truncate(st.err.Error(), max(d.width-len(st.Label)-3, 10), "…"))
+		}
+		d.logView.Height = max(d.height-1, 1)
+		return lipgloss.JoinVertical(lipgloss.Left, header, d.logView.View())
+	}
+
+	var lines []string
+	selectedLine := 0
+	for i, st := range d.steps {
+		if i == d.selected {
+			selectedLine = len(lines)
+		}
+		lines = append(lines, d.renderStep(st, i == d.selected))
+		if i == d.selected || st.state == StateRunning || st.state == StateRetrying {
+			indent := strings.Repeat("  ", st.depth+2)
+			for _, line := range tail(st) {
+				lines = append(lines, d.theme.RenderUnknown(ansi.Truncate(indent+ansi.Strip(line), d.width, "…")))
+			}
+		}
+	}
+	if len(lines) == 0 {
+		return d.theme.RenderUnknown(fmt.Sprintf("waiting for the steps of %s...", d.ref))
+	}
+	if d.height > 0 && len(lines) > d.height {
+		start := min(max(selectedLine-d.height/2, 0), len(lines)-d.height)
+		lines = lines[start : start+d.height]
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (d *Dashboard) renderStep(st *step, selected bool) string {
+	cursor := "  "
+	if selected {
+		cursor = d.theme.RenderEmphasis("> ")
+	}
+	status := string(st.state)
+	if st.attempt > 1 {
+		status = fmt.Sprintf("%s (attempt %d/%d)", status, st.attempt, st.Retries+1)
+	}
+	var elapsed string
+	if d := st.elapsed(); d > 0 {
+		elapsed = " " + d.Round(100*time.Millisecond).String()
+	}
+	label := ansi.Truncate(
+		strings.Repeat("  ", st.depth)+st.Label,
+		max(d.width-len(status)-len(elapsed)-6, 10), "…",
+	)
+	return fmt.Sprintf("%s%s %s %s%s", cursor, d.stateIcon(st.state), label, d.renderState(st.state, status), elapsed)
+}
+
+func (d *Dashboard) stateIcon(state State) string {
+	switch state {
+	case StateRunning, StateRetrying:
+		return d.theme.RenderInfo("●")
+	case StateSucceeded:
+		return d.theme.RenderSuccess("✔")
+	case StateFailed:
+		return d.theme.RenderError("✘")
+	case StatePending, StateSkipped:
+		return d.theme.RenderUnknown("○")
+	default:
+		return " "
+	}
+}
+
+func (d *Dashboard) renderState(state State, text string) string {
+	switch state {
+	case StateRunning:
+		return d.theme.RenderInfo(text)
+	case StateRetrying:
+		return d.theme.RenderWarning(text)
+	case StateSucceeded:
+		return d.theme.RenderSuccess(text)
+	case StateFailed:
+		return d.theme.RenderError(text)
+	case StatePending, StateSkipped:
+		return d.theme.RenderUnknown(text)
+	default:
+		return text
+	}
+}
+
+func tail(st *step) []string {
+	lines := st.lines
+	if st.partial != "" {
+		lines = append(lines[:len(lines):len(lines)], st.partial)
+	}
+	if len(lines) > tailLines {
+		lines = lines[len(lines)-tailLines:]
+	}
+	return lines
+}
+
+func (d *Dashboard) HelpMsg() string {
+	return "[ ↑/↓: select step ] [ enter: toggle step log ] [ x: cancel step ]"
+}
+
+func (d *Dashboard) ShowFooter() bool {
+	return true
+}
+
+func (d *Dashboard) Type() string {
+	return ViewType
+}
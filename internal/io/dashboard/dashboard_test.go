@@ -0,0 +1,180 @@
+package dashboard_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	tuikitIO "github.com/flowexec/tuikit/io"
+	"github.com/flowexec/tuikit/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/flowexec/flow/internal/io"
+	"github.com/flowexec/flow/internal/io/dashboard"
+	"github.com/flowexec/flow/internal/runner"
+)
+
+func TestDashboard(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Dashboard Suite")
+}
+
+func renderState() *types.RenderState {
+	return &types.RenderState{Width: 200, Height: 40, ContentWidth: 200, ContentHeight: 40, Theme: io.Theme("")}
+}
+
+// lines returns the stripped lines of the dashboard view without the cursor.
+func lines(d *dashboard.Dashboard) []string {
+	var result []string
+	for _, line := range strings.Split(ansi.Strip(d.View()), "\n") {
+		result = append(result, strings.TrimSpace(strings.TrimPrefix(line, "> ")))
+	}
+	return result
+}
+
+// wasCancelled reports whether the step was cancelled by running an attempt of it.
+func wasCancelled(s *runner.Step) bool {
+	ran := false
+	err := s.Track(func() error {
+		ran = true
+		return nil
+	})()
+	return !ran && err != nil
+}
+
+var _ = Describe("Dashboard", func() {
+	var d *dashboard.Dashboard
+
+	BeforeEach(func() {
+		d = dashboard.NewDashboard(renderState(), "run ws/steps", nil)
+	})
+
+	newStep := func(parent string, index int, label string, retries int) *runner.Step {
+		s := &runner.Step{Parent: parent, Index: index, ID: "exec ws/" + label, Label: label, Retries: retries}
+		d.StepAdded(s)
+		return s
+	}
+
+	Describe("adding steps", func() {
+		It("lists the steps of an executable in the order that they are added", func() {
+			newStep("run ws/steps", 1, "one", 0)
+			newStep("run ws/steps", 2, "two", 0)
+			d.StepSkipped(&runner.Step{Parent: "run ws/steps", Index: 3, Label: "three"})
+			Expect(lines(d)).To(Equal([]string{"○ one pending", "○ two pending", "○ three skipped"}))
+		})
+
+		It("doesn't add a step twice", func() {
+			s := newStep("run ws/steps", 1, "one", 0)
+			d.StepAdded(s)
+			Expect(lines(d)).To(Equal([]string{"○ one pending"}))
+		})
+
+		It("lists nested steps below the started step that runs them", func() {
+			one := newStep("run ws/steps", 1, "one", 0)
+			newStep("run ws/steps", 2, "two", 0)
+			d.StepStarted(one, 1)
+			newStep(one.ID, 1, "nested", 0)
+			Expect(lines(d)).To(HaveLen(3))
+			Expect(lines(d)[0]).To(HavePrefix("● one running"))
+			Expect(lines(d)[1:]).To(Equal([]string{"○   nested pending", "○ two pending"}))
+		})
+	})
+
+	Describe("running steps", func() {
+		It("reports the result of the step", func() {
+			one := newStep("run ws/steps", 1, "one", 0)
+			two := newStep("run ws/steps", 2, "two", 0)
+			d.StepStarted(one, 1)
+			d.StepFinished(one, 1, nil)
+			d.StepStarted(two, 1)
+			d.StepFinished(two, 1, errors.New("failed"))
+			Expect(lines(d)[0]).To(HavePrefix("✔ one succeeded"))
+			Expect(lines(d)[1]).To(HavePrefix("✘ two failed"))
+		})
+
+		It("retries the step until its attempts are used", func() {
+			s := newStep("run ws/steps", 1, "one", 1)
+			d.StepStarted(s, 1)
+			d.StepFinished(s, 1, errors.New("failed"))
+			Expect(lines(d)[0]).To(HavePrefix("● one retrying"))
+
+			d.StepStarted(s, 2)
+			Expect(lines(d)[0]).To(HavePrefix("● one retrying (attempt 2/2)"))
+
+			d.StepFinished(s, 2, errors.New("failed"))
+			Expect(lines(d)[0]).To(HavePrefix("✘ one failed (attempt 2/2)"))
+		})
+
+		It("marks the steps that didn't finish when the execution finishes", func() {
+			one := newStep("run ws/steps", 1, "one", 0)
+			newStep("run ws/steps", 2, "two", 0)
+			d.StepStarted(one, 1)
+			d.Finish()
+			Expect(lines(d)[0]).To(HavePrefix("✘ one failed"))
+			Expect(lines(d)[1]).To(Equal("○ two skipped"))
+		})
+	})
+
+	Describe("step output", func() {
+		It("shows the last lines of the output below the running step", func() {
+			out, err := os.CreateTemp(GinkgoT().TempDir(), "out")
+			Expect(err).NotTo(HaveOccurred())
+			d = dashboard.NewDashboard(renderState(), "run ws/steps", tuikitIO.NewLogger(tuikitIO.WithOutput(out)))
+			s := newStep("run ws/steps", 1, "one", 0)
+			d.StepStarted(s, 1)
+			l := d.StepLogger(s, nil)
+			l.Println("first")
+			l.Infof("second %d", 2)
+			l.Infox("third", "key", "value")
+			l.Print("fourth")
+			Expect(lines(d)[1:]).To(Equal([]string{"second 2", "third key=value", "fourth"}))
+		})
+	})
+
+	Describe("cancelling steps", func() {
+		It("cancels the selected step", func() {
+			one := runner.NewStep(context.Background(), "run ws/steps", 1, "exec ws/one", "one", 0)
+			two := runner.NewStep(context.Background(), "run ws/steps", 2, "exec ws/two", "two", 0)
+			d.StepAdded(one)
+			d.StepAdded(two)
+			d.StepStarted(two, 1)
+			d.Update(tea.KeyMsg{Type: tea.KeyDown})
+			d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+			Expect(wasCancelled(two)).To(BeTrue())
+			Expect(wasCancelled(one)).To(BeFalse())
+		})
+
+		It("doesn't cancel steps that have finished", func() {
+			s := runner.NewStep(context.Background(), "run ws/steps", 1, "exec ws/one", "one", 0)
+			d.StepAdded(s)
+			d.StepStarted(s, 1)
+			d.StepFinished(s, 1, nil)
+			d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+			Expect(wasCancelled(s)).To(BeFalse())
+		})
+
+		It("doesn't cancel steps after the execution finishes", func() {
+			s := runner.NewStep(context.Background(), "run ws/steps", 1, "exec ws/one", "one", 0)
+			d.StepAdded(s)
+			d.Finish()
+			d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+			d.CancelAll()
+			Expect(wasCancelled(s)).To(BeFalse())
+		})
+
+		It("cancels every step when the view quits", func() {
+			one := runner.NewStep(context.Background(), "run ws/steps", 1, "exec ws/one", "one", 0)
+			two := runner.NewStep(context.Background(), "run ws/steps", 2, "exec ws/two", "two", 0)
+			d.StepAdded(one)
+			d.StepAdded(two)
+			d.Update(tea.QuitMsg{})
+			Expect(wasCancelled(one)).To(BeTrue())
+			Expect(wasCancelled(two)).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,384 @@
+package executable
+
+import (
+	"bufio"
+	"fmt"
+	stdio "io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/flowexec/tuikit/themes"
+	"github.com/flowexec/tuikit/views"
+
+	"github.com/flowexec/flow/internal/context"
+	"github.com/flowexec/flow/types/executable"
+)
+
+const (
+	argFieldPrefix   = "arg:"
+	paramFieldPrefix = "param:"
+)
+
+// RunFunc runs the executable with the reference. The args are passed to it like command line arguments and the
+// params are `KEY=value` overrides, like the values of the `--param` flag.
+type RunFunc func(ref string, args, params []string) error
+
+// PromptParams returns the prompt parameters of the executable, and any referenced executables, that don't
+// have a value set in the env map yet.
+func PromptParams(
+	ctx *context.Context, rootExec *executable.Executable, envMap map[string]string,
+) executable.ParameterList {
+	pending := make(executable.ParameterList, 0)
+	seen := make(map[string]bool)
+	var collect func(e *executable.Executable)
+	collect = func(e *executable.Executable) {
+		if execEnv := e.Env(); execEnv != nil {
+			for _, param := range execEnv.Params {
+				_, exists := envMap[param.EnvKey]
+				if param.Prompt != "" && !exists && !seen[param.EnvKey] {
+					seen[param.EnvKey] = true
+					pending = append(pending, param)
+				}
+			}
+		}
+
+		var childRefs []executable.Ref
+		switch {
+		case e.Serial != nil:
+			for _, child := range e.Serial.Execs {
+				childRefs = append(childRefs, child.Ref)
+			}
+		case e.Parallel != nil:
+			for _, child := range e.Parallel.Execs {
+				childRefs = append(childRefs, child.Ref)
+			}
+		}
+		for _, ref := range childRefs {
+			if ref == "" {
+				continue
+			}
+			childExec, err := ctx.ExecutableCache.GetExecutableByRef(ref)
+			if err != nil {
+				continue
+			}
+			collect(childExec)
+		}
+	}
+	collect(rootExec)
+	return pending
+}
+
+func PromptFormField(param executable.Parameter) *views.FormField {
+	field := &views.FormField{
+		Key:            param.EnvKey,
+		Title:          param.Prompt,
+		Type:           views.PromptTypeText,
+		Default:        param.Default,
+		Placeholder:    param.Default,
+		ValidationExpr: param.Validate,
+	}
+	switch param.Type {
+	case executable.ParameterTypeMasked:
+		field.Type = views.PromptTypeMasked
+	case executable.ParameterTypeConfirm:
+		field.Type = views.PromptTypeConfirm
+	case executable.ParameterTypeSelect:
+		// tuikit forms don't have a select input so the options are listed and enforced with the validation
+		// expression instead
+		field.Description = "Options: " + strings.Join(param.Options, ", ")
+		if field.ValidationExpr == "" {
+			field.ValidationExpr = optionsExpr(param.Options, true)
+		}
+	case executable.ParameterTypeText, "":
+		// no-op
+	}
+	return field
+}
+
+// ArgFormField returns the form field for the value of the argument. The type of the argument is described and,
+// unless the argument has a validation expression, enforced for enum, int and float arguments.
+func ArgFormField(arg executable.Argument) *views.FormField {
+	field := &views.FormField{
+		Key:            argFieldKey(arg),
+		Title:          arg.Flag,
+		Type:           views.PromptTypeText,
+		Default:        arg.Default,
+		Placeholder:    arg.Default,
+		Required:       arg.Required && arg.Default == "",
+		ValidationExpr: arg.Validate,
+	}
+	if arg.Pos != nil {
+		field.Title = fmt.Sprintf("Argument %d", *arg.Pos)
+	}
+	argType := arg.Type
+	if argType == "" {
+		argType = executable.ArgumentTypeString
+	}
+	description := []string{fmt.Sprintf("Type: %s", argType)}
+	if arg.EnvKey != "" {
+		description = append(description, fmt.Sprintf("Env: %s", arg.EnvKey))
+	}
+	switch argType {
+	case executable.ArgumentTypeBool:
+		field.Type = views.PromptTypeConfirm
+	case executable.ArgumentTypeEnum:
+		description = append(description, "Options: "+strings.Join(arg.Options, ", "))
+		if field.ValidationExpr == "" {
+			field.ValidationExpr = optionsExpr(arg.Options, arg.Required)
+		}
+	case executable.ArgumentTypeInt:
+		if field.ValidationExpr == "" {
+			field.ValidationExpr = typeExpr(`-?\d+`, arg.Required)
+		}
+	case executable.ArgumentTypeFloat:
+		if field.ValidationExpr == "" {
+			field.ValidationExpr = typeExpr(`-?(\d+\.?\d*|\.\d+)`, arg.Required)
+		}
+	case executable.ArgumentTypeList:
+		description = append(description, fmt.Sprintf("Values separated by %q", arg.ListSeparator()))
+		// list values are validated individually when the executable is run
+		field.ValidationExpr = ""
+	case executable.ArgumentTypeString, executable.ArgumentTypePath, executable.ArgumentTypeDuration:
+		// no-op
+	}
+	field.Description = strings.Join(description, " | ")
+	return field
+}
+
+// optionsExpr returns a validation expression that only matches one of the options, or an empty value when the
+// value is not required.
+func optionsExpr(options []string, required bool) string {
+	quoted := make([]string, 0, len(options))
+	for _, o := range options {
+		quoted = append(quoted, regexp.QuoteMeta(o))
+	}
+	return typeExpr(strings.Join(quoted, "|"), required)
+}
+
+func typeExpr(expr string, required bool) string {
+	if required {
+		return fmt.Sprintf("^(%s)$", expr)
+	}
+	return fmt.Sprintf("^(%s)?$", expr)
+}
+
+func argFieldKey(arg executable.Argument) string {
+	if arg.Pos != nil {
+		return argFieldPrefix + strconv.Itoa(*arg.Pos)
+	}
+	return argFieldPrefix + arg.Flag
+}
+
+// RunFormFields returns the form fields for the arguments of the executable and the prompt parameters of it and the
+// executables that it references.
+func RunFormFields(ctx *context.Context, exec *executable.Executable) []*views.FormField {
+	var fields []*views.FormField
+	if execEnv := exec.Env(); execEnv != nil {
+		args := slices.Clone(execEnv.Args)
+		slices.SortStableFunc(args, func(a, b executable.Argument) int {
+			switch {
+			case a.Pos != nil && b.Pos != nil:
+				return *a.Pos - *b.Pos
+			case a.Pos != nil:
+				return -1
+			case b.Pos != nil:
+				return 1
+			default:
+				return 0
+			}
+		})
+		for _, arg := range args {
+			fields = append(fields, ArgFormField(arg))
+		}
+	}
+	for _, param := range PromptParams(ctx, exec, nil) {
+		field := PromptFormField(param)
+		field.Key = paramFieldPrefix + field.Key
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// RunInput returns the command line arguments and the parameter overrides for the values of the run form fields.
+// An error is returned when a positional argument is empty while a later one is set, since the positions would shift.
+func RunInput(exec *executable.Executable, values map[string]any) (args, params []string, err error) {
+	if arg := skippedArg(exec, values); arg != nil {
+		return nil, nil, fmt.Errorf("argument %d must be set when a later argument is set", *arg.Pos)
+	}
+	if execEnv := exec.Env(); execEnv != nil {
+		positional := make(map[int]string)
+		for _, arg := range execEnv.Args {
+			val := argValue(arg, values)
+			switch {
+			case val == "":
+				continue
+			case arg.Pos != nil:
+				positional[*arg.Pos] = val
+			case arg.Type == executable.ArgumentTypeList:
+				for _, v := range strings.Split(val, arg.ListSeparator()) {
+					args = append(args, fmt.Sprintf("%s=%s", arg.Flag, strings.TrimSpace(v)))
+				}
+			default:
+				args = append(args, fmt.Sprintf("%s=%s", arg.Flag, val))
+			}
+		}
+		ordered := make([]string, 0, len(positional))
+		for pos := 1; pos <= len(positional); pos++ {
+			ordered = append(ordered, positional[pos])
+		}
+		args = append(ordered, args...)
+	}
+	for key, val := range values {
+		if envKey, found := strings.CutPrefix(key, paramFieldPrefix); found {
+			params = append(params, fmt.Sprintf("%s=%v", envKey, val))
+		}
+	}
+	slices.Sort(params)
+	return args, params, nil
+}
+
+// skippedArg returns the first positional argument that has no value while a later positional argument is set.
+func skippedArg(exec *executable.Executable, values map[string]any) *executable.Argument {
+	execEnv := exec.Env()
+	if execEnv == nil {
+		return nil
+	}
+	set := make(map[int]bool)
+	last := 0
+	for _, arg := range execEnv.Args {
+		if arg.Pos != nil && argValue(arg, values) != "" {
+			set[*arg.Pos] = true
+			last = max(last, *arg.Pos)
+		}
+	}
+	for pos := 1; pos < last; pos++ {
+		if set[pos] {
+			continue
+		}
+		for i, arg := range execEnv.Args {
+			if arg.Pos != nil && *arg.Pos == pos {
+				return &execEnv.Args[i]
+			}
+		}
+		return &executable.Argument{Pos: &pos}
+	}
+	return nil
+}
+
+func argValue(arg executable.Argument, values map[string]any) string {
+	if v, found := values[argFieldKey(arg)]; found {
+		return fmt.Sprintf("%v", v)
+	}
+	return ""
+}
+
+// RunFinishedMsg is sent to the current view once an executable that was run from the TUI returns.
+type RunFinishedMsg struct {
+	Ref executable.Ref
+	Err error
+}
+
+// Notice returns the notice that describes the result of the run.
+func (m RunFinishedMsg) Notice() (string, themes.OutputLevel) {
+	if m.Err != nil {
+		return fmt.Sprintf("%s failed: %v", m.Ref, m.Err), themes.OutputLevelError
+	}
+	return fmt.Sprintf("%s succeeded", m.Ref), themes.OutputLevelSuccess
+}
+
+// runCommand runs the executable while the TUI has released the terminal. Its output is kept on the screen until
+// enter is pressed so that it can be read before the TUI is shown again.
+type runCommand struct {
+	ctx *context.Context
+	run func() error
+}
+
+func (c *runCommand) Run() error {
+	err := c.run()
+	msg := "Press enter to return to flow"
+	if err != nil {
+		msg = fmt.Sprintf("Run failed: %v\n%s", err, msg)
+	}
+	_, _ = fmt.Fprintf(c.ctx.StdOut(), "\n%s", msg)
+	_, _ = bufio.NewReader(c.ctx.StdIn()).ReadString('\n')
+	return err
+}
+
+func (c *runCommand) SetStdin(stdio.Reader)  {}
+func (c *runCommand) SetStdout(stdio.Writer) {}
+func (c *runCommand) SetStderr(stdio.Writer) {}
+
+// Run runs the executable in the terminal while the TUI is suspended. When the executable has arguments or prompt
+// parameters, a form for their values is shown first and the executable is run with the submitted values. Once it
+// returns, the TUI is shown again and a RunFinishedMsg with the result is sent to the current view.
+func Run(ctx *context.Context, exec *executable.Executable, runFunc RunFunc) error {
+	fields := RunFormFields(ctx, exec)
+	if len(fields) == 0 {
+		// The command is sent outside of the update loop that the run key is handled in.
+		go ctx.TUIContainer.Send(runCmd(ctx, exec, runFunc, nil, nil), 0)
+		return nil
+	}
+	form, err := runForm(ctx, exec, fields, runFunc)
+	if err != nil {
+		return err
+	}
+	// The view is set outside of the update loop that the run key is handled in since setting it sends the init
+	// command of the form to the program.
+	go func() {
+		if err := ctx.SetView(form); err != nil {
+			ctx.TUIContainer.HandleError(fmt.Errorf("unable to set view: %w", err))
+		}
+	}()
+	return nil
+}
+
+// runForm returns the form for the run fields. When the submitted values can't be used, the form is shown again with
+// the values and the error on the field that must be fixed.
+func runForm(
+	ctx *context.Context, exec *executable.Executable, fields []*views.FormField, runFunc RunFunc,
+) (*views.Form, error) {
+	form, err := views.NewFormView(ctx.TUIContainer.RenderState(), fields...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the run form: %w", err)
+	}
+	descriptions := make(map[string]string, len(fields))
+	for _, f := range fields {
+		descriptions[f.Key] = f.Description
+	}
+	form.Callback = func(values map[string]any) error {
+		args, params, err := RunInput(exec, values)
+		if err == nil {
+			go ctx.TUIContainer.Send(runCmd(ctx, exec, runFunc, args, params), 0)
+			return nil
+		}
+		errField := fields[0]
+		skipped := skippedArg(exec, values)
+		for _, f := range fields {
+			f.Description = descriptions[f.Key]
+			if skipped != nil && f.Key == argFieldKey(*skipped) {
+				errField = f
+			}
+		}
+		errField.Description = fmt.Sprintf("✘ %s\n%s", err, errField.Description)
+		retry, fErr := runForm(ctx, exec, fields, runFunc)
+		if fErr != nil {
+			return err
+		}
+		// the form is replaced with the next view once the callback returns
+		ctx.TUIContainer.SetNextView(retry)
+		return nil
+	}
+	return form, nil
+}
+
+func runCmd(ctx *context.Context, exec *executable.Executable, runFunc RunFunc, args, params []string) tea.Cmd {
+	cmd := &runCommand{ctx: ctx, run: func() error { return runFunc(exec.Ref().String(), args, params) }}
+	return tea.Exec(cmd, func(err error) tea.Msg {
+		msg := RunFinishedMsg{Ref: exec.Ref(), Err: err}
+		ctx.TUIContainer.SetNotice(msg.Notice())
+		return msg
+	})
+}
@@ -0,0 +1,96 @@
+package executable_test
+
+import (
+	"testing"
+
+	"github.com/flowexec/tuikit/views"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	execIO "github.com/flowexec/flow/internal/io/executable"
+	"github.com/flowexec/flow/types/executable"
+)
+
+func TestExecutableIO(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Executable IO Suite")
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+var _ = Describe("Run form", func() {
+	Describe("ArgFormField", func() {
+		It("should use a confirm field for bool arguments", func() {
+			field := execIO.ArgFormField(executable.Argument{Flag: "force", Type: executable.ArgumentTypeBool})
+			Expect(field.Type).To(Equal(views.PromptTypeConfirm))
+			Expect(field.Title).To(Equal("force"))
+		})
+
+		It("should enforce the options of enum arguments", func() {
+			field := execIO.ArgFormField(executable.Argument{
+				Pos: intPtr(1), Type: executable.ArgumentTypeEnum, Options: []string{"dev", "prod"}, Required: true,
+			})
+			Expect(field.Title).To(Equal("Argument 1"))
+			Expect(field.Required).To(BeTrue())
+			Expect(field.SetAndValidate("dev")).To(Succeed())
+			Expect(field.SetAndValidate("staging")).NotTo(Succeed())
+		})
+
+		It("should allow empty values for optional int arguments", func() {
+			field := execIO.ArgFormField(executable.Argument{Flag: "count", Type: executable.ArgumentTypeInt})
+			Expect(field.SetAndValidate("")).To(Succeed())
+			Expect(field.SetAndValidate("ten")).NotTo(Succeed())
+		})
+	})
+
+	Describe("RunInput", func() {
+		It("should return the arguments and params of the form values", func() {
+			exec := &executable.Executable{
+				Verb: "deploy",
+				Name: "app",
+				Exec: &executable.ExecExecutableType{
+					Cmd: "echo deploy",
+					Args: executable.ArgumentList{
+						{Pos: intPtr(2), EnvKey: "REGION"},
+						{Pos: intPtr(1), EnvKey: "ENV"},
+						{Pos: intPtr(3), EnvKey: "ZONE"},
+						{Flag: "tag", EnvKey: "TAGS", Type: executable.ArgumentTypeList},
+						{Flag: "dry-run", EnvKey: "DRY_RUN", Type: executable.ArgumentTypeBool},
+						{Flag: "note", EnvKey: "NOTE"},
+					},
+				},
+			}
+			args, params, err := execIO.RunInput(exec, map[string]any{
+				"arg:1":         "prod",
+				"arg:2":         "us",
+				"arg:3":         "",
+				"arg:tag":       "a, b",
+				"arg:dry-run":   "true",
+				"arg:note":      "",
+				"param:TOKEN":   "abc",
+				"param:CONFIRM": "false",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(args).To(Equal([]string{"prod", "us", "tag=a", "tag=b", "dry-run=true"}))
+			Expect(params).To(Equal([]string{"CONFIRM=false", "TOKEN=abc"}))
+		})
+
+		It("should reject a skipped positional argument before a set one", func() {
+			exec := &executable.Executable{
+				Verb: "deploy",
+				Name: "app",
+				Exec: &executable.ExecExecutableType{
+					Cmd: "echo deploy",
+					Args: executable.ArgumentList{
+						{Pos: intPtr(1), EnvKey: "ENV"},
+						{Pos: intPtr(2), EnvKey: "REGION"},
+					},
+				},
+			}
+			_, _, err := execIO.RunInput(exec, map[string]any{"arg:1": "", "arg:2": "us"})
+			Expect(err).To(MatchError("argument 1 must be set when a later argument is set"))
+		})
+	})
+})
@@ -12,27 +12,20 @@ import (
 
 	"github.com/flowexec/flow/internal/context"
 	"github.com/flowexec/flow/internal/io/common"
-	"github.com/flowexec/flow/internal/logger"
 	"github.com/flowexec/flow/types/executable"
 )
 
 func NewExecutableView(
 	ctx *context.Context,
 	exec *executable.Executable,
-	runFunc func(string) error,
+	runFunc RunFunc,
 ) tuikit.View {
 	container := ctx.TUIContainer
 	var executableKeyCallbacks = []types.KeyCallback{
 		{
 			Key: "r", Label: "run",
 			Callback: func() error {
-				ctx.TUIContainer.Shutdown(func() {
-					err := runFunc(exec.Ref().String())
-					if err != nil {
-						logger.Log().Error(err, "executable view runner error")
-					}
-				})
-				return nil
+				return Run(ctx, exec, runFunc)
 			},
 		},
 		{
@@ -67,7 +60,7 @@ func NewExecutableView(
 func NewExecutableListView(
 	ctx *context.Context,
 	executables executable.ExecutableList,
-	runFunc func(string) error,
+	runFunc RunFunc,
 ) tuikit.View {
 	container := ctx.TUIContainer
 	if len(executables.Items()) == 0 {
@@ -0,0 +1,120 @@
+package library
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/flowexec/tuikit/themes"
+	"github.com/flowexec/tuikit/types"
+	"github.com/flowexec/tuikit/views"
+)
+
+const (
+	editorHelp = "ctrl+s: save • esc: cancel • tab: indent"
+	// editorChromeHeight is the number of lines of the editor view that are not used by the text area.
+	editorChromeHeight = 5
+)
+
+// Editor is a view for editing the YAML definition of an executable. The definition is passed to the save function
+// when it's saved, and the editor stays open with the error when it can't be saved.
+//
+// The editor has the form view type so that the container forwards all keys to it instead of handling the quit and
+// help keys.
+type Editor struct {
+	title, description string
+	theme              themes.Theme
+	textarea           textarea.Model
+	save               func(string) error
+	err                error
+}
+
+func NewEditor(
+	state *types.RenderState, title, description, content string, save func(string) error,
+) *Editor {
+	ta := textarea.New()
+	ta.ShowLineNumbers = true
+	ta.CharLimit = 0
+	ta.MaxHeight = 0
+	ta.SetValue(strings.TrimRight(content, "\n"))
+	ta.CursorStart()
+	for ta.Line() > 0 {
+		ta.CursorUp()
+	}
+	e := &Editor{
+		title:       title,
+		description: description,
+		theme:       state.Theme,
+		textarea:    ta,
+		save:        save,
+	}
+	e.setSize(state.ContentWidth, state.ContentHeight)
+	return e
+}
+
+func (e *Editor) setSize(width, height int) {
+	e.textarea.SetWidth(max(width-2, 10))
+	e.textarea.SetHeight(max(height-editorChromeHeight, 3))
+}
+
+func (e *Editor) Init() tea.Cmd {
+	e.textarea.Focus()
+	return nil
+}
+
+func (e *Editor) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		e.setSize(msg.Width, msg.Height)
+		return e, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			return e, tea.Quit
+		case "esc":
+			return e, types.ReplaceView
+		case "ctrl+s":
+			if err := e.save(e.textarea.Value()); err != nil {
+				e.err = err
+				return e, nil
+			}
+			return e, types.ReplaceView
+		case "tab":
+			e.textarea.InsertString("  ")
+			return e, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	e.textarea, cmd = e.textarea.Update(msg)
+	return e, cmd
+}
+
+func (e *Editor) View() string {
+	title := lipgloss.NewStyle().Bold(true).Foreground(e.theme.ColorPalette().PrimaryColor()).Render(e.title)
+	status := renderInactive(editorHelp, e.theme)
+	if e.err != nil {
+		status = e.theme.RenderLevel(strings.ReplaceAll(e.err.Error(), "\n", " "), themes.OutputLevelError)
+	}
+	return lipgloss.NewStyle().Padding(0, 1).Render(lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		renderDescription(e.description, e.theme),
+		"",
+		e.textarea.View(),
+		status,
+	))
+}
+
+func (e *Editor) HelpMsg() string {
+	return editorHelp
+}
+
+func (e *Editor) ShowFooter() bool {
+	return false
+}
+
+func (e *Editor) Type() string {
+	return views.FormViewType
+}
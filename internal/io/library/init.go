@@ -3,17 +3,25 @@ package library
 
 import (
 	"slices"
-	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/flowexec/tuikit/types"
 
+	"github.com/flowexec/flow/internal/services/search"
 	"github.com/flowexec/flow/types/executable"
 	"github.com/flowexec/flow/types/workspace"
 )
 
 func (l *Library) Init() tea.Cmd {
+	if l.initialized {
+		// The library is initialized again when it's shown after the search form. The window title and tick are
+		// already set, and no command is returned since the container sends it from within the update loop.
+		l.setSize()
+		return nil
+	}
+	l.initialized = true
+
 	cmds := make([]tea.Cmd, 0)
 	cmds = append(
 		cmds,
@@ -70,20 +78,19 @@ func (l *Library) setVisibleExecs() {
 			}
 		}
 	}
+	filter := l.filter
 	l.mu.RUnlock()
 
-	filter := l.filter
 	filteredExec := l.allExecutables
 	filteredExec = filteredExec.
 		FilterByWorkspace(curWs).
 		FilterByNamespace(curNs).
 		FilterByVerb(filter.Verb).
-		FilterByTags(filter.Tags).
-		FilterBySubstring(filter.Substring)
+		FilterByTags(filter.Tags)
 
-	slices.SortFunc(filteredExec, func(i, j *executable.Executable) int {
-		return strings.Compare(i.Ref().String(), j.Ref().String())
-	})
+	l.mu.RLock()
+	filteredExec = search.Rank(filteredExec, filter.Substring, l.usage, time.Now())
+	l.mu.RUnlock()
 
 	l.mu.Lock()
 	l.visibleExecutables = filteredExec
@@ -9,7 +9,10 @@ import (
 	"github.com/flowexec/tuikit/themes"
 	"github.com/flowexec/tuikit/views"
 
+	"github.com/flowexec/flow/internal/cache"
 	"github.com/flowexec/flow/internal/context"
+	execIO "github.com/flowexec/flow/internal/io/executable"
+	"github.com/flowexec/flow/internal/logger"
 	"github.com/flowexec/flow/types/common"
 	"github.com/flowexec/flow/types/executable"
 	"github.com/flowexec/flow/types/workspace"
@@ -24,6 +27,7 @@ type Library struct {
 	termWidth, termHeight               int
 	noticeText                          string
 	showHelp, showNamespaces, splitView bool
+	initialized                         bool
 
 	visibleWorkspaces  []string
 	visibleNamespaces  []string
@@ -37,7 +41,8 @@ type Library struct {
 	currentFormat, currentHelpPage                                     uint
 	paneZeroViewport, paneOneViewport, paneTwoViewport                 viewport.Model
 
-	cmdRunFunc func(string) error
+	cmdRunFunc execIO.RunFunc
+	usage      *cache.UsageData
 
 	// Mutex to protect concurrent access to shared fields
 	mu sync.RWMutex
@@ -56,11 +61,16 @@ func NewLibrary(
 	execs executable.ExecutableList,
 	filter Filter,
 	theme themes.Theme,
-	runFunc func(string) error,
+	runFunc execIO.RunFunc,
 ) *Library {
 	p1 := viewport.New(0, 0)
 	p2 := viewport.New(0, 0)
 	p3 := viewport.New(0, 0)
+	usage, err := cache.LoadUsageData()
+	if err != nil {
+		logger.Log().Warnx("unable to load executable usage data", "err", err)
+		usage = &cache.UsageData{}
+	}
 	return &Library{
 		ctx:                ctx,
 		allWorkspaces:      workspaces,
@@ -71,6 +81,7 @@ func NewLibrary(
 		paneTwoViewport:    p3,
 		theme:              theme,
 		cmdRunFunc:         runFunc,
+		usage:              usage,
 		visibleWorkspaces:  make([]string, 0),
 		visibleNamespaces:  make([]string, 0),
 		visibleExecutables: make(executable.ExecutableList, 0),
@@ -83,7 +94,7 @@ func NewLibraryView(
 	execs executable.ExecutableList,
 	filter Filter,
 	theme themes.Theme,
-	runFunc func(string) error,
+	runFunc execIO.RunFunc,
 ) tuikit.View {
 	l := NewLibrary(ctx, workspaces, execs, filter, theme, runFunc)
 	return views.NewFrameView(l)
@@ -2,19 +2,24 @@
 package library
 
 import (
-	"os"
+	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/atotto/clipboard"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/flowexec/tuikit/io"
 	"github.com/flowexec/tuikit/themes"
+	"github.com/flowexec/tuikit/views"
 
+	"github.com/flowexec/flow/internal/cache"
 	"github.com/flowexec/flow/internal/filesystem"
 	"github.com/flowexec/flow/internal/io/common"
+	execIO "github.com/flowexec/flow/internal/io/executable"
 	"github.com/flowexec/flow/internal/logger"
 	"github.com/flowexec/flow/internal/services/open"
+	"github.com/flowexec/flow/types/executable"
 )
 
 var log io.Logger
@@ -25,6 +30,8 @@ func (l *Library) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		l.setSize()
+	case execIO.RunFinishedMsg:
+		l.SetNotice(msg.Notice())
 	case tea.KeyMsg:
 		key := msg.String()
 		switch key {
@@ -47,6 +54,8 @@ func (l *Library) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case tea.KeyTab.String():
 			l.splitView = !l.splitView
 			l.setSize()
+		case "/":
+			l.openSearchForm()
 		case "h":
 			switch {
 			case l.showHelp && l.currentHelpPage == 0:
@@ -264,12 +273,42 @@ func (l *Library) updateExecPanes(msg tea.Msg) (viewport.Model, tea.Cmd) {
 				break
 			}
 
-			l.ctx.TUIContainer.Shutdown(func() {
-				if err := l.cmdRunFunc(curExec.Ref().String()); err != nil {
-					log.Fatalx("unable to execute command", "error", err)
-				}
-			})
-			os.Exit(0) // This is necessary to prevent the app from hanging after the command is run
+			// The executable is run once the values of its arguments and prompt params are submitted.
+			if err := execIO.Run(l.ctx, curExec, l.cmdRunFunc); err != nil {
+				log.Error(err, "unable to run executable")
+				l.SetNotice("unable to run executable", themes.OutputLevelError)
+			}
+		case "i":
+			if curExec == nil {
+				l.SetNotice("no executable selected", themes.OutputLevelError)
+				break
+			}
+
+			l.openEditor(curExec)
+		case "p":
+			if curExec == nil {
+				l.SetNotice("no executable selected", themes.OutputLevelError)
+				break
+			}
+
+			l.mu.Lock()
+			pinned := l.usage.ToggleFavorite(curExec.Ref())
+			err := l.usage.Save()
+			l.mu.Unlock()
+			if err != nil {
+				log.Error(err, "unable to save favorites")
+				l.SetNotice("unable to save favorites", themes.OutputLevelError)
+				break
+			}
+
+			// Favorites are listed first so the selection follows the executable to its new position
+			l.setVisibleExecs()
+			l.selectExecutable(curExec.Ref())
+			if pinned {
+				l.SetNotice("pinned to favorites", themes.OutputLevelInfo)
+			} else {
+				l.SetNotice("unpinned from favorites", themes.OutputLevelInfo)
+			}
 		case "f":
 			if l.currentPane == 1 {
 				break
@@ -281,3 +320,104 @@ func (l *Library) updateExecPanes(msg tea.Msg) (viewport.Model, tea.Cmd) {
 
 	return pane.Update(msg)
 }
+
+// openSearchForm shows a form for the query that the executables are searched and ranked by. The library is shown
+// again once the form is submitted.
+func (l *Library) openSearchForm() {
+	l.mu.RLock()
+	query := l.filter.Substring
+	l.mu.RUnlock()
+
+	form, err := views.NewFormView(l.ctx.TUIContainer.RenderState(), &views.FormField{
+		Key:         searchFieldKey,
+		Title:       "Search executables",
+		Description: "Matches names, aliases, tags and descriptions. Submit an empty query to clear the search.",
+		Type:        views.PromptTypeText,
+		Placeholder: query,
+	})
+	if err != nil {
+		log.Error(err, "unable to create search form")
+		l.SetNotice("unable to search executables", themes.OutputLevelError)
+		return
+	}
+	form.Callback = func(values map[string]any) error {
+		l.mu.Lock()
+		l.filter.Substring = strings.TrimSpace(fmt.Sprintf("%v", values[searchFieldKey]))
+		l.currentExecutable = 0
+		l.mu.Unlock()
+		l.setVisibleExecs()
+		l.paneOneViewport.GotoTop()
+		return nil
+	}
+	// The view is set outside of the update loop since setting it sends the init command of the form to the program.
+	go func() {
+		if err := l.ctx.SetView(form); err != nil {
+			l.ctx.TUIContainer.HandleError(fmt.Errorf("unable to set view: %w", err))
+		}
+	}()
+}
+
+// openEditor shows an editor for the YAML definition of the executable. The definition is validated and written to
+// the executable's flow file when it's saved.
+func (l *Library) openEditor(exec *executable.Executable) {
+	definition, err := filesystem.LoadExecutableDefinition(exec)
+	if err != nil {
+		log.Error(err, "unable to load executable definition")
+		l.SetNotice("unable to edit executable: "+err.Error(), themes.OutputLevelError)
+		return
+	}
+
+	editor := NewEditor(
+		l.ctx.TUIContainer.RenderState(),
+		fmt.Sprintf("Edit %s", exec.Ref()),
+		exec.FlowFilePath(),
+		definition,
+		func(edited string) error {
+			updated, err := filesystem.WriteExecutableDefinition(exec, edited)
+			if err != nil {
+				return err
+			}
+			if err := l.reloadExecutables(); err != nil {
+				log.Error(err, "unable to refresh executables")
+				l.SetNotice("executable saved but the cache could not be refreshed", themes.OutputLevelWarning)
+				return nil
+			}
+			l.setVisibleExecs()
+			l.selectExecutable(updated.Ref())
+			l.SetNotice("executable saved", themes.OutputLevelInfo)
+			return nil
+		},
+	)
+	// The view is set outside of the update loop since setting it sends the init command of the view to the program.
+	go func() {
+		if err := l.ctx.SetView(editor); err != nil {
+			l.ctx.TUIContainer.HandleError(fmt.Errorf("unable to set view: %w", err))
+		}
+	}()
+}
+
+// reloadExecutables syncs the workspace and executable caches and reloads the executables of the library.
+func (l *Library) reloadExecutables() error {
+	if err := cache.UpdateAll(); err != nil {
+		return err
+	}
+	execs, err := l.ctx.ExecutableCache.GetExecutableList()
+	if err != nil {
+		return err
+	}
+	l.mu.Lock()
+	l.allExecutables = execs
+	l.mu.Unlock()
+	return nil
+}
+
+func (l *Library) selectExecutable(ref executable.Ref) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for i, e := range l.visibleExecutables {
+		if e.Ref() == ref {
+			l.currentExecutable = uint(i)
+			return
+		}
+	}
+}
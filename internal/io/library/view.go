@@ -4,6 +4,7 @@ package library
 import (
 	"fmt"
 	"math"
+	"slices"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
@@ -24,12 +25,14 @@ const (
 	allWorkspacesLabel    = "all workspaces"
 	withoutNamespaceLabel = "w/o namespace"
 	allNamespacesLabel    = "all namespaces"
+	favoriteIndicator     = "★ "
+	searchFieldKey        = "query"
 
-	containerHelp        = "[ tab: split view ] [ ↑/↓: navigate pane ] [ ←/→: change pane ]"
+	containerHelp        = "[ tab: split view ] [ ↑/↓: navigate pane ] [ ←/→: change pane ] [ /: search ]"
 	paneZeroHelp         = "[ o: open ] [ e: edit ] [ s:set ] ● [ space: show namespaces ]"
 	paneZeroExpandedHelp = "[ o: open ] [ e: edit ] [ s:set ] ● [ space: hide namespaces ]"
-	paneOneHelp          = "[ r: run ] [ e: edit ] [ c: copy ref ]"
-	paneTwoHelp          = "[ r: run ] [ e: edit ] [ c: copy ref ]  ● [ f: change format ]"
+	paneOneHelp          = "[ r: run ] [ e: edit ] [ i: edit inline ] [ c: copy ref ] [ p: pin ]"
+	paneTwoHelp          = paneOneHelp + "  ● [ f: change format ]"
 )
 
 var (
@@ -162,6 +165,10 @@ func (l *Library) paneOneContent() string {
 	var sb strings.Builder
 	l.mu.RLock()
 	sb.WriteString(renderPaneTitle("Executables", len(l.visibleExecutables), l.currentPane == 1, l.theme))
+	if l.filter.Substring != "" {
+		sb.WriteString(renderDescription(fmt.Sprintf("search: %s", l.filter.Substring), l.theme))
+		sb.WriteString("\n\n")
+	}
 	if len(l.visibleExecutables) == 0 {
 		l.mu.RUnlock()
 		sb.WriteString(l.theme.RenderError("No executables found"))
@@ -176,9 +183,14 @@ func (l *Library) paneOneContent() string {
 		curNs = l.visibleNamespaces[l.currentNamespace]
 	}
 	visibleExecutables := l.visibleExecutables
+	favorites := slices.Clone(l.usage.Favorites)
 	l.mu.RUnlock()
 
 	for i, ex := range visibleExecutables {
+		label := shortRef(ex.Ref(), curWs, curNs)
+		if slices.Contains(favorites, ex.Ref()) {
+			label = favoriteIndicator + label
+		}
 		if uint(i) == l.currentExecutable {
 			indicator := "*"
 			if (l.ctx.CurrentWorkspace != nil && ex.Workspace() == l.ctx.CurrentWorkspace.AssignedName()) ||
@@ -186,10 +198,10 @@ func (l *Library) paneOneContent() string {
 				// indicate if runnable from the current ctx
 				indicator = "▶"
 			}
-			refStr := indicator + " " + truncateText(shortRef(ex.Ref(), curWs, curNs), paneWidth)
+			refStr := indicator + " " + truncateText(label, paneWidth)
 			sb.WriteString(renderSelection(refStr, l.theme))
 		} else {
-			sb.WriteString(renderInactive("  "+truncateText(shortRef(ex.Ref(), curWs, curNs), paneWidth), l.theme))
+			sb.WriteString(renderInactive("  "+truncateText(label, paneWidth), l.theme))
 		}
 		sb.WriteString("\n")
 	}
@@ -316,6 +328,7 @@ func (l *Library) footerContent() string {
 				var info string
 				switch {
 				case l.noticeText != "":
+					l.mu.RUnlock()
 					info = l.noticeText
 				default:
 					exec := l.visibleExecutables[l.currentExecutable]
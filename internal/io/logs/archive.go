@@ -0,0 +1,180 @@
+package logs
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/flowexec/tuikit/io"
+	"github.com/go-logfmt/logfmt"
+
+	"github.com/flowexec/flow/internal/runner"
+)
+
+const StatusRunning = "running"
+
+// Filter selects the archive entries that are listed. Unset fields match every entry.
+type Filter struct {
+	// Ref matches entries whose executable reference or command arguments contain the value.
+	Ref    string
+	Since  time.Time
+	Until  time.Time
+	Status string
+	Grep   *regexp.Regexp
+}
+
+// step is the breakdown of a single serial or parallel step recorded in a log archive entry.
+type step struct {
+	Executable string `json:"executable" yaml:"executable"`
+	Index      int    `json:"index"      yaml:"index"`
+	ID         string `json:"id"         yaml:"id"`
+	Start      string `json:"start"      yaml:"start"`
+	End        string `json:"end"        yaml:"end"`
+	Duration   string `json:"duration"   yaml:"duration"`
+	ExitCode   int    `json:"exitCode"   yaml:"exitCode"`
+	Retries    int    `json:"retries"    yaml:"retries"`
+	Status     string `json:"status"     yaml:"status"`
+}
+
+type stepKey struct {
+	executable string
+	index      int
+}
+
+// record is the data parsed from an archive file.
+type record struct {
+	executable string
+	status     string
+	duration   string
+	steps      []step
+	matches    []string
+}
+
+// EntryTime returns the time of the archive entry. Archive file names are written in local time.
+func EntryTime(e io.ArchiveEntry) time.Time {
+	t := e.Time
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.Local)
+}
+
+// matchesEntry reports whether the entry matches the filter fields that do not require reading its archive file.
+func (f Filter) matchesEntry(e io.ArchiveEntry) bool {
+	t := EntryTime(e)
+	if !f.Since.IsZero() && t.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && t.After(f.Until) {
+		return false
+	}
+	return true
+}
+
+func (f Filter) matchesRecord(e io.ArchiveEntry, r record) bool {
+	if f.Ref != "" && !strings.Contains(r.executable, f.Ref) && !strings.Contains(e.Args, f.Ref) {
+		return false
+	}
+	if f.Status != "" && r.status != f.Status {
+		return false
+	}
+	if f.Grep != nil && len(r.matches) == 0 {
+		return false
+	}
+	return true
+}
+
+// readRecord parses the execution and step events in the archive file. Steps that were retried are reported once
+// with the start of their first attempt and the result of their last attempt.
+//
+//nolint:gocognit
+func readRecord(path string, grep *regexp.Regexp) (record, error) {
+	var r record
+	file, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return r, err
+	}
+	defer file.Close()
+
+	positions := make(map[stepKey]int)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if grep != nil && grep.MatchString(line) {
+			r.matches = append(r.matches, line)
+		}
+		if !strings.Contains(line, "msg=\"execution ") && !strings.Contains(line, "msg=\"step finished\"") {
+			continue
+		}
+		fields, ok := decodeLogfmt(line)
+		if !ok {
+			continue
+		}
+		switch fields["msg"] {
+		case runner.ExecutionStartedMsg:
+			if r.executable == "" {
+				r.executable = fields["executable"]
+				r.status = StatusRunning
+			}
+		case runner.ExecutionFinishedMsg:
+			if fields["executable"] == r.executable {
+				r.status = fields["status"]
+				r.duration = fields["duration"]
+			}
+		case runner.StepFinishedMsg:
+			s := stepFromFields(fields)
+			key := stepKey{executable: s.Executable, index: s.Index}
+			if pos, found := positions[key]; found {
+				s.Start = r.steps[pos].Start
+				s.Duration = stepDuration(s.Start, s.End, s.Duration)
+				r.steps[pos] = s
+				continue
+			}
+			positions[key] = len(r.steps)
+			r.steps = append(r.steps, s)
+		}
+	}
+	return r, scanner.Err()
+}
+
+func stepFromFields(fields map[string]string) step {
+	index, _ := strconv.Atoi(fields["index"])
+	attempt, _ := strconv.Atoi(fields["attempt"])
+	exitCode, _ := strconv.Atoi(fields["exitCode"])
+	return step{
+		Executable: fields["executable"],
+		Index:      index,
+		ID:         fields["step"],
+		Start:      fields["start"],
+		End:        fields["end"],
+		Duration:   fields["duration"],
+		ExitCode:   exitCode,
+		Retries:    max(attempt-1, 0),
+		Status:     fields["status"],
+	}
+}
+
+func decodeLogfmt(line string) (map[string]string, bool) {
+	fields := make(map[string]string)
+	dec := logfmt.NewDecoder(strings.NewReader(line))
+	for dec.ScanRecord() {
+		for dec.ScanKeyval() {
+			fields[string(dec.Key())] = string(dec.Value())
+		}
+	}
+	return fields, dec.Err() == nil
+}
+
+func stepDuration(start, end, fallback string) string {
+	s, err := time.Parse(runner.StepTimeFormat, start)
+	if err != nil {
+		return fallback
+	}
+	e, err := time.Parse(runner.StepTimeFormat, end)
+	if err != nil {
+		return fallback
+	}
+	return e.Sub(s).Round(time.Millisecond).String()
+}
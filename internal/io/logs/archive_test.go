@@ -0,0 +1,135 @@
+//nolint:testpackage
+package logs
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/flowexec/tuikit/io"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const retriedLog = `msg="execution started" executable="run ws/steps"
+msg="step finished" executable="run ws/steps" index=1 step="run ws/a" attempt=1 start=2024-01-01T00:00:00Z ` +
+	`end=2024-01-01T00:00:01Z duration=1s exitCode=0 status=succeeded
+msg=retrying step="run ws/b"
+msg="step finished" executable="run ws/steps" index=2 step="run ws/b" attempt=1 start=2024-01-01T00:00:01Z ` +
+	`end=2024-01-01T00:00:02Z duration=1s exitCode=3 status=failed
+msg="step finished" executable="run ws/steps" index=2 step="run ws/b" attempt=2 start=2024-01-01T00:00:02Z ` +
+	`end=2024-01-01T00:00:04Z duration=2s exitCode=0 status=succeeded
+msg="execution finished" executable="run ws/steps" status=success duration=4s
+`
+
+var _ = Describe("Archive", func() {
+	var dir string
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+	})
+
+	writeArchiveFile := func(name, content string, t time.Time) io.ArchiveEntry {
+		path := filepath.Join(dir, name)
+		Expect(os.WriteFile(path, []byte(content), 0600)).To(Succeed())
+		return io.ArchiveEntry{Args: name, Time: t, Path: path}
+	}
+
+	Describe("readRecord", func() {
+		It("reads the execution result", func() {
+			e := writeArchiveFile("steps.log", retriedLog, time.Now())
+			r, err := readRecord(e.Path, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(r.executable).To(Equal("run ws/steps"))
+			Expect(r.status).To(Equal("success"))
+			Expect(r.duration).To(Equal("4s"))
+		})
+
+		It("merges the attempts of retried steps", func() {
+			e := writeArchiveFile("steps.log", retriedLog, time.Now())
+			r, err := readRecord(e.Path, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(r.steps).To(HaveLen(2))
+			Expect(r.steps[0].Retries).To(Equal(0))
+			Expect(r.steps[1]).To(Equal(step{
+				Executable: "run ws/steps",
+				Index:      2,
+				ID:         "run ws/b",
+				Start:      "2024-01-01T00:00:01Z",
+				End:        "2024-01-01T00:00:04Z",
+				Duration:   "3s",
+				ExitCode:   0,
+				Retries:    1,
+				Status:     "succeeded",
+			}))
+		})
+
+		It("reports executions without a finished event as running", func() {
+			e := writeArchiveFile("running.log", "msg=\"execution started\" executable=\"run ws/steps\"\n", time.Now())
+			r, err := readRecord(e.Path, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(r.status).To(Equal(StatusRunning))
+		})
+
+		It("collects the lines that match the grep pattern", func() {
+			e := writeArchiveFile("steps.log", retriedLog, time.Now())
+			r, err := readRecord(e.Path, regexp.MustCompile("retry"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(r.matches).To(Equal([]string{`msg=retrying step="run ws/b"`}))
+		})
+	})
+
+	Describe("Filter", func() {
+		var entries []io.ArchiveEntry
+		now := time.Date(2024, 1, 10, 12, 0, 0, 0, time.Local)
+
+		BeforeEach(func() {
+			entries = []io.ArchiveEntry{
+				writeArchiveFile("steps.log", retriedLog, now.Add(-48*time.Hour)),
+				writeArchiveFile("build.log", `msg="execution started" executable="build ws/app"
+msg="compile failed"
+msg="execution finished" executable="build ws/app" status=failure duration=1s
+`, now.Add(-time.Hour)),
+				writeArchiveFile("test.log", `msg="execution started" executable="test ws/app"
+`, now),
+			}
+		})
+
+		names := func(filtered []io.ArchiveEntry) []string {
+			var result []string
+			for _, e := range filtered {
+				result = append(result, e.Args)
+			}
+			return result
+		}
+
+		It("matches every entry when no fields are set", func() {
+			Expect(names(FilterEntries(entries, Filter{}))).To(Equal([]string{"steps.log", "build.log", "test.log"}))
+		})
+
+		It("filters by status", func() {
+			Expect(names(FilterEntries(entries, Filter{Status: "failure"}))).To(Equal([]string{"build.log"}))
+			Expect(names(FilterEntries(entries, Filter{Status: StatusRunning}))).To(Equal([]string{"test.log"}))
+		})
+
+		It("filters by the executable reference or the command arguments", func() {
+			Expect(names(FilterEntries(entries, Filter{Ref: "ws/app"}))).To(Equal([]string{"build.log", "test.log"}))
+			Expect(names(FilterEntries(entries, Filter{Ref: "steps.log"}))).To(Equal([]string{"steps.log"}))
+		})
+
+		It("filters by the lines that match the grep pattern", func() {
+			filter := Filter{Grep: regexp.MustCompile("compile")}
+			Expect(names(FilterEntries(entries, filter))).To(Equal([]string{"build.log"}))
+		})
+
+		It("filters by the entry time", func() {
+			since := Filter{Since: now.Add(-2 * time.Hour)}
+			Expect(names(FilterEntries(entries, since))).To(Equal([]string{"build.log", "test.log"}))
+			until := Filter{Until: now.Add(-2 * time.Hour)}
+			Expect(names(FilterEntries(entries, until))).To(Equal([]string{"steps.log"}))
+			between := Filter{Since: now.Add(-2 * time.Hour), Until: now.Add(-time.Minute)}
+			Expect(names(FilterEntries(entries, between))).To(Equal([]string{"build.log"}))
+		})
+	})
+})
@@ -0,0 +1,67 @@
+package logs
+
+import (
+	"bufio"
+	"context"
+	stdio "io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/flowexec/tuikit/io"
+
+	"github.com/flowexec/flow/internal/runner"
+)
+
+const followInterval = 250 * time.Millisecond
+
+// LatestExecution returns the most recent entry that was written by `flow exec`.
+func LatestExecution(entries []io.ArchiveEntry) (io.ArchiveEntry, bool) {
+	for i := len(entries) - 1; i >= 0; i-- {
+		r, err := readRecord(entries[i].Path, nil)
+		if err == nil && r.executable != "" {
+			return entries[i], true
+		}
+	}
+	return io.ArchiveEntry{}, false
+}
+
+// Follow writes the content of the archive file to out as it is written. It returns once the execution that the
+// file belongs to has finished or the context is done.
+func Follow(ctx context.Context, path string, out stdio.Writer) error {
+	file, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	var partial string
+	for {
+		line, err := reader.ReadString('\n')
+		switch {
+		case err == nil:
+			line = partial + line
+			partial = ""
+			if _, err := stdio.WriteString(out, line); err != nil {
+				return err
+			}
+			if strings.Contains(line, "msg=\""+runner.ExecutionFinishedMsg+"\"") {
+				return nil
+			}
+		case err == stdio.EOF:
+			partial += line
+			select {
+			case <-ctx.Done():
+				if partial != "" {
+					_, _ = stdio.WriteString(out, partial)
+				}
+				return nil
+			case <-time.After(followInterval):
+			}
+		default:
+			return err
+		}
+	}
+}
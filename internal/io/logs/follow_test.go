@@ -0,0 +1,80 @@
+package logs_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/flowexec/flow/internal/io/logs"
+)
+
+// syncBuffer is a buffer that can be read while Follow writes to it.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+var _ = Describe("Follow", func() {
+	var path string
+
+	BeforeEach(func() {
+		path = filepath.Join(GinkgoT().TempDir(), "run+steps__2024-01-01-00-00-00.log")
+		Expect(os.WriteFile(path, []byte("msg=\"execution started\" executable=\"run ws/steps\"\n"), 0600)).To(Succeed())
+	})
+
+	It("writes the lines that are appended until the execution finishes", func() {
+		out := &syncBuffer{}
+		done := make(chan error, 1)
+		go func() { done <- logs.Follow(context.Background(), path, out) }()
+		Eventually(out.String).Should(Equal("msg=\"execution started\" executable=\"run ws/steps\"\n"))
+
+		file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+		Expect(err).NotTo(HaveOccurred())
+		defer file.Close()
+		_, err = file.WriteString("msg=one\nmsg=tw")
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(out.String).Should(HaveSuffix("msg=one\n"))
+		Consistently(done, 300*time.Millisecond).ShouldNot(Receive())
+
+		_, err = file.WriteString(
+			"o\nmsg=\"execution finished\" executable=\"run ws/steps\" status=success\nmsg=after\n",
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(done, time.Second).Should(Receive(BeNil()))
+		Expect(out.String()).To(HaveSuffix("msg=one\nmsg=two\nmsg=\"execution finished\" executable=\"run ws/steps\" " +
+			"status=success\n"))
+	})
+
+	It("returns when the context is done", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		out := &syncBuffer{}
+		done := make(chan error, 1)
+		go func() { done <- logs.Follow(ctx, path, out) }()
+		Consistently(done, 300*time.Millisecond).ShouldNot(Receive())
+		cancel()
+		Eventually(done, time.Second).Should(Receive(BeNil()))
+	})
+
+	It("returns an error for missing files", func() {
+		Expect(logs.Follow(context.Background(), filepath.Join(GinkgoT().TempDir(), "missing.log"), &syncBuffer{})).
+			NotTo(Succeed())
+	})
+})
@@ -11,52 +11,84 @@ import (
 )
 
 type entry struct {
-	Args string `json:"args" yaml:"args"`
-	Time string `json:"time" yaml:"time"`
-	File string `json:"file" yaml:"file"`
+	Args       string   `json:"args"                 yaml:"args"`
+	Time       string   `json:"time"                 yaml:"time"`
+	File       string   `json:"file"                 yaml:"file"`
+	Executable string   `json:"executable,omitempty" yaml:"executable,omitempty"`
+	Status     string   `json:"status,omitempty"     yaml:"status,omitempty"`
+	Duration   string   `json:"duration,omitempty"   yaml:"duration,omitempty"`
+	Steps      []step   `json:"steps,omitempty"      yaml:"steps,omitempty"`
+	Matches    []string `json:"matches,omitempty"    yaml:"matches,omitempty"`
 }
 
 type entryResponse struct {
 	Logs []entry `json:"logs" yaml:"logs"`
 }
 
-func tuikitToEntry(e io.ArchiveEntry) entry {
+func tuikitToEntry(e io.ArchiveEntry, r record) entry {
 	return entry{
-		Args: e.Args,
-		Time: e.Time.String(),
-		File: e.Path,
+		Args:       e.Args,
+		Time:       e.Time.String(),
+		File:       e.Path,
+		Executable: r.executable,
+		Status:     r.status,
+		Duration:   r.duration,
+		Steps:      r.steps,
+		Matches:    r.matches,
 	}
 }
 
-func marshalEntriesJSON(entries []io.ArchiveEntry) ([]byte, error) {
-	entriesJSON := make([]entry, len(entries))
-	for i, e := range entries {
-		entriesJSON[i] = tuikitToEntry(e)
+// FilterEntries returns the archive entries that match the filter.
+func FilterEntries(entries []io.ArchiveEntry, filter Filter) []io.ArchiveEntry {
+	filtered, _ := filterEntries(entries, filter)
+	return filtered
+}
+
+func filterEntries(entries []io.ArchiveEntry, filter Filter) ([]io.ArchiveEntry, []entry) {
+	var filtered []io.ArchiveEntry
+	var converted []entry
+	for _, e := range entries {
+		if !filter.matchesEntry(e) {
+			continue
+		}
+		r, err := readRecord(e.Path, filter.Grep)
+		if err != nil {
+			logger.Log().Debugf("unable to read log entry %s - %v", e.Path, err)
+		}
+		if !filter.matchesRecord(e, r) {
+			continue
+		}
+		filtered = append(filtered, e)
+		converted = append(converted, tuikitToEntry(e, r))
 	}
-	entriesResponse := entryResponse{Logs: entriesJSON}
+	return filtered, converted
+}
+
+func marshalEntriesJSON(entries []entry) ([]byte, error) {
+	entriesResponse := entryResponse{Logs: entries}
 	return json.MarshalIndent(entriesResponse, "", "  ")
 }
 
-func marshalEntriesYAML(entries []io.ArchiveEntry) ([]byte, error) {
-	entriesYAML := make([]entry, len(entries))
-	for i, e := range entries {
-		entriesYAML[i] = tuikitToEntry(e)
-	}
-	entriesResponse := entryResponse{Logs: entriesYAML}
+func marshalEntriesYAML(entries []entry) ([]byte, error) {
+	entriesResponse := entryResponse{Logs: entries}
 	return yaml.Marshal(entriesResponse)
 }
 
-func PrintEntries(format string, entries []io.ArchiveEntry) {
-	logger.Log().Debugf("listing %d log entries", len(entries))
+func PrintEntries(format string, entries []io.ArchiveEntry, filter Filter) {
+	_, converted := filterEntries(entries, filter)
+	if converted == nil {
+		converted = []entry{}
+	}
+	logger.Log().Debugf("listing %d of %d log entries", len(converted), len(entries))
 	switch common.NormalizeFormat(format) {
 	case common.YAMLFormat:
-		str, err := marshalEntriesYAML(entries)
+		str, err := marshalEntriesYAML(converted)
 		if err != nil {
 			logger.Log().Fatalf("Failed to marshal log entries - %v", err)
 		}
 		logger.Log().Println(string(str))
 	case common.JSONFormat:
-		str, err := marshalEntriesJSON(entries)
+		str, err := marshalEntriesJSON(converted)
 		if err != nil {
 			logger.Log().Fatalf("Failed to marshal log entries - %v", err)
 		}
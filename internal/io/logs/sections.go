@@ -0,0 +1,98 @@
+package logs
+
+import (
+	"strings"
+
+	"github.com/flowexec/flow/internal/runner"
+)
+
+// section is a group of lines of a log archive entry. Lines that are written between the start and finish events of
+// a serial or parallel step are grouped in the section of the step. Other lines are grouped in sections without a
+// step.
+type section struct {
+	step  *step
+	lines []string
+	open  bool
+}
+
+// parseSections groups the lines of the archive file content by step. The attempts of a step that was retried are
+// grouped in a single section. Lines that are written while several steps are running are added to the section of
+// the step that is named in their step field, or to the section of the step that started last.
+//
+//nolint:gocognit
+func parseSections(content string) []*section {
+	var sections []*section
+	positions := make(map[stepKey]int)
+	var running []*section
+	add := func(line string) {
+		target := lineSection(line, running)
+		if target == nil {
+			if len(sections) == 0 || sections[len(sections)-1].step != nil {
+				sections = append(sections, &section{})
+			}
+			target = sections[len(sections)-1]
+		}
+		target.lines = append(target.lines, line)
+	}
+
+	for _, line := range strings.Split(strings.TrimRight(content, "\n"), "\n") {
+		if !strings.Contains(line, "msg=\"step ") {
+			add(line)
+			continue
+		}
+		fields, ok := decodeLogfmt(line)
+		if !ok || (fields["msg"] != runner.StepStartedMsg && fields["msg"] != runner.StepFinishedMsg) {
+			add(line)
+			continue
+		}
+		s := stepFromFields(fields)
+		key := stepKey{executable: s.Executable, index: s.Index}
+		pos, found := positions[key]
+		if !found {
+			pos = len(sections)
+			positions[key] = pos
+			sections = append(sections, &section{step: &s})
+		}
+		sec := sections[pos]
+		switch fields["msg"] {
+		case runner.StepStartedMsg:
+			if !sec.open {
+				sec.open = true
+				running = append(running, sec)
+			}
+			sec.lines = append(sec.lines, line)
+		case runner.StepFinishedMsg:
+			if found {
+				s.Start = sec.step.Start
+				s.Duration = stepDuration(s.Start, s.End, s.Duration)
+			}
+			sec.step = &s
+			sec.lines = append(sec.lines, line)
+			sec.open = false
+			for i, r := range running {
+				if r == sec {
+					running = append(running[:i], running[i+1:]...)
+					break
+				}
+			}
+		}
+	}
+	return sections
+}
+
+// lineSection returns the running step section that the line belongs to, or nil if no step is running.
+func lineSection(line string, running []*section) *section {
+	if len(running) == 0 {
+		return nil
+	}
+	if len(running) > 1 && strings.Contains(line, "step=") {
+		if fields, ok := decodeLogfmt(line); ok {
+			for i := len(running) - 1; i >= 0; i-- {
+				if running[i].step.ID == fields["step"] {
+					return running[i]
+				}
+			}
+		}
+	}
+	return running[len(running)-1]
+}
@@ -0,0 +1,347 @@
+package logs
+
+import (
+	"fmt"
+	stdio "io"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/flowexec/tuikit/io"
+	"github.com/flowexec/tuikit/themes"
+	"github.com/flowexec/tuikit/types"
+
+	"github.com/flowexec/flow/internal/context"
+)
+
+const (
+	ArchiveViewType = "log-archive"
+	EntryViewType   = "log-entry"
+)
+
+// ArchiveView lists the log archive entries. The selected entry is opened in an EntryView.
+type ArchiveView struct {
+	ctx     *context.Context
+	theme   themes.Theme
+	entries []io.ArchiveEntry
+	model   list.Model
+	err     error
+}
+
+func NewArchiveView(ctx *context.Context, archiveDir string) *ArchiveView {
+	state := ctx.TUIContainer.RenderState()
+	v := &ArchiveView{ctx: ctx, theme: state.Theme}
+	entries, err := io.ListArchiveEntries(archiveDir)
+	if err != nil {
+		v.err = err
+		return v
+	}
+	slices.Reverse(entries)
+	v.entries = entries
+	items := make([]list.Item, 0, len(entries))
+	for _, e := range entries {
+		items = append(items, e)
+	}
+	v.model = list.New(items, &archiveDelegate{theme: state.Theme}, state.ContentWidth, state.ContentHeight)
+	v.model.SetShowTitle(false)
+	v.model.SetShowHelp(false)
+	v.model.SetShowPagination(false)
+	v.model.SetStatusBarItemName("log entry", "log entries")
+	v.model.Styles = state.Theme.ListStyles()
+	return v
+}
+
+func (v *ArchiveView) Init() tea.Cmd {
+	return nil
+}
+
+func (v *ArchiveView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if v.err != nil {
+		return v, nil
+	}
+	switch msg := msg.(type) {
+	case *types.RenderState:
+		v.model.SetSize(msg.ContentWidth, msg.ContentHeight)
+	case tea.KeyMsg:
+		if v.model.FilterState() == list.Filtering {
+			break
+		}
+		switch msg.String() {
+		case "enter":
+			if e, ok := v.model.SelectedItem().(io.ArchiveEntry); ok {
+				v.open(e)
+			}
+			return v, nil
+		case "d":
+			if e, ok := v.model.SelectedItem().(io.ArchiveEntry); ok {
+				v.delete(e)
+			}
+			return v, nil
+		case "x":
+			for _, e := range slices.Clone(v.entries) {
+				v.delete(e)
+			}
+			return v, nil
+		}
+	}
+	var cmd tea.Cmd
+	v.model, cmd = v.model.Update(msg)
+	return v, cmd
+}
+
+func (v *ArchiveView) open(e io.ArchiveEntry) {
+	view := NewEntryView(v.ctx.TUIContainer.RenderState(), e)
+	// The view is set outside of the update loop since setting it sends the init command of the view to the program.
+	go func() {
+		if err := v.ctx.SetView(view); err != nil {
+			v.ctx.TUIContainer.HandleError(fmt.Errorf("unable to set view: %w", err))
+		}
+	}()
+}
+
+func (v *ArchiveView) delete(e io.ArchiveEntry) {
+	if err := io.DeleteArchiveEntry(e.Path); err != nil {
+		v.err = err
+		return
+	}
+	v.entries = slices.DeleteFunc(v.entries, func(entry io.ArchiveEntry) bool { return entry.Path == e.Path })
+	items := make([]list.Item, 0, len(v.entries))
+	for _, entry := range v.entries {
+		items = append(items, entry)
+	}
+	v.model.SetItems(items)
+}
+
+func (v *ArchiveView) View() string {
+	switch {
+	case v.err != nil:
+		return v.theme.RenderError(v.err.Error())
+	case len(v.entries) == 0:
+		return v.theme.RenderUnknown("no log entries found")
+	}
+	return v.theme.BoxStyle().Width(v.model.Width()).Render(v.model.View())
+}
+
+func (v *ArchiveView) HelpMsg() string {
+	return "[ enter: select ] [ /: filter ] ● [ d: delete selected ] [ x: delete all ]"
+}
+
+func (v *ArchiveView) ShowFooter() bool {
+	return v.err == nil
+}
+
+func (v *ArchiveView) Type() string {
+	return ArchiveViewType
+}
+
+type archiveDelegate struct {
+	theme themes.Theme
+}
+
+func (d *archiveDelegate) Height() int                             { return 1 }
+func (d *archiveDelegate) Spacing() int                            { return 0 }
+func (d *archiveDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return nil }
+func (d *archiveDelegate) Render(w stdio.Writer, m list.Model, index int, item list.Item) {
+	e, ok := item.(io.ArchiveEntry)
+	if !ok {
+		return
+	}
+	line := fmt.Sprintf("%d. %s (%s)", index+1, e.Title(), e.Description())
+	style := lipgloss.NewStyle().Foreground(d.theme.ColorPalette().WhiteColor()).PaddingLeft(2)
+	if index == m.Index() {
+		style = lipgloss.NewStyle().
+			Foreground(d.theme.ColorPalette().SecondaryColor()).
+			BorderForeground(d.theme.ColorPalette().SecondaryColor()).
+			BorderLeft(true).
+			PaddingLeft(2)
+	}
+	_, _ = fmt.Fprint(w, style.Render(line))
+}
+
+// EntryView shows the content of a log archive entry. The output of each serial and parallel step is grouped below
+// a header with the step's status, duration, exit code and retries and can be collapsed or expanded.
+type EntryView struct {
+	entry    io.ArchiveEntry
+	theme    themes.Theme
+	width    int
+	height   int
+	sections []*section
+	// steps are the indexes of the sections of steps, and selected is the index of the selected step in it.
+	steps     []int
+	collapsed map[int]bool
+	selected  int
+	viewport  viewport.Model
+	err       error
+}
+
+func NewEntryView(state *types.RenderState, entry io.ArchiveEntry) *EntryView {
+	v := &EntryView{
+		entry:     entry,
+		theme:     state.Theme,
+		width:     state.ContentWidth,
+		height:    state.ContentHeight,
+		collapsed: make(map[int]bool),
+		viewport:  viewport.New(state.ContentWidth, max(state.ContentHeight-1, 1)),
+	}
+	content, err := entry.Read()
+	if err != nil {
+		v.err = err
+		return v
+	}
+	v.sections = parseSections(content)
+	for i, s := range v.sections {
+		if s.step != nil {
+			v.steps = append(v.steps, i)
+		}
+	}
+	v.render()
+	return v
+}
+
+func (v *EntryView) Init() tea.Cmd {
+	return nil
+}
+
+func (v *EntryView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case *types.RenderState:
+		v.width, v.height = msg.ContentWidth, msg.ContentHeight
+		v.viewport.Width, v.viewport.Height = v.width, max(v.height-1, 1)
+		v.render()
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			if len(v.steps) == 0 {
+				v.viewport.ScrollUp(1)
+			} else if v.selected > 0 {
+				v.selected--
+				v.render()
+			}
+		case "down", "j":
+			if len(v.steps) == 0 {
+				v.viewport.ScrollDown(1)
+			} else if v.selected < len(v.steps)-1 {
+				v.selected++
+				v.render()
+			}
+		case "pgup":
+			v.viewport.PageUp()
+		case "pgdown":
+			v.viewport.PageDown()
+		case "enter", " ":
+			if len(v.steps) > 0 {
+				i := v.steps[v.selected]
+				v.collapsed[i] = !v.collapsed[i]
+				v.render()
+			}
+		case "c", "e":
+			for _, i := range v.steps {
+				v.collapsed[i] = msg.String() == "c"
+			}
+			v.render()
+		}
+	}
+	return v, nil
+}
+
+// render sets the content of the viewport and scrolls it to show the header of the selected step.
+func (v *EntryView) render() {
+	var lines []string
+	selectedLine := -1
+	for i, s := range v.sections {
+		if s.step == nil {
+			for _, line := range s.lines {
+				lines = append(lines, ansi.Wrap(line, max(v.width, 10), ""))
+			}
+			continue
+		}
+		selected := len(v.steps) > 0 && v.steps[v.selected] == i
+		if selected {
+			selectedLine = len(lines)
+		}
+		lines = append(lines, v.renderHeader(s, v.collapsed[i], selected))
+		if v.collapsed[i] {
+			continue
+		}
+		for _, line := range s.lines {
+			lines = append(lines, ansi.Wrap("    "+line, max(v.width, 10), ""))
+		}
+	}
+	if len(lines) == 0 {
+		lines = append(lines, v.theme.RenderUnknown("no data found in log entry"))
+	}
+	v.viewport.SetContent(strings.Join(lines, "\n"))
+	if selectedLine >= 0 {
+		// lines can be wrapped, so the line of the header is counted in the rendered content
+		offset := lipgloss.Height(strings.Join(lines[:selectedLine+1], "\n")) - 1
+		if offset < v.viewport.YOffset || offset >= v.viewport.YOffset+v.viewport.Height {
+			v.viewport.SetYOffset(offset)
+		}
+	}
+}
+
+func (v *EntryView) renderHeader(s *section, collapsed, selected bool) string {
+	cursor := "  "
+	if selected {
+		cursor = v.theme.RenderEmphasis("> ")
+	}
+	toggle := "▾"
+	if collapsed {
+		toggle = "▸"
+	}
+	st := s.step
+	status := st.Status
+	if s.open || status == "" {
+		status = StatusRunning
+	}
+	details := []string{status}
+	if st.Duration != "" {
+		details = append(details, st.Duration)
+	}
+	if st.ExitCode != 0 {
+		details = append(details, fmt.Sprintf("exit %d", st.ExitCode))
+	}
+	if st.Retries == 1 {
+		details = append(details, "1 retry")
+	} else if st.Retries > 1 {
+		details = append(details, fmt.Sprintf("%d retries", st.Retries))
+	}
+	label := fmt.Sprintf("%s %s step %d", toggle, st.Executable, st.Index)
+	if st.ID != "" && st.ID != st.Executable {
+		label += fmt.Sprintf(" (%s)", st.ID)
+	}
+	detail := strings.Join(details, " · ")
+	switch status {
+	case "succeeded":
+		detail = v.theme.RenderSuccess(detail)
+	case "failed":
+		detail = v.theme.RenderError(detail)
+	default:
+		detail = v.theme.RenderInfo(detail)
+	}
+	return ansi.Truncate(cursor+v.theme.RenderBold(label)+" "+detail, max(v.width, 10), "…")
+}
+
+func (v *EntryView) View() string {
+	if v.err != nil {
+		return v.theme.RenderError(v.err.Error())
+	}
+	title := v.theme.RenderBold(fmt.Sprintf("%s (%s)", v.entry.Title(), v.entry.Description()))
+	return lipgloss.JoinVertical(lipgloss.Left, ansi.Truncate(title, max(v.width, 10), "…"), v.viewport.View())
+}
+
+func (v *EntryView) HelpMsg() string {
+	return "[ ↑/↓: select step ] [ enter: collapse/expand step ] [ c: collapse all ] [ e: expand all ]"
+}
+
+func (v *EntryView) ShowFooter() bool {
+	return true
+}
+
+func (v *EntryView) Type() string {
+	return EntryViewType
+}
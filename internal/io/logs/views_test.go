@@ -0,0 +1,108 @@
+package logs_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	tuikitIO "github.com/flowexec/tuikit/io"
+	"github.com/flowexec/tuikit/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/flowexec/flow/internal/io"
+	"github.com/flowexec/flow/internal/io/logs"
+)
+
+func TestLogs(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Logs Suite")
+}
+
+const serialLog = `msg="execution started" executable="run ws/steps"
+msg="step started" executable="run ws/steps" index=1 step="run ws/steps" attempt=1 start=2024-01-01T00:00:00Z
+msg=one step="run ws/steps"
+msg="step finished" executable="run ws/steps" index=1 step="run ws/steps" attempt=1 start=2024-01-01T00:00:00Z ` +
+	`end=2024-01-01T00:00:01Z duration=1s exitCode=0 status=succeeded
+msg="step started" executable="run ws/steps" index=2 step="run ws/steps" attempt=1 start=2024-01-01T00:00:01Z
+msg=two-first step="run ws/steps"
+msg="step finished" executable="run ws/steps" index=2 step="run ws/steps" attempt=1 start=2024-01-01T00:00:01Z ` +
+	`end=2024-01-01T00:00:02Z duration=1s exitCode=3 status=failed
+msg="step started" executable="run ws/steps" index=2 step="run ws/steps" attempt=2 start=2024-01-01T00:00:02Z
+msg=two-second step="run ws/steps"
+msg="step finished" executable="run ws/steps" index=2 step="run ws/steps" attempt=2 start=2024-01-01T00:00:02Z ` +
+	`end=2024-01-01T00:00:04Z duration=2s exitCode=3 status=failed
+msg="execution finished" executable="run ws/steps" status=failure
+`
+
+func writeEntry(dir, content string) tuikitIO.ArchiveEntry {
+	path := filepath.Join(dir, "run+steps__2024-01-01-00-00-00.log")
+	Expect(os.WriteFile(path, []byte(content), 0600)).To(Succeed())
+	return tuikitIO.ArchiveEntry{Args: "run steps", Time: time.Now(), Path: path}
+}
+
+func renderState() *types.RenderState {
+	return &types.RenderState{Width: 200, Height: 40, ContentWidth: 200, ContentHeight: 40, Theme: io.Theme("")}
+}
+
+var _ = Describe("EntryView", func() {
+	var entry tuikitIO.ArchiveEntry
+
+	BeforeEach(func() {
+		entry = writeEntry(GinkgoT().TempDir(), serialLog)
+	})
+
+	It("groups the output of each step below its header", func() {
+		view := ansi.Strip(logs.NewEntryView(renderState(), entry).View())
+		Expect(view).To(ContainSubstring("▾ run ws/steps step 1 succeeded · 1s"))
+		Expect(view).To(ContainSubstring("▾ run ws/steps step 2 failed · 3s · exit 3 · 1 retry"))
+		Expect(view).To(ContainSubstring("msg=\"execution started\""))
+		Expect(view).To(ContainSubstring("    msg=one"))
+		Expect(view).To(ContainSubstring("    msg=two-first"))
+		Expect(view).To(ContainSubstring("    msg=two-second"))
+	})
+
+	It("collapses and expands the selected step", func() {
+		v := logs.NewEntryView(renderState(), entry)
+		v.Update(tea.KeyMsg{Type: tea.KeyDown})
+		v.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		view := ansi.Strip(v.View())
+		Expect(view).To(ContainSubstring("▸ run ws/steps step 2"))
+		Expect(view).To(ContainSubstring("msg=one"))
+		Expect(view).NotTo(ContainSubstring("msg=two-first"))
+
+		v.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		Expect(ansi.Strip(v.View())).To(ContainSubstring("msg=two-first"))
+	})
+
+	It("collapses and expands all steps", func() {
+		v := logs.NewEntryView(renderState(), entry)
+		v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+		view := ansi.Strip(v.View())
+		Expect(view).NotTo(ContainSubstring("msg=one"))
+		Expect(view).NotTo(ContainSubstring("msg=two"))
+		Expect(view).To(ContainSubstring("msg=\"execution finished\""))
+
+		v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+		Expect(ansi.Strip(v.View())).To(ContainSubstring("msg=one"))
+	})
+
+	It("groups interleaved parallel output by the step field of the lines", func() {
+		entry = writeEntry(GinkgoT().TempDir(), `msg="step started" executable="run ws/par" index=1 step="run ws/a"
+msg="step started" executable="run ws/par" index=2 step="run ws/b"
+msg=a-out step="run ws/a"
+msg=b-out step="run ws/b"
+msg="step finished" executable="run ws/par" index=1 step="run ws/a" exitCode=0 status=succeeded
+msg="step finished" executable="run ws/par" index=2 step="run ws/b" exitCode=0 status=succeeded
+`)
+		v := logs.NewEntryView(renderState(), entry)
+		v.Update(tea.KeyMsg{Type: tea.KeyDown})
+		v.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		view := ansi.Strip(v.View())
+		Expect(view).To(ContainSubstring("msg=a-out"))
+		Expect(view).NotTo(ContainSubstring("msg=b-out"))
+	})
+})
@@ -0,0 +1,104 @@
+package logger
+
+import (
+	"fmt"
+
+	"github.com/flowexec/tuikit/io"
+)
+
+// OutputMethod is the logger method that output was written with.
+type OutputMethod int
+
+const (
+	MethodPrint OutputMethod = iota
+	MethodPrintln
+	MethodInfof
+	MethodNoticef
+	MethodInfox
+	MethodNoticex
+)
+
+// Output is a call that writes the output of an executable to a logger.
+type Output struct {
+	Method OutputMethod
+	// Msg is the data of Print and Println calls and the message of the other calls.
+	Msg string
+	// Args are the format arguments of Infof and Noticef calls and the key-value pairs of Infox and Noticex calls.
+	Args []any
+}
+
+// Text returns the output as it is written by Print. Messages are formatted and end with a newline.
+func (o Output) Text() string {
+	switch o.Method {
+	case MethodPrint:
+		return o.Msg
+	case MethodPrintln:
+		return o.Msg + "\n"
+	case MethodInfof, MethodNoticef:
+		return fmt.Sprintf(o.Msg, o.Args...) + "\n"
+	case MethodInfox, MethodNoticex:
+		msg := o.Msg
+		for i := 0; i+1 < len(o.Args); i += 2 {
+			msg += fmt.Sprintf(" %v=%v", o.Args[i], o.Args[i+1])
+		}
+		return msg + "\n"
+	default:
+		return o.Msg
+	}
+}
+
+// WriteTo makes the call on l.
+func (o Output) WriteTo(l io.Logger) {
+	switch o.Method {
+	case MethodPrint:
+		l.Print(o.Msg)
+	case MethodPrintln:
+		l.Println(o.Msg)
+	case MethodInfof:
+		l.Infof(o.Msg, o.Args...)
+	case MethodNoticef:
+		l.Noticef(o.Msg, o.Args...)
+	case MethodInfox:
+		l.Infox(o.Msg, o.Args...)
+	case MethodNoticex:
+		l.Noticex(o.Msg, o.Args...)
+	}
+}
+
+// captureLogger passes the output that is written to it to a capture function. Calls that are not used for the
+// output are passed through to the wrapped logger.
+type captureLogger struct {
+	io.Logger
+
+	capture func(Output)
+}
+
+// NewCaptureLogger returns a logger that passes the output that is written with Print, Println, Infof, Noticef,
+// Infox and Noticex to capture instead of writing it to l. Other calls are made on l.
+func NewCaptureLogger(l io.Logger, capture func(Output)) io.Logger {
+	return &captureLogger{Logger: l, capture: capture}
+}
+
+func (l *captureLogger) Print(data string) {
+	l.capture(Output{Method: MethodPrint, Msg: data})
+}
+
+func (l *captureLogger) Println(data string) {
+	l.capture(Output{Method: MethodPrintln, Msg: data})
+}
+
+func (l *captureLogger) Infof(msg string, args ...any) {
+	l.capture(Output{Method: MethodInfof, Msg: msg, Args: args})
+}
+
+func (l *captureLogger) Noticef(msg string, args ...any) {
+	l.capture(Output{Method: MethodNoticef, Msg: msg, Args: args})
+}
+
+func (l *captureLogger) Infox(msg string, kv ...any) {
+	l.capture(Output{Method: MethodInfox, Msg: msg, Args: kv})
+}
+
+func (l *captureLogger) Noticex(msg string, kv ...any) {
+	l.capture(Output{Method: MethodNoticex, Msg: msg, Args: kv})
+}
@@ -0,0 +1,34 @@
+package logger_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/flowexec/flow/internal/logger"
+)
+
+var _ = Describe("CaptureLogger", func() {
+	It("should capture the output calls instead of writing them", func() {
+		var captured []logger.Output
+		l := logger.NewCaptureLogger(nil, func(out logger.Output) { captured = append(captured, out) })
+		l.Print("one")
+		l.Println("two")
+		l.Infof("three %d", 3)
+		l.Noticex("four", "key", "value")
+		Expect(captured).To(Equal([]logger.Output{
+			{Method: logger.MethodPrint, Msg: "one"},
+			{Method: logger.MethodPrintln, Msg: "two"},
+			{Method: logger.MethodInfof, Msg: "three %d", Args: []any{3}},
+			{Method: logger.MethodNoticex, Msg: "four", Args: []any{"key", "value"}},
+		}))
+	})
+
+	It("should return the output as text", func() {
+		Expect(logger.Output{Method: logger.MethodPrint, Msg: "one"}.Text()).To(Equal("one"))
+		Expect(logger.Output{Method: logger.MethodPrintln, Msg: "two"}.Text()).To(Equal("two\n"))
+		Expect(logger.Output{Method: logger.MethodNoticef, Msg: "three %d", Args: []any{3}}.Text()).
+			To(Equal("three 3\n"))
+		Expect(logger.Output{Method: logger.MethodInfox, Msg: "four", Args: []any{"key", "value"}}.Text()).
+			To(Equal("four key=value\n"))
+	})
+})
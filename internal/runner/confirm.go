@@ -0,0 +1,163 @@
+package runner
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/flowexec/tuikit/views"
+
+	"github.com/flowexec/flow/internal/context"
+	"github.com/flowexec/flow/internal/io"
+	envUtils "github.com/flowexec/flow/internal/utils/env"
+	execUtils "github.com/flowexec/flow/internal/utils/executables"
+	"github.com/flowexec/flow/types/executable"
+)
+
+const confirmKey = "confirm"
+
+// confirmMu makes sure that only one confirmation is prompted for at a time, e.g. when parallel steps require one.
+var confirmMu sync.Mutex
+
+// Confirm asks for the confirmation required by the executable, if any. The env map is used to expand the
+// confirmation phrase. An executable is only confirmed once per context.
+func Confirm(ctx *context.Context, e *executable.Executable, envMap map[string]string) error {
+	confirmation := e.Confirmation()
+	if confirmation == nil {
+		return nil
+	}
+
+	confirmMu.Lock()
+	defer confirmMu.Unlock()
+	if ctx.IsConfirmed(e.Ref()) {
+		return nil
+	}
+	msg := confirmation.Message
+	if msg == "" {
+		msg = fmt.Sprintf("Are you sure you want to run %s?", e.Ref())
+	}
+	defaultEnv := envUtils.DefaultEnv(ctx, e)
+	phrase := os.Expand(confirmation.Phrase, func(key string) string {
+		if val, found := envMap[key]; found {
+			return val
+		}
+		if val, found := defaultEnv[key]; found {
+			return val
+		}
+		return os.Getenv(key)
+	})
+	if err := confirmRun(ctx, msg, phrase); err != nil {
+		return fmt.Errorf("%s: %w", e.Ref(), err)
+	}
+	ctx.MarkConfirmed(e.Ref())
+	return nil
+}
+
+// ConfirmRun prompts for a confirmation with the tuikit form. If a phrase is set, it must be typed to confirm.
+// Confirmations are skipped when --yes is set and fail when input is disabled.
+func ConfirmRun(ctx *context.Context, msg, phrase string) error {
+	confirmMu.Lock()
+	defer confirmMu.Unlock()
+	return confirmRun(ctx, msg, phrase)
+}
+
+func confirmRun(ctx *context.Context, msg, phrase string) error {
+	if ctx.SkipConfirmations {
+		return nil
+	}
+	if ctx.DisableInput {
+		return errors.New("confirmation required but input is disabled - use --yes to confirm the run")
+	}
+
+	field := &views.FormField{Key: confirmKey, Title: msg, Type: views.PromptTypeConfirm}
+	if phrase != "" {
+		field.Type = views.PromptTypeText
+		field.Description = fmt.Sprintf("Type '%s' to confirm", phrase)
+	}
+	form, err := views.NewForm(io.Theme(ctx.Config.Theme.String()), ctx.StdIn(), ctx.StdOut(), field)
+	if err != nil {
+		return err
+	}
+	if err := form.Run(ctx.Ctx); err != nil {
+		return err
+	}
+
+	val := form.FindByKey(confirmKey).Value()
+	switch {
+	case phrase != "" && strings.TrimSpace(val) != phrase:
+		return fmt.Errorf("run not confirmed - the entered value did not match '%s'", phrase)
+	case phrase == "" && val != "true":
+		return errors.New("run not confirmed")
+	}
+	return nil
+}
+
+// ConfirmationRequired reports whether a confirmation will be prompted for while the executable is run. This
+// includes the confirmations of the executables that it references in its steps and hooks, the given hooks and
+// the reviews of serial steps.
+func ConfirmationRequired(ctx *context.Context, e *executable.Executable, hooks ...*executable.Hooks) bool {
+	if ctx.SkipConfirmations {
+		return false
+	}
+	visited := make(map[executable.Ref]bool)
+	for _, h := range hooks {
+		if hooksRequireConfirmation(ctx, h, visited) {
+			return true
+		}
+	}
+	return confirmationRequired(ctx, e, visited)
+}
+
+func confirmationRequired(ctx *context.Context, e *executable.Executable, visited map[executable.Ref]bool) bool {
+	if visited[e.Ref()] {
+		return false
+	}
+	visited[e.Ref()] = true
+	if e.Confirmation() != nil && !ctx.IsConfirmed(e.Ref()) {
+		return true
+	}
+
+	var refs []executable.Ref
+	switch {
+	case e.Serial != nil:
+		for i, step := range e.Serial.Execs {
+			if step.ReviewRequired && i < len(e.Serial.Execs)-1 {
+				return true
+			}
+			refs = append(refs, step.Ref)
+		}
+	case e.Parallel != nil:
+		for _, step := range e.Parallel.Execs {
+			refs = append(refs, step.Ref)
+		}
+	}
+	for _, ref := range refs {
+		if ref == "" {
+			continue
+		}
+		if child, err := execUtils.ExecutableForRef(ctx, ref); err == nil && confirmationRequired(ctx, child, visited) {
+			return true
+		}
+	}
+	return hooksRequireConfirmation(ctx, e.Hooks, visited)
+}
+
+func hooksRequireConfirmation(ctx *context.Context, hooks *executable.Hooks, visited map[executable.Ref]bool) bool {
+	if hooks == nil {
+		return false
+	}
+	for _, list := range []executable.HookList{hooks.Before, hooks.After, hooks.OnFailure, hooks.Finally} {
+		for _, hook := range list {
+			if hook.Ref == "" {
+				continue
+			}
+			h, err := execUtils.ExecutableForRef(ctx, hook.Ref)
+			if err == nil && confirmationRequired(ctx, h, visited) {
+				return true
+			}
+		}
+	}
+	return false
+}
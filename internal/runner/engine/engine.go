@@ -15,6 +15,19 @@ type Result struct {
 	ID      string
 	Error   error
 	Retries int
+	Skipped bool
+}
+
+// Status returns whether the execution succeeded, failed or was skipped.
+func (r Result) Status() string {
+	switch {
+	case r.Skipped:
+		return "skipped"
+	case r.Error != nil:
+		return "failed"
+	default:
+		return "succeeded"
+	}
 }
 
 type ResultSummary struct {
@@ -30,6 +43,19 @@ func (rs ResultSummary) HasErrors() bool {
 	return false
 }
 
+// Steps returns a line for every result with its status and error, if any.
+func (rs ResultSummary) Steps() string {
+	var res string
+	for _, r := range rs.Results {
+		res += fmt.Sprintf("- %s: %s", r.ID, r.Status())
+		if r.Error != nil {
+			res += fmt.Sprintf(" (%v)", r.Error)
+		}
+		res += "\n"
+	}
+	return res
+}
+
 func (rs ResultSummary) String() string {
 	var res string
 	if rs.HasErrors() {
@@ -123,4 +123,16 @@ var _ = Describe("e.Execute", func() {
 			Expect(summary.HasErrors()).To(BeTrue())
 		})
 	})
+
+	Context("ResultSummary", func() {
+		It("should list the status of every result", func() {
+			summary := engine.ResultSummary{Results: []engine.Result{
+				{ID: "exec1"},
+				{ID: "exec2", Skipped: true},
+				{ID: "exec3", Error: errors.New("error")},
+			}}
+
+			Expect(summary.Steps()).To(Equal("- exec1: succeeded\n- exec2: skipped\n- exec3: failed (error)\n"))
+		})
+	})
 })
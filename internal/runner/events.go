@@ -0,0 +1,158 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/flowexec/tuikit/io"
+
+	"github.com/flowexec/flow/internal/logger"
+	"github.com/flowexec/flow/internal/services/run"
+	"github.com/flowexec/flow/types/executable"
+)
+
+const (
+	ExecutionStartedMsg  = "execution started"
+	ExecutionFinishedMsg = "execution finished"
+	StepStartedMsg       = "step started"
+	StepFinishedMsg      = "step finished"
+	// StepTimeFormat is the format of the start and end times of execution and step events.
+	StepTimeFormat = time.RFC3339Nano
+)
+
+// LogExecutionStarted logs a structured event for the start of a `flow exec` run. Like step events, execution
+// events are always written to the log archive.
+func LogExecutionStarted(ref string, start time.Time) {
+	logger.Log().Debugx(ExecutionStartedMsg, "executable", ref, "start", start.Format(StepTimeFormat))
+}
+
+// LogExecutionFinished logs a structured event for the result of a `flow exec` run.
+func LogExecutionFinished(ref string, start time.Time, err error) {
+	end := time.Now()
+	status := StatusSuccess
+	if err != nil {
+		status = StatusFailure
+	}
+	logger.Log().Debugx(
+		ExecutionFinishedMsg,
+		"executable", ref,
+		"start", start.Format(StepTimeFormat), "end", end.Format(StepTimeFormat),
+		"duration", end.Sub(start).Round(time.Millisecond).String(),
+		"exitCode", run.ExitCode(err), "status", status,
+	)
+}
+
+// Step is a serial or parallel step of an executable. Steps are identified by the reference of their parent
+// executable and their position in it.
+type Step struct {
+	Parent string
+	Index  int
+	ID     string
+	// Label describes the step to users. It is the reference or the command of the step.
+	Label   string
+	Retries int
+	// SkipReason is set for steps that are skipped.
+	SkipReason string
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewStep returns a step and reports it to the step observer, if one is set. The step is cancelled when ctx, the
+// context of the execution that runs it, is done.
+func NewStep(ctx context.Context, parent string, index int, id, label string, retries int) *Step {
+	stepCtx, cancel := context.WithCancel(ctx)
+	s := &Step{Parent: parent, Index: index, ID: id, Label: label, Retries: retries, ctx: stepCtx, cancel: cancel}
+	for _, o := range stepObservers() {
+		o.StepAdded(s)
+	}
+	return s
+}
+
+// Cancel interrupts the running attempt of the step. Remaining attempts are not run.
+func (s *Step) Cancel() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+}
+
+// Prepare sets the context that cancels the executable of the step and the logger that its output is written to.
+// The output is written to out, or the default logger when it is nil, wrapped by the step loggers of the observers.
+func (s *Step) Prepare(e *executable.Executable, out io.Logger) {
+	if e.Exec == nil {
+		return
+	}
+	e.Exec.SetRunContext(s.ctx)
+	for _, o := range stepObservers() {
+		l := out
+		if l == nil {
+			l = logger.Log()
+		}
+		if wrapped := o.StepLogger(s, l); wrapped != nil {
+			out = wrapped
+		}
+	}
+	e.Exec.SetLogger(out)
+}
+
+// Track wraps the function of the step so that the start and end of every attempt are logged as structured events
+// and reported to the step observer. The events are always written to the log archive, even when debug logs are
+// not displayed.
+func (s *Step) Track(fn func() error) func() error {
+	attempt := 0
+	return func() error {
+		attempt++
+		start := time.Now()
+		logger.Log().Debugx(
+			StepStartedMsg,
+			"executable", s.Parent, "index", s.Index, "step", s.ID, "attempt", attempt,
+			"start", start.Format(StepTimeFormat),
+		)
+		obs := stepObservers()
+		for _, o := range obs {
+			o.StepStarted(s, attempt)
+		}
+		err := s.ctx.Err()
+		if err == nil {
+			err = fn()
+		}
+		if err != nil && s.ctx.Err() != nil {
+			err = fmt.Errorf("step cancelled - %w", err)
+		}
+		end := time.Now()
+		status := "succeeded"
+		if err != nil {
+			status = "failed"
+		}
+		logger.Log().Debugx(
+			StepFinishedMsg,
+			"executable", s.Parent, "index", s.Index, "step", s.ID, "attempt", attempt,
+			"start", start.Format(StepTimeFormat), "end", end.Format(StepTimeFormat),
+			"duration", end.Sub(start).Round(time.Millisecond).String(),
+			"exitCode", run.ExitCode(err), "status", status,
+		)
+		for _, o := range obs {
+			o.StepFinished(s, attempt, err)
+		}
+		return err
+	}
+}
+
+// SkipStep reports a step that is not run to the step observers.
+func SkipStep(parent string, index int, label, reason string) {
+	s := &Step{Parent: parent, Index: index, Label: label, SkipReason: reason}
+	for _, o := range stepObservers() {
+		o.StepSkipped(s)
+	}
+}
+
+// StepLabel returns the label of a step that runs the referenced executable or the command.
+func StepLabel(ref executable.Ref, cmd string) string {
+	if ref != "" {
+		return ref.String()
+	}
+	line, _, _ := strings.Cut(strings.TrimSpace(cmd), "\n")
+	return line
+}
@@ -1,6 +1,9 @@
 package exec
 
 import (
+	stdCtx "context"
+	"maps"
+
 	"github.com/pkg/errors"
 
 	"github.com/flowexec/flow/internal/context"
@@ -41,6 +44,7 @@ func (r *execRunner) Exec(
 	if err != nil {
 		return errors.Wrap(err, "unable to set parameters to env")
 	}
+	maps.Copy(envMap, runner.HookEnv(inputEnv))
 	envList := env.EnvMapToEnvList(envMap)
 
 	if cb, err := env.CreateTempEnvFiles(
@@ -71,6 +75,14 @@ func (r *execRunner) Exec(
 
 	logMode := execSpec.LogMode
 	logFields := execSpec.GetLogFields()
+	log := execSpec.GetLogger()
+	if log == nil {
+		log = logger.Log()
+	}
+	runCtx := execSpec.GetRunContext()
+	if runCtx == nil {
+		runCtx = stdCtx.Background()
+	}
 
 	switch {
 	case execSpec.Cmd == "" && execSpec.File == "":
@@ -78,9 +90,9 @@ func (r *execRunner) Exec(
 	case execSpec.Cmd != "" && execSpec.File != "":
 		return errors.New("cannot set both cmd and file")
 	case execSpec.Cmd != "":
-		return run.RunCmd(execSpec.Cmd, targetDir, envList, logMode, logger.Log(), ctx.StdIn(), logFields)
+		return run.RunCmd(runCtx, execSpec.Cmd, targetDir, envList, logMode, log, ctx.StdIn(), logFields)
 	case execSpec.File != "":
-		return run.RunFile(execSpec.File, targetDir, envList, logMode, logger.Log(), ctx.StdIn(), logFields)
+		return run.RunFile(runCtx, execSpec.File, targetDir, envList, logMode, log, ctx.StdIn(), logFields)
 	default:
 		return errors.New("unable to determine how e should be run")
 	}
@@ -0,0 +1,232 @@
+package runner
+
+import (
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/flowexec/flow/internal/context"
+	"github.com/flowexec/flow/internal/logger"
+	"github.com/flowexec/flow/internal/runner/engine"
+	envUtils "github.com/flowexec/flow/internal/utils/env"
+	execUtils "github.com/flowexec/flow/internal/utils/executables"
+	"github.com/flowexec/flow/types/executable"
+)
+
+const (
+	// ExecutableRefEnvVar is set to the reference of the executable for its hooks.
+	ExecutableRefEnvVar = "FLOW_EXECUTABLE_REF"
+	// ExecutableStatusEnvVar is set to the result status of the executable for its after, onFailure and finally
+	// hooks.
+	ExecutableStatusEnvVar = "FLOW_EXECUTABLE_STATUS"
+	// ExecutableDurationEnvVar is set to the run duration of the executable for its after, onFailure and finally
+	// hooks.
+	ExecutableDurationEnvVar = "FLOW_EXECUTABLE_DURATION"
+	// ExecutableErrorEnvVar is set to the error message of the failed executable for its onFailure hooks.
+	ExecutableErrorEnvVar = "FLOW_EXECUTABLE_ERROR"
+
+	StatusSuccess = "success"
+	StatusFailure = "failure"
+)
+
+// hookEnvKeys are the keys of the values that describe the executable to its hooks. They are passed to the hooks
+// with their input env instead of the process environment, so that hooks of executables that run at the same time
+// don't see each other's values.
+var hookEnvKeys = []string{
+	ExecutableRefEnvVar, ExecutableStatusEnvVar, ExecutableDurationEnvVar, ExecutableErrorEnvVar,
+}
+
+// HookEnv returns the values of the input env that describe the executable that a hook is run for. Runners add them
+// to the environment of the hook's commands and pass them on to the steps of serial and parallel hooks.
+func HookEnv(inputEnv map[string]string) map[string]string {
+	env := make(map[string]string)
+	for _, key := range hookEnvKeys {
+		if val, found := inputEnv[key]; found {
+			env[key] = val
+		}
+	}
+	return env
+}
+
+// ExecWithGlobalHooks runs the executable with the given hooks around it. The first hooks are the outermost, e.g.
+// the user config hooks followed by the workspace hooks. Hook commands are run from the workspace root.
+func ExecWithGlobalHooks(
+	ctx *context.Context,
+	e *executable.Executable,
+	eng engine.Engine,
+	inputEnv map[string]string,
+	hooks ...*executable.Hooks,
+) error {
+	// the executable is confirmed before its global hooks are run
+	if err := Confirm(ctx, e, inputEnv); err != nil {
+		return err
+	}
+	run := func() error { return Exec(ctx, e, eng, inputEnv) }
+	for i := len(hooks) - 1; i >= 0; i-- {
+		if hooks[i] == nil {
+			continue
+		}
+		h, next := hooks[i], run
+		run = func() error { return runWithHooks(ctx, e, eng, inputEnv, h, "//", next) }
+	}
+	return run()
+}
+
+// execWithHooks runs the executable between its own hooks. Hook steps inherit the environment of the executable.
+func execWithHooks(
+	ctx *context.Context,
+	e *executable.Executable,
+	eng engine.Engine,
+	inputEnv map[string]string,
+) error {
+	if execEnv := e.Env(); execEnv != nil {
+		envMap, err := envUtils.BuildEnvMap(ctx.Config.CurrentVaultName(), execEnv, ctx.Args, inputEnv, nil)
+		if err != nil {
+			return errors.Wrap(err, "unable to resolve parameters")
+		}
+		// the resolved values are passed with the input env instead of the process environment so that secrets are
+		// only visible to the executable and its hooks
+		env := make(map[string]string, len(inputEnv)+len(envMap))
+		maps.Copy(env, inputEnv)
+		maps.Copy(env, envMap)
+		inputEnv = env
+	}
+	return runWithHooks(ctx, e, eng, inputEnv, e.Hooks, "", func() error {
+		return execRunner(ctx, e, eng, inputEnv)
+	})
+}
+
+// runWithHooks calls run between the before and after hooks. The finally hooks are also registered as a context
+// callback so that they are run when the process is finalized before run returns (e.g. when it is cancelled).
+func runWithHooks(
+	ctx *context.Context,
+	e *executable.Executable,
+	eng engine.Engine,
+	inputEnv map[string]string,
+	hooks *executable.Hooks,
+	dir executable.Directory,
+	run func() error,
+) error {
+	start := time.Now()
+	// hookVars returns the values that describe the executable to its hooks for the given status
+	hookVars := func(status string) map[string]string {
+		vars := map[string]string{ExecutableRefEnvVar: e.Ref().String()}
+		if status != "" {
+			vars[ExecutableStatusEnvVar] = status
+			vars[ExecutableDurationEnvVar] = time.Since(start).Round(time.Millisecond).String()
+		}
+		return vars
+	}
+
+	var once sync.Once
+	var finallyErr error
+	runFinally := func(vars map[string]string) error {
+		once.Do(func() {
+			finallyErr = runHooks(ctx, e, eng, inputEnv, vars, dir, "finally", hooks.Finally)
+		})
+		return finallyErr
+	}
+	if len(hooks.Finally) > 0 {
+		// the callback only runs the hooks when the process is finalized before the executable finished
+		ctx.AddCallback(func(_ *context.Context) error { return runFinally(hookVars(StatusFailure)) })
+	}
+
+	err := runHooks(ctx, e, eng, inputEnv, hookVars(""), dir, "before", hooks.Before)
+	if err == nil {
+		err = run()
+	}
+	status := StatusSuccess
+	if err != nil {
+		status = StatusFailure
+	}
+	vars := hookVars(status)
+
+	if err == nil {
+		err = runHooks(ctx, e, eng, inputEnv, vars, dir, "after", hooks.After)
+		if err != nil {
+			vars[ExecutableStatusEnvVar] = StatusFailure
+		}
+	}
+
+	if err != nil && len(hooks.OnFailure) > 0 {
+		failureVars := maps.Clone(vars)
+		failureVars[ExecutableErrorEnvVar] = err.Error()
+		if fErr := runHooks(ctx, e, eng, inputEnv, failureVars, dir, "onFailure", hooks.OnFailure); fErr != nil {
+			logger.Log().Error(fErr, "onFailure hook failed")
+		}
+	}
+	if fErr := runFinally(vars); fErr != nil {
+		if err == nil {
+			return fErr
+		}
+		logger.Log().Error(fErr, "finally hook failed")
+	}
+	return err
+}
+
+// runHooks runs the hooks of a stage in order. The values of hookVars are added to the input env of each hook.
+func runHooks(
+	ctx *context.Context,
+	parent *executable.Executable,
+	eng engine.Engine,
+	inputEnv, hookVars map[string]string,
+	dir executable.Directory,
+	stage string,
+	hooks executable.HookList,
+) error {
+	for i, hook := range hooks {
+		var exec *executable.Executable
+		switch {
+		case hook.Ref != "":
+			var err error
+			exec, err = execUtils.ExecutableForRef(ctx, hook.Ref)
+			if err != nil {
+				return err
+			}
+		case hook.Cmd != "":
+			exec = execUtils.ExecutableForCmd(parent, hook.Cmd, i)
+			exec.Exec.Dir = dir
+		default:
+			return fmt.Errorf("%s hook %d must have a ref or cmd", stage, i+1)
+		}
+		logger.Log().Debugf("running %s hook %s (%d/%d)", stage, exec.Ref(), i+1, len(hooks))
+
+		hookEnv := maps.Clone(inputEnv)
+		if hookEnv == nil {
+			hookEnv = make(map[string]string)
+		}
+		maps.Copy(hookEnv, hookVars)
+		if len(hook.Args) > 0 {
+			execEnv := exec.Env()
+			if execEnv == nil || execEnv.Args == nil {
+				logger.Log().Warnf(
+					"executable %s has no arguments defined, skipping argument processing",
+					exec.Ref().String(),
+				)
+			} else {
+				a, err := envUtils.BuildArgsEnvMap(execEnv.Args, hook.Args, hookEnv)
+				if err != nil {
+					return errors.Wrap(err, "unable to process hook arguments")
+				}
+				maps.Copy(hookEnv, a)
+			}
+		}
+
+		done := StartOperation(&Operation{
+			Parent:     parent.Ref().String(),
+			ID:         exec.Ref().String(),
+			Kind:       OperationHook,
+			Name:       fmt.Sprintf("%s hook: %s", stage, StepLabel(hook.Ref, hook.Cmd)),
+			Attributes: map[string]any{"flow.hook.stage": stage, "flow.hook.index": i + 1},
+		})
+		err := Exec(ctx, exec, eng, hookEnv)
+		done(err)
+		if err != nil {
+			return errors.Wrapf(err, "%s hook %s failed", stage, exec.Ref())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package runner
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/flowexec/tuikit/io"
+)
+
+var (
+	observers  []StepObserver
+	observerMu sync.RWMutex
+)
+
+// StepObserver is notified of the progress of serial and parallel steps, e.g. to display it while they run.
+type StepObserver interface {
+	StepAdded(step *Step)
+	StepStarted(step *Step, attempt int)
+	StepFinished(step *Step, attempt int, err error)
+	StepSkipped(step *Step)
+	// StepLogger returns the logger that the output of the step is written to. The logger that the output would
+	// otherwise be written to is passed so that it can be wrapped. A nil logger keeps it.
+	StepLogger(step *Step, l io.Logger) io.Logger
+}
+
+// AddStepObserver adds an observer that is notified of the progress of serial and parallel steps. Observers are
+// notified in the order that they are added. The returned function removes the observer.
+func AddStepObserver(o StepObserver) func() {
+	observerMu.Lock()
+	defer observerMu.Unlock()
+	observers = append(observers, o)
+	return func() {
+		observerMu.Lock()
+		defer observerMu.Unlock()
+		if i := slices.Index(observers, o); i >= 0 {
+			observers = slices.Delete(observers, i, i+1)
+		}
+	}
+}
+
+func stepObservers() []StepObserver {
+	observerMu.RLock()
+	defer observerMu.RUnlock()
+	return slices.Clone(observers)
+}
+
+// OperationObserver is a StepObserver that is also notified of the hooks and requests that are run.
+type OperationObserver interface {
+	OperationStarted(op *Operation)
+	OperationFinished(op *Operation, err error)
+}
+
+const (
+	OperationHook    = "hook"
+	OperationRequest = "request"
+)
+
+// Operation is a hook or a request that is run for an executable. Operations are identified by the reference of
+// the executable that they are run for and, like steps, have the ID of the executable that they run.
+type Operation struct {
+	Parent string
+	ID     string
+	Kind   string
+	Name   string
+	// Attributes describe the operation. They can be set until it finishes.
+	Attributes map[string]any
+}
+
+// StartOperation reports the start of the operation to the operation observers. The returned function reports
+// its result.
+func StartOperation(op *Operation) func(err error) {
+	var obs []OperationObserver
+	for _, o := range stepObservers() {
+		if oo, ok := o.(OperationObserver); ok {
+			obs = append(obs, oo)
+		}
+	}
+	if op.Attributes == nil {
+		op.Attributes = make(map[string]any)
+	}
+	for _, o := range obs {
+		o.OperationStarted(op)
+	}
+	return func(err error) {
+		for _, o := range obs {
+			o.OperationFinished(op, err)
+		}
+	}
+}
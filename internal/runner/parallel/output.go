@@ -0,0 +1,159 @@
+package parallel
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/flowexec/tuikit/io"
+	"github.com/flowexec/tuikit/themes"
+
+	"github.com/flowexec/flow/internal/logger"
+	"github.com/flowexec/flow/types/executable"
+)
+
+// stepOutput is the logger that the output of a parallel step is written to. Depending on the output mode, the
+// output is prefixed with the step name or buffered until the step attempt completes. Calls that are not used for
+// the step output are passed through to the wrapped logger.
+type stepOutput struct {
+	io.Logger
+
+	base  io.Logger
+	mode  executable.ParallelExecutableTypeOutputMode
+	name  string
+	style lipgloss.Style
+	width int
+	// flushMu is shared by the steps so that the buffered blocks are not interleaved.
+	flushMu *sync.Mutex
+
+	mu        sync.Mutex
+	lineStart bool
+	buffer    []bufferedOutput
+}
+
+func newStepOutput(
+	base io.Logger,
+	mode executable.ParallelExecutableTypeOutputMode,
+	name string,
+	color lipgloss.Color,
+	flushMu *sync.Mutex,
+) *stepOutput {
+	o := &stepOutput{
+		base:      base,
+		mode:      mode,
+		name:      name,
+		style:     lipgloss.NewStyle().Foreground(color),
+		flushMu:   flushMu,
+		lineStart: true,
+	}
+	o.Logger = logger.NewCaptureLogger(base, o.write)
+	return o
+}
+
+// stepColors returns the theme colors that are cycled through for the step prefixes.
+func stepColors(theme themes.Theme) []lipgloss.Color {
+	p := theme.ColorPalette()
+	return []lipgloss.Color{
+		p.PrimaryColor(), p.SecondaryColor(), p.TertiaryColor(), p.InfoColor(), p.WarningColor(), p.EmphasisColor(),
+	}
+}
+
+func (o *stepOutput) prefix() string {
+	return o.style.Render(fmt.Sprintf("[%-*s]", o.width, o.name)) + " "
+}
+
+func (o *stepOutput) write(out logger.Output) {
+	switch {
+	case o.mode != executable.ParallelExecutableTypeOutputModePrefixed:
+		o.record(out)
+	case out.Method == logger.MethodPrint || out.Method == logger.MethodPrintln:
+		o.printPrefixed(out.Text())
+	default:
+		out.Msg = o.prefix() + out.Msg
+		out.WriteTo(o.base)
+	}
+}
+
+// printPrefixed writes the data with the prefix at the start of each line.
+func (o *stepOutput) printPrefixed(data string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	var b strings.Builder
+	for _, line := range strings.SplitAfter(data, "\n") {
+		if line == "" {
+			continue
+		}
+		if o.lineStart {
+			b.WriteString(o.prefix())
+		}
+		b.WriteString(line)
+		o.lineStart = strings.HasSuffix(line, "\n")
+	}
+	o.base.Print(b.String())
+}
+
+// bufferedOutput is output that is written when the step attempt completes.
+type bufferedOutput struct {
+	logger.Output
+
+	// mode is the log mode that was set when the output was written. The exec's log mode is only set on the wrapped
+	// logger while its output is written.
+	mode io.LogMode
+}
+
+func (b bufferedOutput) writeTo(l io.Logger) {
+	if cur := l.LogMode(); b.mode != cur {
+		l.SetMode(b.mode)
+		defer l.SetMode(cur)
+	}
+	b.WriteTo(l)
+}
+
+// record buffers the output with the log mode that is set when it is written.
+func (o *stepOutput) record(out logger.Output) {
+	mode := o.base.LogMode()
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.buffer = append(o.buffer, bufferedOutput{Output: out, mode: mode})
+}
+
+// wrap returns fn with the buffered output written when it returns. Each attempt of the step is written separately.
+func (o *stepOutput) wrap(fn func() error) func() error {
+	if o.mode == executable.ParallelExecutableTypeOutputModePrefixed {
+		return fn
+	}
+	return func() error {
+		err := fn()
+		o.flush(err)
+		return err
+	}
+}
+
+func (o *stepOutput) flush(err error) {
+	o.mu.Lock()
+	buffer := o.buffer
+	o.buffer = nil
+	o.mu.Unlock()
+
+	if len(buffer) == 0 {
+		return
+	}
+	if err == nil && o.mode == executable.ParallelExecutableTypeOutputModeBufferedOnFailure {
+		// the output of attempts that succeed is only written to the log archive, as debug messages
+		for _, b := range buffer {
+			o.base.Debugx(strings.TrimRight(b.Text(), "\n"), "step", o.name)
+		}
+		return
+	}
+	o.flushMu.Lock()
+	defer o.flushMu.Unlock()
+	header := o.name
+	if err != nil {
+		header += " (failed)"
+	}
+	o.base.Println(o.style.Render(header))
+	for _, b := range buffer {
+		b.writeTo(o.base)
+	}
+}
@@ -0,0 +1,177 @@
+//nolint:testpackage
+package parallel
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/flowexec/tuikit/io"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/flowexec/flow/types/executable"
+)
+
+// fakeLogger records the output that is written to it. Calls that aren't used by the step output panic.
+type fakeLogger struct {
+	io.Logger
+
+	mu     sync.Mutex
+	mode   io.LogMode
+	writes []string
+	modes  []io.LogMode
+	debug  []string
+}
+
+func (l *fakeLogger) write(data string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.writes = append(l.writes, ansi.Strip(data))
+	l.modes = append(l.modes, l.mode)
+}
+
+func (l *fakeLogger) output() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return strings.Join(l.writes, "")
+}
+
+func (l *fakeLogger) LogMode() io.LogMode           { return l.mode }
+func (l *fakeLogger) SetMode(mode io.LogMode)       { l.mode = mode }
+func (l *fakeLogger) Print(data string)             { l.write(data) }
+func (l *fakeLogger) Println(data string)           { l.write(data + "\n") }
+func (l *fakeLogger) Infof(msg string, args ...any) { l.write(fmt.Sprintf(msg, args...) + "\n") }
+
+func (l *fakeLogger) Debugx(msg string, kv ...any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.debug = append(l.debug, fmt.Sprint(append([]any{msg}, kv...)...))
+}
+
+var _ = Describe("stepOutput", func() {
+	var (
+		logger  *fakeLogger
+		flushMu *sync.Mutex
+	)
+
+	BeforeEach(func() {
+		logger = &fakeLogger{mode: io.Text}
+		flushMu = &sync.Mutex{}
+	})
+
+	newOutput := func(mode executable.ParallelExecutableTypeOutputMode, name string) *stepOutput {
+		out := newStepOutput(logger, mode, name, "", flushMu)
+		out.width = 5
+		return out
+	}
+
+	Describe("prefixed", func() {
+		It("prefixes each line with the padded step name", func() {
+			out := newOutput(executable.ParallelExecutableTypeOutputModePrefixed, "a")
+			out.Print("one\ntwo\n")
+			out.Infof("three %d", 3)
+			Expect(logger.output()).To(Equal("[a    ] one\n[a    ] two\n[a    ] three 3\n"))
+		})
+
+		It("only prefixes the start of lines that are written in parts", func() {
+			out := newOutput(executable.ParallelExecutableTypeOutputModePrefixed, "a")
+			out.Print("one")
+			out.Print(" more\ntw")
+			out.Println("o")
+			Expect(logger.output()).To(Equal("[a    ] one more\n[a    ] two\n"))
+		})
+
+		It("writes the output when it's written", func() {
+			out := newOutput(executable.ParallelExecutableTypeOutputModePrefixed, "a")
+			run := out.wrap(func() error {
+				out.Println("one")
+				Expect(logger.output()).To(Equal("[a    ] one\n"))
+				return nil
+			})
+			Expect(run()).To(Succeed())
+		})
+	})
+
+	Describe("grouped", func() {
+		It("writes the output of each step below its name when the step completes", func() {
+			a := newOutput(executable.ParallelExecutableTypeOutputModeGrouped, "a")
+			b := newOutput(executable.ParallelExecutableTypeOutputModeGrouped, "b")
+			runA := a.wrap(func() error {
+				a.Println("a-one")
+				return nil
+			})
+			runB := b.wrap(func() error {
+				b.Println("b-one")
+				a.Println("a-two")
+				b.Infof("b-two")
+				return errors.New("failed")
+			})
+			Expect(runB()).NotTo(Succeed())
+			Expect(logger.output()).To(Equal("b (failed)\nb-one\nb-two\n"))
+
+			Expect(runA()).To(Succeed())
+			Expect(logger.output()).To(Equal("b (failed)\nb-one\nb-two\na\na-two\na-one\n"))
+		})
+
+		It("writes the output of each attempt separately", func() {
+			out := newOutput(executable.ParallelExecutableTypeOutputModeGrouped, "a")
+			attempt := 0
+			run := out.wrap(func() error {
+				attempt++
+				out.Println(fmt.Sprintf("attempt %d", attempt))
+				if attempt == 1 {
+					return errors.New("failed")
+				}
+				return nil
+			})
+			Expect(run()).NotTo(Succeed())
+			Expect(run()).To(Succeed())
+			Expect(logger.output()).To(Equal("a (failed)\nattempt 1\na\nattempt 2\n"))
+		})
+
+		It("writes the output with the log mode that was set when it was written", func() {
+			out := newOutput(executable.ParallelExecutableTypeOutputModeGrouped, "a")
+			run := out.wrap(func() error {
+				logger.SetMode(io.Logfmt)
+				out.Println("one")
+				logger.SetMode(io.Text)
+				return nil
+			})
+			Expect(run()).To(Succeed())
+			Expect(logger.modes).To(Equal([]io.LogMode{io.Text, io.Logfmt}))
+			Expect(logger.LogMode()).To(Equal(io.Text))
+		})
+	})
+
+	Describe("buffered-on-failure", func() {
+		It("only writes the output of attempts that succeed to the log archive", func() {
+			out := newOutput(executable.ParallelExecutableTypeOutputModeBufferedOnFailure, "a")
+			run := out.wrap(func() error {
+				out.Println("one")
+				return nil
+			})
+			Expect(run()).To(Succeed())
+			Expect(logger.output()).To(BeEmpty())
+			Expect(logger.debug).To(Equal([]string{fmt.Sprint("one", "step", "a")}))
+		})
+
+		It("only writes the output of attempts that fail", func() {
+			out := newOutput(executable.ParallelExecutableTypeOutputModeBufferedOnFailure, "a")
+			attempt := 0
+			run := out.wrap(func() error {
+				attempt++
+				out.Println(fmt.Sprintf("attempt %d", attempt))
+				if attempt == 1 {
+					return errors.New("failed")
+				}
+				return nil
+			})
+			Expect(run()).NotTo(Succeed())
+			Expect(run()).To(Succeed())
+			Expect(logger.output()).To(Equal("a (failed)\nattempt 1\n"))
+		})
+	})
+})
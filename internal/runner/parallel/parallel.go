@@ -4,11 +4,15 @@ import (
 	stdCtx "context"
 	"fmt"
 	"maps"
+	"strconv"
+	"sync"
 
+	tuikitIO "github.com/flowexec/tuikit/io"
 	"github.com/pkg/errors"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/flowexec/flow/internal/context"
+	"github.com/flowexec/flow/internal/io"
 	"github.com/flowexec/flow/internal/logger"
 	"github.com/flowexec/flow/internal/runner"
 	"github.com/flowexec/flow/internal/runner/engine"
@@ -101,6 +105,10 @@ func handleExec(
 	group.SetLimit(limit)
 
 	dataMap := expr.ExpressionEnv(ctx, parent, cacheData, promptedEnv)
+	outputMode := parallelSpec.OutputMode
+	colors := stepColors(io.Theme(ctx.Config.Theme.String()))
+	var outputs []*stepOutput
+	var flushMu sync.Mutex
 
 	var execs []engine.Exec
 	for i, refConfig := range parallelSpec.Execs {
@@ -109,6 +117,10 @@ func handleExec(
 				return err
 			} else if !truthy {
 				logger.Log().Debugf("skipping execution %d/%d", i+1, len(parallelSpec.Execs))
+				runner.SkipStep(
+					parent.Ref().String(), i+1, runner.StepLabel(refConfig.Ref, refConfig.Cmd),
+					fmt.Sprintf("condition %q is false", refConfig.If),
+				)
 				continue
 			}
 		}
@@ -128,6 +140,7 @@ func handleExec(
 
 		execPromptedEnv := make(map[string]string)
 		maps.Copy(promptedEnv, execPromptedEnv)
+		maps.Copy(execPromptedEnv, runner.HookEnv(promptedEnv))
 		if len(refConfig.Args) > 0 {
 			execEnv := exec.Env()
 			if execEnv == nil || execEnv.Args == nil {
@@ -161,16 +174,36 @@ func handleExec(
 			}
 		}
 
-		runExec := func() error {
-			err := runner.Exec(ctx, exec, eng, execPromptedEnv)
-			if err != nil {
-				return err
+		step := runner.NewStep(
+			ctx.Ctx, parent.Ref().String(), i+1, exec.Ref().String(),
+			runner.StepLabel(refConfig.Ref, refConfig.Cmd), refConfig.Retries,
+		)
+		run := func() error {
+			return runner.Exec(ctx, exec, eng, execPromptedEnv)
+		}
+		var out tuikitIO.Logger
+		if exec.Exec != nil && outputMode != "" && outputMode != executable.ParallelExecutableTypeOutputModeInterleaved {
+			name := strconv.Itoa(i + 1)
+			if len(refConfig.Ref) > 0 {
+				name = refConfig.Ref.String()
 			}
-			return nil
+			stepOut := newStepOutput(logger.Log(), outputMode, name, colors[len(outputs)%len(colors)], &flushMu)
+			outputs = append(outputs, stepOut)
+			run = stepOut.wrap(run)
+			out = stepOut
 		}
+		step.Prepare(exec, out)
+		runExec := step.Track(run)
 
 		execs = append(execs, engine.Exec{ID: exec.Ref().String(), Function: runExec, MaxRetries: refConfig.Retries})
 	}
+	width := 0
+	for _, out := range outputs {
+		width = max(width, len(out.name))
+	}
+	for _, out := range outputs {
+		out.width = width
+	}
 	results := eng.Execute(
 		ctx.Ctx, execs,
 		engine.WithMode(engine.Parallel),
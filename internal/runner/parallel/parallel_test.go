@@ -3,12 +3,14 @@ package parallel_test
 import (
 	stdCtx "context"
 	"errors"
+	"sync"
 	"testing"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
 
+	"github.com/flowexec/flow/internal/context"
 	"github.com/flowexec/flow/internal/runner"
 	"github.com/flowexec/flow/internal/runner/engine"
 	"github.com/flowexec/flow/internal/runner/engine/mocks"
@@ -131,6 +133,39 @@ var _ = Describe("ParallelRunner", func() {
 			Expect(parallelRnr.Exec(ctx.Ctx, rootExec, mockEngine, promptedEnv)).ToNot(Succeed())
 		})
 
+		It("should redirect the output of each step when an output mode is set", func() {
+			rootExec.Parallel.OutputMode = executable.ParallelExecutableTypeOutputModeGrouped
+			// both steps run the same executable
+			cached := subExecs[0]
+			rootExec.Parallel.Execs = rootExec.Parallel.Execs[:2]
+			rootExec.Parallel.Execs[1].Ref = rootExec.Parallel.Execs[0].Ref
+			ctx.ExecutableCache.EXPECT().GetExecutableByRef(cached.Ref()).Return(cached, nil).Times(2)
+			ctx.RunnerMock.EXPECT().IsCompatible(gomock.Any()).Return(true).AnyTimes()
+			ctx.Logger.EXPECT().Warnf(gomock.Any(), gomock.Any()).AnyTimes()
+
+			var mu sync.Mutex
+			var steps []*executable.ExecExecutableType
+			ctx.RunnerMock.EXPECT().Exec(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ *context.Context, e *executable.Executable, _ engine.Engine, _ map[string]string) error {
+					mu.Lock()
+					defer mu.Unlock()
+					steps = append(steps, e.Exec)
+					return nil
+				}).Times(2)
+			eng := engine.NewExecEngine()
+			Expect(parallelRnr.Exec(ctx.Ctx, rootExec, eng, make(map[string]string))).To(Succeed())
+
+			Expect(steps).To(HaveLen(2))
+			for _, step := range steps {
+				Expect(step.GetLogger()).ToNot(BeNil())
+				Expect(step.GetRunContext()).ToNot(BeNil())
+			}
+			Expect(steps[0].GetLogger()).ToNot(BeIdenticalTo(steps[1].GetLogger()))
+			Expect(steps[0].GetRunContext()).ToNot(BeIdenticalTo(steps[1].GetRunContext()))
+			Expect(cached.Exec.GetLogger()).To(BeNil())
+			Expect(cached.Exec.GetRunContext()).To(BeNil())
+		})
+
 		It("should skip execution when condition is false", func() {
 			parallelSpec := rootExec.Parallel
 			parallelSpec.Execs[0].If = "false"
@@ -0,0 +1,126 @@
+package report
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+)
+
+func jsonReport(result *Result) ([]byte, error) {
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr,omitempty"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name       string           `xml:"name,attr"`
+	ClassName  string           `xml:"classname,attr"`
+	Time       string           `xml:"time,attr"`
+	Properties *junitProperties `xml:"properties,omitempty"`
+	Failure    *junitMessage    `xml:"failure,omitempty"`
+	Skipped    *junitMessage    `xml:"skipped,omitempty"`
+}
+
+type junitProperties struct {
+	Properties []junitProperty `xml:"property"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// junitReport writes a test suite for the executable and for every nested serial or parallel executable. Their steps
+// are the test cases of the suite. An executable without steps is written as a suite with a single test case.
+func junitReport(result *Result) ([]byte, error) {
+	report := junitTestSuites{Name: result.ID, Time: seconds(result)}
+	if len(result.Steps) == 0 {
+		report.Suites = append(report.Suites, junitSuite(result, []*Result{result}))
+	} else {
+		appendSuites(&report.Suites, result)
+	}
+	for _, s := range report.Suites {
+		report.Tests += s.Tests
+		report.Failures += s.Failures
+		report.Skipped += s.Skipped
+	}
+	data, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), append(data, '\n')...), nil
+}
+
+func appendSuites(suites *[]junitTestSuite, result *Result) {
+	if len(result.Steps) == 0 {
+		return
+	}
+	*suites = append(*suites, junitSuite(result, result.Steps))
+	for _, step := range result.Steps {
+		appendSuites(suites, step)
+	}
+}
+
+func junitSuite(result *Result, cases []*Result) junitTestSuite {
+	suite := junitTestSuite{Name: result.ID, Time: seconds(result), Tests: len(cases)}
+	if !result.Start.IsZero() {
+		suite.Timestamp = result.Start.Format("2006-01-02T15:04:05")
+	}
+	for _, c := range cases {
+		tc := junitTestCase{Name: caseName(c), ClassName: result.ID, Time: seconds(c)}
+		if c.Retries > 0 {
+			tc.Properties = &junitProperties{Properties: []junitProperty{
+				{Name: "attempts", Value: strconv.Itoa(c.Attempts)},
+			}}
+		}
+		switch c.Status {
+		case StatusFailed:
+			suite.Failures++
+			tc.Failure = &junitMessage{Message: c.Error, Text: c.Output}
+		case StatusSkipped:
+			suite.Skipped++
+			tc.Skipped = &junitMessage{Message: c.SkipReason}
+		}
+		suite.Cases = append(suite.Cases, tc)
+	}
+	return suite
+}
+
+func caseName(r *Result) string {
+	if r.Index == 0 {
+		return r.Label
+	}
+	return fmt.Sprintf("%d: %s", r.Index, r.Label)
+}
+
+func seconds(r *Result) string {
+	return strconv.FormatFloat(r.duration().Seconds(), 'f', 3, 64)
+}
@@ -0,0 +1,271 @@
+package report
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/flowexec/tuikit/io"
+
+	"github.com/flowexec/flow/internal/logger"
+	"github.com/flowexec/flow/internal/runner"
+	"github.com/flowexec/flow/internal/services/redact"
+	"github.com/flowexec/flow/internal/services/run"
+)
+
+type Format string
+
+const (
+	FormatJUnit Format = "junit"
+	FormatJSON  Format = "json"
+
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+	StatusSkipped   = "skipped"
+
+	// maxOutputLines is the number of output lines that are kept for each failed step.
+	maxOutputLines = 500
+	// notRunReason is the skip reason of steps that were not started, e.g. after another step failed.
+	notRunReason = "not run"
+)
+
+// Target is a report file to write and its format.
+type Target struct {
+	Format Format
+	Path   string
+}
+
+// ParseTargets parses report targets in the FORMAT=PATH form.
+func ParseTargets(values []string) ([]Target, error) {
+	targets := make([]Target, 0, len(values))
+	for _, v := range values {
+		format, path, found := strings.Cut(v, "=")
+		if !found || path == "" {
+			return nil, fmt.Errorf("invalid report '%s' - expected FORMAT=PATH", v)
+		}
+		switch Format(format) {
+		case FormatJUnit, FormatJSON:
+		default:
+			return nil, fmt.Errorf("unsupported report format '%s' - must be one of %s, %s", format, FormatJUnit, FormatJSON)
+		}
+		targets = append(targets, Target{Format: Format(format), Path: path})
+	}
+	return targets, nil
+}
+
+// Result is the result of an executable or one of its serial or parallel steps. Steps of nested serial and parallel
+// executables are the steps of the step that runs them.
+type Result struct {
+	ID         string    `json:"id,omitempty"`
+	Label      string    `json:"label"`
+	Index      int       `json:"index,omitempty"`
+	Status     string    `json:"status"`
+	Start      time.Time `json:"start,omitzero"`
+	End        time.Time `json:"end,omitzero"`
+	Duration   string    `json:"duration,omitempty"`
+	Attempts   int       `json:"attempts,omitempty"`
+	Retries    int       `json:"retries,omitempty"`
+	ExitCode   int       `json:"exitCode,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	SkipReason string    `json:"skipReason,omitempty"`
+	// Output is the output of the last attempt of a failed step.
+	Output string    `json:"output,omitempty"`
+	Steps  []*Result `json:"steps,omitempty"`
+
+	parentID string
+	running  bool
+	lines    []string
+	partial  string
+}
+
+func (r *Result) duration() time.Duration {
+	if r.Start.IsZero() || r.End.IsZero() {
+		return 0
+	}
+	return r.End.Sub(r.Start)
+}
+
+// Collector builds the result tree of an execution from the progress of its steps. It is a runner.StepObserver.
+type Collector struct {
+	mu    sync.Mutex
+	root  *Result
+	steps map[*runner.Step]*Result
+}
+
+func NewCollector(ref string) *Collector {
+	return &Collector{
+		root:  &Result{ID: ref, Label: ref, Start: time.Now(), running: true},
+		steps: make(map[*runner.Step]*Result),
+	}
+}
+
+func (c *Collector) StepAdded(s *runner.Step) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.add(s)
+}
+
+func (c *Collector) StepSkipped(s *runner.Step) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	r := c.add(s)
+	r.Status = StatusSkipped
+	r.SkipReason = s.SkipReason
+}
+
+// add must be called with the lock held. The step is added to the latest running step that runs its parent
+// executable. Command steps have the ID of their parent executable, so they are not considered.
+func (c *Collector) add(s *runner.Step) *Result {
+	parent := c.root
+	for _, r := range c.steps {
+		if r.running && r.ID == s.Parent && r.ID != r.parentID && (parent == c.root || r.Start.After(parent.Start)) {
+			parent = r
+		}
+	}
+	r := &Result{ID: s.ID, Label: s.Label, Index: s.Index, parentID: s.Parent}
+	parent.Steps = append(parent.Steps, r)
+	slices.SortStableFunc(parent.Steps, func(a, b *Result) int { return a.Index - b.Index })
+	c.steps[s] = r
+	return r
+}
+
+func (c *Collector) StepStarted(s *runner.Step, attempt int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	r, found := c.steps[s]
+	if !found {
+		return
+	}
+	if attempt == 1 {
+		r.Start = time.Now()
+	}
+	// The steps of a nested executable are added again when the step is retried.
+	c.forget(r.Steps)
+	r.Steps = nil
+	r.running = true
+	r.Attempts = attempt
+	r.Retries = attempt - 1
+	r.lines, r.partial = nil, ""
+}
+
+func (c *Collector) StepFinished(s *runner.Step, attempt int, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	r, found := c.steps[s]
+	if !found {
+		return
+	}
+	r.End = time.Now()
+	r.Duration = r.duration().Round(time.Millisecond).String()
+	r.ExitCode = run.ExitCode(err)
+	if err == nil {
+		r.running = false
+		r.Status = StatusSucceeded
+		r.Error = ""
+		return
+	}
+	r.Status = StatusFailed
+	r.Error = redact.String(err.Error())
+	r.Output = strings.Join(outputLines(r), "\n")
+	if attempt > s.Retries {
+		r.running = false
+	}
+}
+
+// forget must be called with the lock held.
+func (c *Collector) forget(results []*Result) {
+	for s, r := range c.steps {
+		if slices.Contains(results, r) {
+			c.forget(r.Steps)
+			delete(c.steps, s)
+		}
+	}
+}
+
+// StepLogger returns a logger that records the output of the step and writes it to l.
+func (c *Collector) StepLogger(s *runner.Step, l io.Logger) io.Logger {
+	return logger.NewCaptureLogger(l, func(out logger.Output) {
+		c.appendOutput(s, out.Text())
+		out.WriteTo(l)
+	})
+}
+
+func (c *Collector) appendOutput(s *runner.Step, data string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	r, found := c.steps[s]
+	if !found {
+		return
+	}
+	lines := strings.Split(r.partial+data, "\n")
+	r.partial = lines[len(lines)-1]
+	r.lines = append(r.lines, lines[:len(lines)-1]...)
+	if len(r.lines) > maxOutputLines {
+		r.lines = r.lines[len(r.lines)-maxOutputLines:]
+	}
+}
+
+func outputLines(r *Result) []string {
+	lines := r.lines
+	if r.partial != "" {
+		lines = append(lines[:len(lines):len(lines)], r.partial)
+	}
+	return lines
+}
+
+// Finish records the result of the execution. Steps that were not started are marked as skipped and steps that did
+// not finish as failed.
+func (c *Collector) Finish(err error) *Result {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, r := range c.steps {
+		switch {
+		case r.Status == "" && r.Start.IsZero():
+			r.Status = StatusSkipped
+			r.SkipReason = notRunReason
+		case r.Status == "" || r.running:
+			r.Status = StatusFailed
+			r.End = time.Now()
+			r.Duration = r.duration().Round(time.Millisecond).String()
+		}
+		r.running = false
+	}
+	c.root.running = false
+	c.root.End = time.Now()
+	c.root.Duration = c.root.duration().Round(time.Millisecond).String()
+	c.root.ExitCode = run.ExitCode(err)
+	c.root.Status = StatusSucceeded
+	if err != nil {
+		c.root.Status = StatusFailed
+		c.root.Error = redact.String(err.Error())
+	}
+	return c.root
+}
+
+// Write writes the result to the report target. The parent directories of the file are created if needed.
+func Write(target Target, result *Result) error {
+	var data []byte
+	var err error
+	switch target.Format {
+	case FormatJUnit:
+		data, err = junitReport(result)
+	case FormatJSON:
+		data, err = jsonReport(result)
+	default:
+		return fmt.Errorf("unsupported report format '%s'", target.Format)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to encode %s report - %w", target.Format, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(target.Path), 0750); err != nil {
+		return fmt.Errorf("unable to create report directory - %w", err)
+	}
+	if err := os.WriteFile(target.Path, data, 0600); err != nil {
+		return fmt.Errorf("unable to write %s report - %w", target.Format, err)
+	}
+	return nil
+}
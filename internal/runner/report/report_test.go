@@ -0,0 +1,129 @@
+package report_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/flowexec/tuikit/io/mocks"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/flowexec/flow/internal/runner"
+	"github.com/flowexec/flow/internal/runner/report"
+)
+
+func TestReport(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Report Suite")
+}
+
+var _ = Describe("Report", func() {
+	Describe("ParseTargets", func() {
+		It("should parse the format and path of the targets", func() {
+			targets, err := report.ParseTargets([]string{"junit=out/results.xml", "json=results.json"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(targets).To(Equal([]report.Target{
+				{Format: report.FormatJUnit, Path: "out/results.xml"},
+				{Format: report.FormatJSON, Path: "results.json"},
+			}))
+		})
+
+		It("should return an error for invalid targets", func() {
+			_, err := report.ParseTargets([]string{"results.xml"})
+			Expect(err).To(HaveOccurred())
+			_, err = report.ParseTargets([]string{"xml=results.xml"})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("Collector", func() {
+		var (
+			collector  *report.Collector
+			result     *report.Result
+			mockLogger *mocks.MockLogger
+		)
+
+		run := func(s *runner.Step, attempt int, err error, output string) {
+			collector.StepStarted(s, attempt)
+			if output != "" {
+				mockLogger.EXPECT().Print(output)
+				collector.StepLogger(s, mockLogger).Print(output)
+			}
+			collector.StepFinished(s, attempt, err)
+		}
+
+		BeforeEach(func() {
+			mockLogger = mocks.NewMockLogger(gomock.NewController(GinkgoT()))
+			collector = report.NewCollector("exec ws/root")
+			first := &runner.Step{Parent: "exec ws/root", Index: 1, ID: "exec ws/root", Label: "echo one"}
+			nested := &runner.Step{Parent: "exec ws/root", Index: 2, ID: "exec ws/nested", Label: "exec nested", Retries: 1}
+			skipped := &runner.Step{Parent: "exec ws/root", Index: 3, Label: "echo skipped", SkipReason: "condition is false"}
+			notRun := &runner.Step{Parent: "exec ws/root", Index: 4, ID: "exec ws/root", Label: "echo four"}
+			collector.StepAdded(first)
+			collector.StepAdded(nested)
+			collector.StepSkipped(skipped)
+			collector.StepAdded(notRun)
+
+			run(first, 1, nil, "")
+			collector.StepStarted(nested, 1)
+			child := &runner.Step{Parent: "exec ws/nested", Index: 1, ID: "exec ws/nested", Label: "exit 1"}
+			collector.StepAdded(child)
+			run(child, 1, errors.New("exit status 1"), "first attempt\n")
+			collector.StepFinished(nested, 1, errors.New("nested failed"))
+			collector.StepStarted(nested, 2)
+			child = &runner.Step{Parent: "exec ws/nested", Index: 1, ID: "exec ws/nested", Label: "exit 1"}
+			collector.StepAdded(child)
+			run(child, 1, errors.New("exit status 1"), "second attempt\n")
+			collector.StepFinished(nested, 2, errors.New("nested failed"))
+			result = collector.Finish(errors.New("root failed"))
+		})
+
+		It("should record the results of the steps as a tree", func() {
+			Expect(result.Status).To(Equal(report.StatusFailed))
+			Expect(result.Error).To(Equal("root failed"))
+			Expect(result.Steps).To(HaveLen(4))
+			Expect(result.Steps[0].Status).To(Equal(report.StatusSucceeded))
+
+			nested := result.Steps[1]
+			Expect(nested.Status).To(Equal(report.StatusFailed))
+			Expect(nested.Attempts).To(Equal(2))
+			Expect(nested.Retries).To(Equal(1))
+			Expect(nested.Steps).To(HaveLen(1))
+			Expect(nested.Steps[0].Output).To(Equal("second attempt"))
+
+			Expect(result.Steps[2].Status).To(Equal(report.StatusSkipped))
+			Expect(result.Steps[2].SkipReason).To(Equal("condition is false"))
+			Expect(result.Steps[3].Status).To(Equal(report.StatusSkipped))
+			Expect(result.Steps[3].SkipReason).To(Equal("not run"))
+		})
+
+		It("should write a junit report with a suite for every executable with steps", func() {
+			path := filepath.Join(GinkgoT().TempDir(), "reports", "results.xml")
+			Expect(report.Write(report.Target{Format: report.FormatJUnit, Path: path}, result)).To(Succeed())
+			data, err := os.ReadFile(filepath.Clean(path))
+			Expect(err).NotTo(HaveOccurred())
+			xml := string(data)
+			Expect(xml).To(ContainSubstring(`<testsuites name="exec ws/root" tests="5" failures="2" skipped="2"`))
+			Expect(xml).To(ContainSubstring(`<testsuite name="exec ws/nested" tests="1" failures="1" skipped="0"`))
+			Expect(xml).To(ContainSubstring(`<property name="attempts" value="2"></property>`))
+			Expect(xml).To(ContainSubstring(`<failure message="exit status 1">second attempt</failure>`))
+			Expect(xml).To(ContainSubstring(`<skipped message="condition is false"></skipped>`))
+		})
+
+		It("should write a json report", func() {
+			path := filepath.Join(GinkgoT().TempDir(), "results.json")
+			Expect(report.Write(report.Target{Format: report.FormatJSON, Path: path}, result)).To(Succeed())
+			data, err := os.ReadFile(filepath.Clean(path))
+			Expect(err).NotTo(HaveOccurred())
+			var written report.Result
+			Expect(json.Unmarshal(data, &written)).To(Succeed())
+			Expect(written.ID).To(Equal("exec ws/root"))
+			Expect(written.Steps).To(HaveLen(4))
+			Expect(written.Steps[1].Steps[0].Error).To(Equal("exit status 1"))
+		})
+	})
+})
@@ -3,8 +3,10 @@ package request
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v3"
@@ -14,6 +16,7 @@ import (
 	"github.com/flowexec/flow/internal/runner"
 	"github.com/flowexec/flow/internal/runner/engine"
 	"github.com/flowexec/flow/internal/services/expr"
+	"github.com/flowexec/flow/internal/services/redact"
 	"github.com/flowexec/flow/internal/services/rest"
 	"github.com/flowexec/flow/internal/utils/env"
 	"github.com/flowexec/flow/types/executable"
@@ -62,7 +65,23 @@ func (r *requestRunner) Exec(
 		Body:    body,
 		Timeout: requestSpec.Timeout,
 	}
+	method := strings.ToUpper(string(requestSpec.Method))
+	if method == "" {
+		method = http.MethodGet
+	}
+	op := &runner.Operation{
+		Parent:     e.Ref().String(),
+		ID:         e.Ref().String(),
+		Kind:       runner.OperationRequest,
+		Name:       fmt.Sprintf("%s %s", method, requestSpec.URL),
+		Attributes: map[string]any{"http.request.method": method, "url.full": redact.String(url)},
+	}
+	done := runner.StartOperation(op)
 	resp, err := rest.SendRequest(&restRequest, requestSpec.ValidStatusCodes)
+	if resp != nil {
+		op.Attributes["http.response.status_code"] = resp.Code
+	}
+	done(err)
 	if err != nil {
 		return errors.Wrap(err, "request failed")
 	}
@@ -77,7 +96,7 @@ func (r *requestRunner) Exec(
 
 	log := logger.Log()
 	if requestSpec.LogResponse {
-		log.Infox(fmt.Sprintf("Successfully sent request to %s", requestSpec.URL), "response", respStr)
+		log.Infox(fmt.Sprintf("Successfully sent request to %s", requestSpec.URL), "response", redact.String(respStr))
 	} else {
 		log.Infof("Successfully sent request to %s", requestSpec.URL)
 	}
@@ -26,11 +26,28 @@ func RegisterRunner(runner Runner) {
 	registeredRunners = append(registeredRunners, runner)
 }
 
+// Exec runs the executable with a compatible runner. The confirmation required by the executable is asked for before
+// it is run, so that it's also required when the executable is a step or hook of another executable.
 func Exec(
 	ctx *context.Context,
 	executable *executable.Executable,
 	eng engine.Engine,
 	inputEnv map[string]string,
+) error {
+	if err := Confirm(ctx, executable, inputEnv); err != nil {
+		return err
+	}
+	if executable.Hooks != nil {
+		return execWithHooks(ctx, executable, eng, inputEnv)
+	}
+	return execRunner(ctx, executable, eng, inputEnv)
+}
+
+func execRunner(
+	ctx *context.Context,
+	executable *executable.Executable,
+	eng engine.Engine,
+	inputEnv map[string]string,
 ) error {
 	var assignedRunner Runner
 	for _, runner := range registeredRunners {
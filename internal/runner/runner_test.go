@@ -1,19 +1,29 @@
 package runner_test
 
 import (
+	stdCtx "context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/flowexec/tuikit/io"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
 
+	cacheMocks "github.com/flowexec/flow/internal/cache/mocks"
 	"github.com/flowexec/flow/internal/context"
 	"github.com/flowexec/flow/internal/runner"
 	"github.com/flowexec/flow/internal/runner/engine"
 	engMocks "github.com/flowexec/flow/internal/runner/engine/mocks"
 	"github.com/flowexec/flow/internal/runner/mocks"
+	"github.com/flowexec/flow/types/config"
 	"github.com/flowexec/flow/types/executable"
+	"github.com/flowexec/flow/types/workspace"
 )
 
 func TestRunner(t *testing.T) {
@@ -90,3 +100,318 @@ var _ = Describe("Runner", func() {
 		})
 	})
 })
+
+var _ = Describe("Hooks", func() {
+	var (
+		ctrl       *gomock.Controller
+		mockRunner *mocks.MockRunner
+		mockEngine *engMocks.MockEngine
+		ctx        *context.Context
+		ran        []string
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockRunner = mocks.NewMockRunner(ctrl)
+		runner.RegisterRunner(mockRunner)
+		mockEngine = engMocks.NewMockEngine(gomock.NewController(GinkgoT()))
+		ctx = &context.Context{Config: &config.Config{}}
+		ran = nil
+		mockRunner.EXPECT().IsCompatible(gomock.Any()).Return(true).AnyTimes()
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+		runner.Reset()
+	})
+
+	newExecutable := func(hooks *executable.Hooks) *executable.Executable {
+		e := &executable.Executable{
+			Verb:  "exec",
+			Name:  "main",
+			Exec:  &executable.ExecExecutableType{Cmd: "main"},
+			Hooks: hooks,
+		}
+		e.SetContext("ws", "/ws", "", "/ws/flow.flow")
+		return e
+	}
+
+	expectRuns := func(failing string) {
+		mockRunner.EXPECT().Exec(ctx, gomock.Any(), mockEngine, gomock.Any()).DoAndReturn(
+			func(_ *context.Context, e *executable.Executable, _ engine.Engine, env map[string]string) error {
+				ran = append(ran, e.Exec.Cmd)
+				if e.Exec.Cmd == "on-failure" {
+					Expect(env[runner.ExecutableErrorEnvVar]).To(ContainSubstring("failed"))
+					Expect(env).To(HaveKeyWithValue(runner.ExecutableStatusEnvVar, runner.StatusFailure))
+				} else {
+					Expect(env).NotTo(HaveKey(runner.ExecutableErrorEnvVar))
+				}
+				if e.Exec.Cmd == failing {
+					return errors.New(failing + " failed")
+				}
+				return nil
+			}).AnyTimes()
+	}
+
+	hooks := &executable.Hooks{
+		Before:    executable.HookList{{Cmd: "before"}},
+		After:     executable.HookList{{Cmd: "after"}},
+		OnFailure: executable.HookList{{Cmd: "on-failure"}},
+		Finally:   executable.HookList{{Cmd: "finally"}},
+	}
+
+	It("should run the hooks around the executable", func() {
+		expectRuns("")
+		Expect(runner.Exec(ctx, newExecutable(hooks), mockEngine, map[string]string{})).To(Succeed())
+		Expect(ran).To(Equal([]string{"before", "main", "after", "finally"}))
+	})
+
+	It("should run the onFailure and finally hooks when the executable fails", func() {
+		expectRuns("main")
+		err := runner.Exec(ctx, newExecutable(hooks), mockEngine, map[string]string{})
+		Expect(err).To(MatchError("main failed"))
+		Expect(ran).To(Equal([]string{"before", "main", "on-failure", "finally"}))
+	})
+
+	It("should pass the ref and error of each executable to its own onFailure hooks", func() {
+		var mu sync.Mutex
+		errs := make(map[string]string)
+		mockRunner.EXPECT().Exec(ctx, gomock.Any(), mockEngine, gomock.Any()).DoAndReturn(
+			func(_ *context.Context, e *executable.Executable, _ engine.Engine, env map[string]string) error {
+				if strings.HasPrefix(e.Exec.Cmd, "on-failure") {
+					mu.Lock()
+					errs[e.Exec.Cmd] = env[runner.ExecutableRefEnvVar] + ": " + env[runner.ExecutableErrorEnvVar]
+					mu.Unlock()
+					return nil
+				}
+				time.Sleep(10 * time.Millisecond)
+				return errors.New(e.Exec.Cmd + " failed")
+			}).AnyTimes()
+
+		var wg sync.WaitGroup
+		for _, name := range []string{"one", "two"} {
+			e := newExecutable(&executable.Hooks{OnFailure: executable.HookList{{Cmd: "on-failure-" + name}}})
+			e.Name, e.Exec.Cmd = name, name
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				Expect(runner.Exec(ctx, e, mockEngine, map[string]string{})).NotTo(Succeed())
+			}()
+		}
+		wg.Wait()
+		Expect(errs).To(Equal(map[string]string{
+			"on-failure-one": "exec ws/one: one failed",
+			"on-failure-two": "exec ws/two: two failed",
+		}))
+		Expect(os.Getenv(runner.ExecutableErrorEnvVar)).To(BeEmpty())
+	})
+
+	It("should pass the parameters of the executable to its hooks without setting them in the process env", func() {
+		mockRunner.EXPECT().Exec(ctx, gomock.Any(), mockEngine, gomock.Any()).DoAndReturn(
+			func(_ *context.Context, e *executable.Executable, _ engine.Engine, env map[string]string) error {
+				ran = append(ran, e.Exec.Cmd)
+				Expect(env).To(HaveKeyWithValue("HOOK_PARAM", "value"))
+				Expect(os.Getenv("HOOK_PARAM")).To(BeEmpty())
+				return nil
+			}).AnyTimes()
+		e := newExecutable(&executable.Hooks{Before: executable.HookList{{Cmd: "before"}}})
+		e.Exec.Params = executable.ParameterList{{EnvKey: "HOOK_PARAM", Text: "value"}}
+		Expect(runner.Exec(ctx, e, mockEngine, map[string]string{})).To(Succeed())
+		Expect(ran).To(Equal([]string{"before", "main"}))
+	})
+
+	It("should not run the executable when a before hook fails", func() {
+		expectRuns("before")
+		err := runner.Exec(ctx, newExecutable(hooks), mockEngine, map[string]string{})
+		Expect(err).To(HaveOccurred())
+		Expect(ran).To(Equal([]string{"before", "on-failure", "finally"}))
+	})
+
+	It("should run the global hooks around the executable hooks", func() {
+		mockRunner.EXPECT().Exec(ctx, gomock.Any(), mockEngine, gomock.Any()).DoAndReturn(
+			func(_ *context.Context, e *executable.Executable, _ engine.Engine, env map[string]string) error {
+				ran = append(ran, e.Exec.Cmd)
+				switch e.Exec.Cmd {
+				case "main":
+					Expect(env).NotTo(HaveKey(runner.ExecutableRefEnvVar))
+				case "config-finally":
+					Expect(e.Exec.Dir).To(Equal(executable.Directory("//")))
+					Expect(env).To(HaveKeyWithValue(runner.ExecutableRefEnvVar, "exec ws/main"))
+					Expect(env).To(HaveKeyWithValue(runner.ExecutableStatusEnvVar, runner.StatusSuccess))
+					Expect(env).To(HaveKey(runner.ExecutableDurationEnvVar))
+				default:
+					Expect(env).To(HaveKeyWithValue(runner.ExecutableRefEnvVar, "exec ws/main"))
+					Expect(env).NotTo(HaveKey(runner.ExecutableStatusEnvVar))
+				}
+				return nil
+			}).AnyTimes()
+		cfgHooks := &executable.Hooks{Finally: executable.HookList{{Cmd: "config-finally"}}}
+		wsHooks := &executable.Hooks{Before: executable.HookList{{Cmd: "ws-before"}}}
+		e := newExecutable(&executable.Hooks{Before: executable.HookList{{Cmd: "before"}}})
+		Expect(runner.ExecWithGlobalHooks(ctx, e, mockEngine, map[string]string{}, cfgHooks, wsHooks)).To(Succeed())
+		Expect(ran).To(Equal([]string{"ws-before", "before", "main", "config-finally"}))
+		Expect(os.Getenv(runner.ExecutableRefEnvVar)).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Confirmations", func() {
+	var (
+		ctrl       *gomock.Controller
+		mockRunner *mocks.MockRunner
+		mockEngine *engMocks.MockEngine
+		ctx        *context.Context
+		cache      *cacheMocks.MockExecutableCache
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockRunner = mocks.NewMockRunner(ctrl)
+		runner.RegisterRunner(mockRunner)
+		mockEngine = engMocks.NewMockEngine(gomock.NewController(GinkgoT()))
+		cache = cacheMocks.NewMockExecutableCache(ctrl)
+		ctx = &context.Context{
+			Config:           &config.Config{},
+			CurrentWorkspace: &workspace.Workspace{},
+			ExecutableCache:  cache,
+			DisableInput:     true,
+		}
+		mockRunner.EXPECT().IsCompatible(gomock.Any()).Return(true).AnyTimes()
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+		runner.Reset()
+	})
+
+	newExecutable := func(name string, tags ...string) *executable.Executable {
+		e := &executable.Executable{
+			Verb: "exec",
+			Name: name,
+			Tags: tags,
+			Exec: &executable.ExecExecutableType{Cmd: name},
+		}
+		e.SetContext("ws", "/ws", "", "/ws/flow.flow")
+		return e
+	}
+
+	It("should require a confirmation for a protected executable", func() {
+		mockRunner.EXPECT().Exec(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+		err := runner.Exec(ctx, newExecutable("protected", executable.ProtectedTag), mockEngine, nil)
+		Expect(err).To(MatchError(ContainSubstring("confirmation required")))
+	})
+
+	It("should require a confirmation for a protected hook", func() {
+		protected := newExecutable("protected", executable.ProtectedTag)
+		cache.EXPECT().GetExecutableByRef(gomock.Any()).Return(protected, nil).AnyTimes()
+		e := newExecutable("main")
+		e.Hooks = &executable.Hooks{Before: executable.HookList{{Ref: protected.Ref()}}}
+		Expect(runner.ConfirmationRequired(ctx, e)).To(BeTrue())
+
+		mockRunner.EXPECT().Exec(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+		err := runner.Exec(ctx, e, mockEngine, nil)
+		Expect(err).To(MatchError(ContainSubstring("confirmation required")))
+	})
+
+	It("should run a protected executable when confirmations are skipped", func() {
+		ctx.SkipConfirmations = true
+		mockRunner.EXPECT().Exec(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		Expect(runner.Exec(ctx, newExecutable("protected", executable.ProtectedTag), mockEngine, nil)).To(Succeed())
+	})
+
+	It("should not confirm an executable again once it was confirmed", func() {
+		e := newExecutable("protected", executable.ProtectedTag)
+		ctx.MarkConfirmed(e.Ref())
+		mockRunner.EXPECT().Exec(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		Expect(runner.Exec(ctx, e, mockEngine, nil)).To(Succeed())
+		Expect(runner.ConfirmationRequired(ctx, e)).To(BeFalse())
+	})
+})
+
+type recordingObserver struct {
+	events []string
+}
+
+func (o *recordingObserver) StepAdded(s *runner.Step) {
+	o.events = append(o.events, fmt.Sprintf("added %d", s.Index))
+}
+
+func (o *recordingObserver) StepStarted(s *runner.Step, attempt int) {
+	o.events = append(o.events, fmt.Sprintf("started %d/%d", s.Index, attempt))
+}
+
+func (o *recordingObserver) StepFinished(s *runner.Step, attempt int, err error) {
+	o.events = append(o.events, fmt.Sprintf("finished %d/%d %v", s.Index, attempt, err))
+}
+
+func (o *recordingObserver) StepSkipped(s *runner.Step) {
+	o.events = append(o.events, fmt.Sprintf("skipped %d", s.Index))
+}
+
+func (o *recordingObserver) StepLogger(_ *runner.Step, _ io.Logger) io.Logger {
+	return nil
+}
+
+var _ = Describe("Step", func() {
+	var (
+		observer *recordingObserver
+		remove   func()
+	)
+
+	BeforeEach(func() {
+		observer = &recordingObserver{}
+		remove = runner.AddStepObserver(observer)
+	})
+
+	AfterEach(func() {
+		remove()
+	})
+
+	It("should report the attempts of the step to the observer", func() {
+		step := runner.NewStep(stdCtx.Background(), "exec ws/parent", 1, "exec ws/child", "exec ws/child", 1)
+		attempts := 0
+		track := step.Track(func() error {
+			attempts++
+			if attempts == 1 {
+				return errors.New("failed")
+			}
+			return nil
+		})
+		Expect(track()).To(MatchError("failed"))
+		Expect(track()).To(Succeed())
+		runner.SkipStep("exec ws/parent", 2, "exec ws/other", "condition is false")
+		Expect(observer.events).To(Equal([]string{
+			"added 1", "started 1/1", "finished 1/1 failed", "started 1/2", "finished 1/2 <nil>", "skipped 2",
+		}))
+	})
+
+	It("should cancel the step when the execution is cancelled", func() {
+		ctx, cancel := stdCtx.WithCancel(stdCtx.Background())
+		step := runner.NewStep(ctx, "exec ws/parent", 1, "exec ws/child", "exec ws/child", 0)
+		cancel()
+		Expect(step.Track(func() error { return nil })()).NotTo(Succeed())
+		Expect(observer.events).To(Equal([]string{
+			"added 1", "started 1/1", "finished 1/1 step cancelled - context canceled",
+		}))
+	})
+
+	It("should not run the step after it is cancelled", func() {
+		step := runner.NewStep(stdCtx.Background(), "exec ws/parent", 1, "exec ws/child", "exec ws/child", 0)
+		e := &executable.Executable{Exec: &executable.ExecExecutableType{Cmd: "echo"}}
+		step.Prepare(e, nil)
+		step.Cancel()
+		Expect(e.Exec.GetRunContext().Err()).To(HaveOccurred())
+		ran := false
+		err := step.Track(func() error {
+			ran = true
+			return nil
+		})()
+		Expect(err).To(MatchError(ContainSubstring("step cancelled")))
+		Expect(ran).To(BeFalse())
+	})
+
+	It("should label the step with its reference or the first line of its command", func() {
+		Expect(runner.StepLabel("exec ws/child", "")).To(Equal("exec ws/child"))
+		Expect(runner.StepLabel("", "echo one\necho two")).To(Equal("echo one"))
+	})
+})
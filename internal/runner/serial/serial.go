@@ -3,8 +3,6 @@ package serial
 import (
 	"fmt"
 	"maps"
-	"os"
-	"strings"
 
 	"github.com/pkg/errors"
 
@@ -102,6 +100,10 @@ func handleExec(
 			}
 			if !truthy {
 				logger.Log().Debugf("skipping execution %d/%d", i+1, len(serialSpec.Execs))
+				runner.SkipStep(
+					parent.Ref().String(), i+1, runner.StepLabel(refConfig.Ref, refConfig.Cmd),
+					fmt.Sprintf("condition %q is false", refConfig.If),
+				)
 				continue
 			}
 			logger.Log().Debugf("condition %s is true", refConfig.If)
@@ -123,6 +125,7 @@ func handleExec(
 
 		execPromptedEnv := make(map[string]string)
 		maps.Copy(promptedEnv, execPromptedEnv)
+		maps.Copy(execPromptedEnv, runner.HookEnv(promptedEnv))
 		if len(refConfig.Args) > 0 {
 			execEnv := exec.Env()
 			if execEnv == nil || execEnv.Args == nil {
@@ -156,9 +159,14 @@ func handleExec(
 			}
 		}
 
-		runExec := func() error {
+		step := runner.NewStep(
+			ctx.Ctx, parent.Ref().String(), i+1, exec.Ref().String(),
+			runner.StepLabel(refConfig.Ref, refConfig.Cmd), refConfig.Retries,
+		)
+		step.Prepare(exec, nil)
+		runExec := step.Track(func() error {
 			return runSerialExecFunc(ctx, i, refConfig, exec, eng, execPromptedEnv, serialSpec)
-		}
+		})
 
 		execs = append(execs, engine.Exec{ID: exec.Ref().String(), Function: runExec, MaxRetries: refConfig.Retries})
 	}
@@ -182,18 +190,10 @@ func runSerialExecFunc(
 	if err != nil {
 		return err
 	}
-	if step < len(serialSpec.Execs) && refConfig.ReviewRequired {
-		logger.Log().Println("Do you want to proceed with the next execution? (y/n)")
-		if !inputConfirmed(os.Stdin) {
-			return fmt.Errorf("stopping runner early (%d/%d)", step+1, len(serialSpec.Execs))
+	if step < len(serialSpec.Execs)-1 && refConfig.ReviewRequired {
+		if err := runner.ConfirmRun(ctx, "Do you want to proceed with the next execution?", ""); err != nil {
+			return fmt.Errorf("stopping runner early (%d/%d) - %w", step+1, len(serialSpec.Execs), err)
 		}
 	}
 	return nil
 }
-
-func inputConfirmed(in *os.File) bool {
-	var response string
-	_, _ = fmt.Fscanf(in, response)
-	response = strings.TrimSpace(strings.ToLower(response))
-	return response == "y" || response == "yes"
-}
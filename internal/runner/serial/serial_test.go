@@ -3,6 +3,7 @@ package serial_test
 import (
 	stdCtx "context"
 	"errors"
+	"os"
 	"testing"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -138,5 +139,61 @@ var _ = Describe("SerialRunner", func() {
 				Return(results).Times(1)
 			Expect(serialRnr.Exec(ctx.Ctx, rootExec, mockEngine, make(map[string]string))).To(Succeed())
 		})
+
+		It("should require a confirmation for a protected step", func() {
+			ctx.Ctx.DisableInput = true
+			protected := subExecs[0]
+			protected.Tags = append(protected.Tags, executable.ProtectedTag)
+			for _, e := range subExecs {
+				ctx.ExecutableCache.EXPECT().GetExecutableByRef(e.Ref()).Return(e, nil).AnyTimes()
+			}
+			ctx.RunnerMock.EXPECT().IsCompatible(gomock.Any()).Return(true).AnyTimes()
+			ctx.RunnerMock.EXPECT().Exec(gomock.Any(), protected, gomock.Any(), gomock.Any()).Times(0)
+			err := serialRnr.Exec(ctx.Ctx, rootExec, engine.NewExecEngine(), make(map[string]string))
+			Expect(err).To(MatchError(ContainSubstring("confirmation required")))
+		})
+
+		Context("when a step requires a review", func() {
+			BeforeEach(func() {
+				rootExec.Serial.Execs[0].ReviewRequired = true
+				for _, e := range subExecs {
+					ctx.ExecutableCache.EXPECT().GetExecutableByRef(e.Ref()).Return(e, nil).AnyTimes()
+				}
+				ctx.RunnerMock.EXPECT().IsCompatible(gomock.Any()).Return(true).AnyTimes()
+				ctx.Logger.EXPECT().Println(gomock.Any()).AnyTimes()
+			})
+
+			setStdIn := func(input string) {
+				stdIn, err := os.CreateTemp(GinkgoT().TempDir(), "stdin")
+				Expect(err).NotTo(HaveOccurred())
+				_, err = stdIn.WriteString(input)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = stdIn.Seek(0, 0)
+				Expect(err).NotTo(HaveOccurred())
+				ctx.Ctx.SetIO(stdIn, ctx.Ctx.StdOut())
+			}
+
+			It("should continue when the review is confirmed", func() {
+				setStdIn("y\n")
+				ctx.RunnerMock.EXPECT().Exec(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).Times(3)
+				Expect(serialRnr.Exec(ctx.Ctx, rootExec, engine.NewExecEngine(), make(map[string]string))).To(Succeed())
+			})
+
+			It("should stop when the review is declined", func() {
+				setStdIn("n\n")
+				ctx.RunnerMock.EXPECT().Exec(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).Times(1)
+				Expect(serialRnr.Exec(ctx.Ctx, rootExec, engine.NewExecEngine(), make(map[string]string))).
+					ToNot(Succeed())
+			})
+
+			It("should not prompt when confirmations are skipped", func() {
+				ctx.Ctx.SkipConfirmations = true
+				ctx.RunnerMock.EXPECT().Exec(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).Times(3)
+				Expect(serialRnr.Exec(ctx.Ctx, rootExec, engine.NewExecEngine(), make(map[string]string))).To(Succeed())
+			})
+		})
 	})
 })
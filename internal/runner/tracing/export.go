@@ -0,0 +1,96 @@
+package tracing
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/flowexec/flow/internal/filesystem"
+	"github.com/flowexec/flow/types/config"
+)
+
+const (
+	TracesEndpointEnvVar = "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"
+	EndpointEnvVar       = "OTEL_EXPORTER_OTLP_ENDPOINT"
+
+	tracesPath    = "/v1/traces"
+	exportTimeout = 10 * time.Second
+)
+
+// Endpoint returns the URL of the OTLP/HTTP traces endpoint that traces are exported to. It returns an empty string
+// when no endpoint is set in the config or the environment.
+func Endpoint(cfg *config.Tracing) string {
+	switch {
+	case cfg != nil && cfg.Endpoint != nil && *cfg.Endpoint != "":
+		return tracesURL(*cfg.Endpoint)
+	case os.Getenv(TracesEndpointEnvVar) != "":
+		return os.Getenv(TracesEndpointEnvVar)
+	case os.Getenv(EndpointEnvVar) != "":
+		return tracesURL(os.Getenv(EndpointEnvVar))
+	default:
+		return ""
+	}
+}
+
+func tracesURL(endpoint string) string {
+	if strings.HasSuffix(endpoint, tracesPath) {
+		return endpoint
+	}
+	return strings.TrimSuffix(endpoint, "/") + tracesPath
+}
+
+// Export sends the trace to the OTLP endpoint or, when none is set, writes it to a file in the traces directory.
+// The URL or path that the trace was exported to is returned.
+func Export(cfg *config.Tracing, trace *Trace) (string, error) {
+	data, err := trace.JSON()
+	if err != nil {
+		return "", fmt.Errorf("unable to encode trace - %w", err)
+	}
+	if endpoint := Endpoint(cfg); endpoint != "" {
+		var headers map[string]string
+		if cfg != nil {
+			headers = cfg.Headers
+		}
+		return endpoint, send(endpoint, headers, data)
+	}
+
+	dir := filesystem.TracesDir()
+	if cfg != nil && cfg.Directory != nil && *cfg.Directory != "" {
+		dir = *cfg.Directory
+	}
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return "", fmt.Errorf("unable to create traces directory - %w", err)
+	}
+	path := filepath.Join(dir, fmt.Sprintf("%s-%s.json", trace.Root.Start.Format("20060102T150405"), trace.ID))
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return "", fmt.Errorf("unable to write trace - %w", err)
+	}
+	return path, nil
+}
+
+func send(endpoint string, headers map[string]string, data []byte) error {
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("invalid traces endpoint - %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	client := http.Client{Timeout: exportTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to send trace - %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unable to send trace - collector responded with %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,236 @@
+package tracing
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/flowexec/tuikit/io"
+
+	"github.com/flowexec/flow/internal/runner"
+	"github.com/flowexec/flow/internal/services/redact"
+	"github.com/flowexec/flow/internal/services/run"
+	"github.com/flowexec/flow/types/executable"
+)
+
+// Span is a timed operation of an execution, e.g. the executable, one of its steps, a retry attempt, a request or
+// a hook.
+type Span struct {
+	ID         string
+	ParentID   string
+	Name       string
+	Start      time.Time
+	End        time.Time
+	Attributes map[string]any
+	// Error is the error message of a failed span.
+	Error string
+	// Failed is set for spans that completed with an error.
+	Failed bool
+
+	// ref and owner are the references of the executable that the span runs and the executable that it is run for.
+	// Steps and operations are added to the latest running span that runs the executable they are run for.
+	ref     string
+	owner   string
+	running bool
+}
+
+// Trace is the trace of an execution.
+type Trace struct {
+	ID    string
+	Root  *Span
+	Spans []*Span
+	// Resource describes the flow binary that produced the trace.
+	Resource map[string]any
+}
+
+// Tracer builds the trace of an execution from the progress of its steps, requests and hooks. It is a
+// runner.StepObserver and runner.OperationObserver.
+type Tracer struct {
+	mu    sync.Mutex
+	trace *Trace
+	steps map[*runner.Step]*stepSpans
+	ops   map[*runner.Operation]*Span
+}
+
+type stepSpans struct {
+	step, attempt *Span
+}
+
+func NewTracer(ref executable.Ref, serviceVersion string) *Tracer {
+	root := &Span{
+		ID:         newID(8),
+		Name:       ref.String(),
+		Start:      time.Now(),
+		Attributes: refAttributes(ref),
+		ref:        ref.String(),
+		running:    true,
+	}
+	resource := map[string]any{"service.name": "flow"}
+	if serviceVersion != "" {
+		resource["service.version"] = serviceVersion
+	}
+	return &Tracer{
+		trace: &Trace{ID: newID(16), Root: root, Spans: []*Span{root}, Resource: resource},
+		steps: make(map[*runner.Step]*stepSpans),
+		ops:   make(map[*runner.Operation]*Span),
+	}
+}
+
+func (t *Tracer) StepAdded(_ *runner.Step) {}
+
+func (t *Tracer) StepSkipped(s *runner.Step) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	sp := t.start(s.Label, s.Parent, s.ID, stepAttributes(s))
+	sp.Attributes["flow.step.skipped"] = true
+	sp.Attributes["flow.step.skip_reason"] = s.SkipReason
+	sp.End, sp.running = sp.Start, false
+}
+
+func (t *Tracer) StepStarted(s *runner.Step, attempt int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	spans, found := t.steps[s]
+	if !found {
+		spans = &stepSpans{step: t.start(s.Label, s.Parent, s.ID, stepAttributes(s))}
+		t.steps[s] = spans
+	}
+	spans.step.Attributes["flow.step.attempts"] = attempt
+	if s.Retries == 0 {
+		return
+	}
+	// Each attempt of a step that is retried is a child span of the step. The spans of a nested executable are
+	// added to the attempt that runs it.
+	spans.attempt = t.start(fmt.Sprintf("attempt %d", attempt), s.Parent, s.ID, map[string]any{
+		"flow.step.attempt": attempt,
+		"flow.step.retry":   attempt > 1,
+	})
+	spans.attempt.ParentID = spans.step.ID
+}
+
+func (t *Tracer) StepFinished(s *runner.Step, attempt int, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	spans, found := t.steps[s]
+	if !found {
+		return
+	}
+	if spans.attempt != nil {
+		finish(spans.attempt, err)
+	}
+	if err == nil || attempt > s.Retries {
+		finish(spans.step, err)
+	}
+}
+
+// StepLogger keeps the logger of the step. The output of the steps is not traced.
+func (t *Tracer) StepLogger(_ *runner.Step, _ io.Logger) io.Logger {
+	return nil
+}
+
+func (t *Tracer) OperationStarted(op *runner.Operation) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	attrs := refAttributes(executable.Ref(op.ID))
+	attrs["flow.operation"] = op.Kind
+	t.ops[op] = t.start(op.Name, op.Parent, op.ID, attrs)
+}
+
+func (t *Tracer) OperationFinished(op *runner.Operation, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	sp, found := t.ops[op]
+	if !found {
+		return
+	}
+	for k, v := range op.Attributes {
+		sp.Attributes[k] = v
+	}
+	finish(sp, err)
+	delete(t.ops, op)
+}
+
+// start adds a running span to the latest running span that runs the owner executable. Spans that run the
+// executable they are run for, e.g. command steps, are not considered. It must be called with the lock held.
+func (t *Tracer) start(name, owner, ref string, attrs map[string]any) *Span {
+	parent := t.trace.Root
+	for _, sp := range t.trace.Spans {
+		if !sp.running || sp == t.trace.Root || sp.ref != owner || sp.ref == sp.owner {
+			continue
+		}
+		if !sp.Start.Before(parent.Start) {
+			parent = sp
+		}
+	}
+	sp := &Span{
+		ID:         newID(8),
+		ParentID:   parent.ID,
+		Name:       name,
+		Start:      time.Now(),
+		Attributes: attrs,
+		ref:        ref,
+		owner:      owner,
+		running:    true,
+	}
+	t.trace.Spans = append(t.trace.Spans, sp)
+	return sp
+}
+
+// Finish ends the trace with the result of the execution. Spans that did not finish are ended with it.
+func (t *Tracer) Finish(err error) *Trace {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, sp := range t.trace.Spans {
+		if sp.running && sp != t.trace.Root {
+			sp.End, sp.running = time.Now(), false
+		}
+	}
+	finish(t.trace.Root, err)
+	return t.trace
+}
+
+func finish(sp *Span, err error) {
+	sp.End = time.Now()
+	sp.running = false
+	sp.Attributes["flow.exit_code"] = run.ExitCode(err)
+	if err != nil {
+		sp.Failed = true
+		sp.Error = redact.String(err.Error())
+		sp.Attributes["flow.status"] = runner.StatusFailure
+	} else {
+		sp.Failed = false
+		sp.Error = ""
+		sp.Attributes["flow.status"] = runner.StatusSuccess
+	}
+}
+
+func refAttributes(ref executable.Ref) map[string]any {
+	if ref == "" {
+		return make(map[string]any)
+	}
+	attrs := map[string]any{"flow.executable.ref": ref.String(), "flow.verb": ref.Verb().String()}
+	if ref.ID() != "" {
+		attrs["flow.workspace"] = ref.Workspace()
+	}
+	return attrs
+}
+
+func stepAttributes(s *runner.Step) map[string]any {
+	attrs := refAttributes(executable.Ref(s.ID))
+	attrs["flow.step.index"] = s.Index
+	attrs["flow.step.label"] = s.Label
+	attrs["flow.step.parent"] = s.Parent
+	if s.Retries > 0 {
+		attrs["flow.step.retries"] = s.Retries
+	}
+	return attrs
+}
+
+// newID returns a random hex encoded ID of n bytes. Trace IDs are 16 bytes and span IDs are 8 bytes.
+func newID(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,228 @@
+package tracing_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/flowexec/flow/internal/runner"
+	"github.com/flowexec/flow/internal/runner/tracing"
+	"github.com/flowexec/flow/types/config"
+)
+
+func TestTracing(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Tracing Suite")
+}
+
+// exported is the subset of an OTLP/JSON export request that is checked by the tests.
+type exported struct {
+	ResourceSpans []struct {
+		Resource struct {
+			Attributes []attribute `json:"attributes"`
+		} `json:"resource"`
+		ScopeSpans []struct {
+			Spans []struct {
+				TraceID      string      `json:"traceId"`
+				SpanID       string      `json:"spanId"`
+				ParentSpanID string      `json:"parentSpanId"`
+				Name         string      `json:"name"`
+				Attributes   []attribute `json:"attributes"`
+				Status       struct {
+					Code    int    `json:"code"`
+					Message string `json:"message"`
+				} `json:"status"`
+			} `json:"spans"`
+		} `json:"scopeSpans"`
+	} `json:"resourceSpans"`
+}
+
+type attribute struct {
+	Key   string         `json:"key"`
+	Value map[string]any `json:"value"`
+}
+
+func spanNamed(trace *tracing.Trace, name string) *tracing.Span {
+	for _, sp := range trace.Spans {
+		if sp.Name == name {
+			return sp
+		}
+	}
+	return nil
+}
+
+var _ = Describe("Tracer", func() {
+	var trace *tracing.Trace
+
+	BeforeEach(func() {
+		tracer := tracing.NewTracer("exec ws/root", "v1.0.0")
+		defer runner.AddStepObserver(tracer)()
+
+		runner.StartOperation(&runner.Operation{
+			Parent: "exec ws/root", ID: "exec ws/root", Kind: runner.OperationHook, Name: "before hook: echo before",
+		})(nil)
+		first := runner.NewStep(context.Background(), "exec ws/root", 1, "exec ws/root", "echo one", 0)
+		Expect(first.Track(func() error { return nil })()).To(Succeed())
+
+		nested := runner.NewStep(context.Background(), "exec ws/root", 2, "exec ws/nested", "exec nested", 1)
+		attempt := 0
+		run := nested.Track(func() error {
+			attempt++
+			req := &runner.Operation{
+				Parent: "exec ws/nested",
+				ID:     "exec ws/nested",
+				Kind:   runner.OperationRequest,
+				Name:   "GET https://example.com",
+			}
+			done := runner.StartOperation(req)
+			req.Attributes["http.response.status_code"] = 200
+			done(nil)
+			child := runner.NewStep(context.Background(), "exec ws/nested", 1, "exec ws/nested", "exit 1", 0)
+			if attempt == 1 {
+				return child.Track(func() error { return errors.New("exit status 1") })()
+			}
+			return child.Track(func() error { return nil })()
+		})
+		Expect(run()).NotTo(Succeed())
+		Expect(run()).To(Succeed())
+		runner.SkipStep("exec ws/root", 3, "echo skipped", "condition is false")
+
+		trace = tracer.Finish(errors.New("exit status 2"))
+	})
+
+	It("should add the steps, operations and retry attempts to the spans that run them", func() {
+		root := trace.Root
+		Expect(root.Name).To(Equal("exec ws/root"))
+		Expect(root.ParentID).To(BeEmpty())
+		Expect(root.Attributes).To(HaveKeyWithValue("flow.executable.ref", "exec ws/root"))
+		Expect(root.Attributes).To(HaveKeyWithValue("flow.workspace", "ws"))
+		Expect(root.Attributes).To(HaveKeyWithValue("flow.verb", "exec"))
+
+		Expect(spanNamed(trace, "before hook: echo before").ParentID).To(Equal(root.ID))
+		Expect(spanNamed(trace, "echo one").ParentID).To(Equal(root.ID))
+		Expect(spanNamed(trace, "echo skipped").Attributes).To(HaveKeyWithValue("flow.step.skipped", true))
+
+		nested := spanNamed(trace, "exec nested")
+		Expect(nested.ParentID).To(Equal(root.ID))
+		Expect(nested.Attributes).To(HaveKeyWithValue("flow.step.attempts", 2))
+		Expect(nested.Failed).To(BeFalse())
+		first, second := spanNamed(trace, "attempt 1"), spanNamed(trace, "attempt 2")
+		Expect(first.ParentID).To(Equal(nested.ID))
+		Expect(first.Failed).To(BeTrue())
+		Expect(second.ParentID).To(Equal(nested.ID))
+		Expect(second.Attributes).To(HaveKeyWithValue("flow.step.retry", true))
+
+		var children, requests []*tracing.Span
+		for _, sp := range trace.Spans {
+			switch sp.Name {
+			case "exit 1":
+				children = append(children, sp)
+			case "GET https://example.com":
+				requests = append(requests, sp)
+			}
+		}
+		Expect(children).To(HaveLen(2))
+		Expect(children[0].ParentID).To(Equal(first.ID))
+		Expect(children[1].ParentID).To(Equal(second.ID))
+		Expect(requests).To(HaveLen(2))
+		Expect(requests[1].ParentID).To(Equal(second.ID))
+		Expect(requests[1].Attributes).To(HaveKeyWithValue("http.response.status_code", 200))
+	})
+
+	It("should set the exit status of the spans", func() {
+		Expect(trace.Root.Failed).To(BeTrue())
+		Expect(trace.Root.Error).To(Equal("exit status 2"))
+		Expect(trace.Root.Attributes).To(HaveKeyWithValue("flow.status", runner.StatusFailure))
+		Expect(spanNamed(trace, "echo one").Attributes).To(HaveKeyWithValue("flow.status", runner.StatusSuccess))
+		for _, sp := range trace.Spans {
+			Expect(sp.End).NotTo(BeZero())
+		}
+	})
+
+	Describe("Export", func() {
+		It("should send the trace to the collector endpoint", func() {
+			var body []byte
+			var header http.Header
+			collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer GinkgoRecover()
+				Expect(r.Method).To(Equal(http.MethodPost))
+				Expect(r.URL.Path).To(Equal("/v1/traces"))
+				header = r.Header
+				body, _ = io.ReadAll(r.Body)
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer collector.Close()
+
+			endpoint := collector.URL
+			cfg := &config.Tracing{
+				Enabled: true, Endpoint: &endpoint, Headers: map[string]string{"Authorization": "token"},
+			}
+			location, err := tracing.Export(cfg, trace)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(location).To(Equal(collector.URL + "/v1/traces"))
+			Expect(header.Get("Content-Type")).To(Equal("application/json"))
+			Expect(header.Get("Authorization")).To(Equal("token"))
+
+			var req exported
+			Expect(json.Unmarshal(body, &req)).To(Succeed())
+			Expect(req.ResourceSpans).To(HaveLen(1))
+			Expect(req.ResourceSpans[0].Resource.Attributes).To(ContainElement(
+				attribute{Key: "service.name", Value: map[string]any{"stringValue": "flow"}},
+			))
+			spans := req.ResourceSpans[0].ScopeSpans[0].Spans
+			Expect(spans).To(HaveLen(len(trace.Spans)))
+			Expect(spans[0].TraceID).To(HaveLen(32))
+			Expect(spans[0].SpanID).To(Equal(trace.Root.ID))
+			Expect(spans[0].Status.Code).To(Equal(2))
+			Expect(spans[0].Attributes).To(ContainElement(
+				attribute{Key: "flow.exit_code", Value: map[string]any{"intValue": "1"}},
+			))
+		})
+
+		It("should return an error when the collector rejects the trace", func() {
+			collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusBadRequest)
+			}))
+			defer collector.Close()
+
+			endpoint := collector.URL + "/v1/traces"
+			_, err := tracing.Export(&config.Tracing{Enabled: true, Endpoint: &endpoint}, trace)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should write the trace to the directory when no endpoint is set", func() {
+			GinkgoT().Setenv(tracing.TracesEndpointEnvVar, "")
+			GinkgoT().Setenv(tracing.EndpointEnvVar, "")
+			dir := GinkgoT().TempDir()
+			location, err := tracing.Export(&config.Tracing{Enabled: true, Directory: &dir}, trace)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(filepath.Dir(location)).To(Equal(dir))
+
+			data, err := os.ReadFile(location)
+			Expect(err).NotTo(HaveOccurred())
+			var req exported
+			Expect(json.Unmarshal(data, &req)).To(Succeed())
+			Expect(req.ResourceSpans[0].ScopeSpans[0].Spans).To(HaveLen(len(trace.Spans)))
+		})
+	})
+
+	Describe("Endpoint", func() {
+		It("should fall back to the OTLP environment variables", func() {
+			GinkgoT().Setenv(tracing.TracesEndpointEnvVar, "")
+			GinkgoT().Setenv(tracing.EndpointEnvVar, "http://localhost:4318/")
+			Expect(tracing.Endpoint(&config.Tracing{Enabled: true})).To(Equal("http://localhost:4318/v1/traces"))
+
+			GinkgoT().Setenv(tracing.TracesEndpointEnvVar, "http://collector:4318/custom")
+			Expect(tracing.Endpoint(&config.Tracing{Enabled: true})).To(Equal("http://collector:4318/custom"))
+		})
+	})
+})
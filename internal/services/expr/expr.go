@@ -75,6 +75,7 @@ type CtxData struct {
 	FlowFileName  string `expr:"flowFileName"`
 	FlowFilePath  string `expr:"flowFilePath"`
 	FlowFileDir   string `expr:"flowFileDir"`
+	Profile       string `expr:"profile"`
 }
 
 type ExpressionData struct {
@@ -101,6 +102,7 @@ func ExpressionEnv(
 			FlowFileName:  fn,
 			FlowFilePath:  executable.FlowFilePath(),
 			FlowFileDir:   filepath.Dir(executable.FlowFilePath()),
+			Profile:       ctx.CurrentProfile,
 		},
 		Store: dataMap,
 		Env:   envMap,
@@ -19,6 +19,7 @@ type Template struct {
 	text      string
 	data      any
 	tmpl      *template.Template
+	partials  map[string]string
 	exprCache map[string]*vm.Program
 }
 
@@ -31,6 +32,15 @@ func NewTemplate(name string, data any) *Template {
 	return t
 }
 
+// AddPartial registers a named partial that can be included in the template with {{ template "name" }}.
+// Partials must be added before the template is parsed.
+func (t *Template) AddPartial(name, text string) {
+	if t.partials == nil {
+		t.partials = make(map[string]string)
+	}
+	t.partials[name] = text
+}
+
 func (t *Template) Parse(text string) error {
 	t.text = text
 	processed := t.preProcessExpressions(text)
@@ -40,6 +50,11 @@ func (t *Template) Parse(text string) error {
 	if err != nil {
 		return fmt.Errorf("parsing template: %w", err)
 	}
+	for name, partial := range t.partials {
+		if _, err := parsed.New(name).Parse(t.preProcessExpressions(partial)); err != nil {
+			return fmt.Errorf("parsing partial %s: %w", name, err)
+		}
+	}
 
 	t.tmpl = parsed
 	return nil
@@ -129,6 +144,11 @@ func (t *Template) preProcessExpressions(text string) string {
 			}
 		case action == "else":
 			result.WriteString("else")
+		case strings.HasPrefix(action, "template "):
+			// partials are always executed with the root data
+			result.WriteString("template ")
+			result.WriteString(strings.TrimSpace(strings.TrimPrefix(action, "template ")))
+			result.WriteString(" $")
 		case strings.HasPrefix(action, "range "):
 			value := strings.TrimPrefix(action, "range ")
 			result.WriteString("range expr `")
@@ -147,6 +147,26 @@ Namespace: {{ .namespace }}
 		})
 	})
 
+	Describe("partials", func() {
+		It("includes partials with the root data", func() {
+			tmpl.AddPartial("header", "workspace: {{ ctx.workspace }}")
+			err := tmpl.Parse(`{{ range workspaces }}{{ template "header" }};{{ end }}`)
+			Expect(err).NotTo(HaveOccurred())
+
+			result, err := tmpl.ExecuteToString()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal("workspace: test_workspace;workspace: test_workspace;"))
+		})
+
+		It("errors when the partial is not defined", func() {
+			err := tmpl.Parse(`{{ template "missing" }}`)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = tmpl.ExecuteToString()
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
 	Describe("Template with trim markers", func() {
 		It("handles trim markers in range", func() {
 			template := `start
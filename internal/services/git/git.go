@@ -1,20 +1,65 @@
 package git
 
-// var log = io.Log().With().Str("service", "git").Logger()
-//
-// func Pull(repoDir string) error {
-// 	if info, err := os.Stat(repoDir); err != nil && os.IsNotExist(err) {
-// 		return fmt.Errorf("git repo %s does not exist", repoDir)
-// 	} else if err != nil {
-// 		return fmt.Errorf("unable to check for git repo %s - %w", repoDir, err)
-// 	} else if !info.IsDir() {
-// 		return fmt.Errorf("git repo %s is not a directory", repoDir)
-// 	}
-//
-// 	if err := run.RunCmd("git pull", repoDir, nil); err != nil {
-// 		return fmt.Errorf("unable to pull git repo %s - %w", repoDir, err)
-// 	}
-//
-// 	log.Info().Msgf("successfully pulled git repo %s", repoDir)
-// 	return nil
-// }
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Clone clones the repository at url into dir. If ref is set, the branch, tag or commit is checked out.
+// Branches and tags are cloned shallowly; commits require the full history to be fetched.
+func Clone(url, ref, dir string) error {
+	if strings.HasPrefix(ref, "-") {
+		return fmt.Errorf("invalid git ref %s", ref)
+	}
+	if _, err := exec.LookPath("git"); err != nil {
+		return fmt.Errorf("git must be installed to clone %s", url)
+	}
+
+	args := []string{"clone", "--quiet", "--depth", "1"}
+	if ref != "" {
+		args = append(args, "--branch", ref)
+	}
+	// the url and dir are passed after -- so that they aren't parsed as options
+	if err := run("", append(args, "--", url, dir)...); err == nil {
+		return nil
+	} else if ref == "" {
+		return err
+	}
+
+	// the ref may be a commit, which can't be cloned directly
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("unable to clean up clone directory - %w", err)
+	}
+	if err := run("", "clone", "--quiet", "--", url, dir); err != nil {
+		return err
+	}
+	if err := run(dir, "checkout", "--quiet", ref, "--"); err != nil {
+		return fmt.Errorf("unable to checkout %s - %w", ref, err)
+	}
+	return nil
+}
+
+// HeadCommit returns the commit SHA that is checked out in the repository at dir.
+func HeadCommit(dir string) (string, error) {
+	cmd := exec.Command("git", "rev-parse", "HEAD")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("unable to resolve git commit - %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+func run(dir string, args ...string) error {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("git %s failed - %s", args[0], strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
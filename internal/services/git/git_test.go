@@ -0,0 +1,81 @@
+package git_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/flowexec/flow/internal/services/git"
+)
+
+func TestGit(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Git Suite")
+}
+
+var _ = Describe("Clone", func() {
+	var (
+		repo   string
+		commit string
+	)
+
+	gitCmd := func(dir string, args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+		)
+		out, err := cmd.CombinedOutput()
+		Expect(err).NotTo(HaveOccurred(), string(out))
+		return string(out)
+	}
+
+	BeforeEach(func() {
+		if _, err := exec.LookPath("git"); err != nil {
+			Skip("git is not installed")
+		}
+		repo = GinkgoT().TempDir()
+		gitCmd(repo, "init", "--quiet", "--initial-branch", "main")
+		Expect(os.WriteFile(filepath.Join(repo, "file.txt"), []byte("first"), 0600)).To(Succeed())
+		gitCmd(repo, "add", "file.txt")
+		gitCmd(repo, "commit", "--quiet", "-m", "first")
+		commit = gitCmd(repo, "rev-parse", "HEAD")[:40]
+		Expect(os.WriteFile(filepath.Join(repo, "file.txt"), []byte("second"), 0600)).To(Succeed())
+		gitCmd(repo, "commit", "--quiet", "-am", "second")
+	})
+
+	It("clones the default branch", func() {
+		dir := filepath.Join(GinkgoT().TempDir(), "clone")
+		Expect(git.Clone("file://"+repo, "", dir)).To(Succeed())
+		Expect(filepath.Join(dir, "file.txt")).To(BeAnExistingFile())
+		data, err := os.ReadFile(filepath.Join(dir, "file.txt"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal("second"))
+	})
+
+	It("checks out commits", func() {
+		dir := filepath.Join(GinkgoT().TempDir(), "clone")
+		Expect(git.Clone("file://"+repo, commit, dir)).To(Succeed())
+		head, err := git.HeadCommit(dir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(head).To(Equal(commit))
+	})
+
+	It("rejects refs that look like options", func() {
+		dir := filepath.Join(GinkgoT().TempDir(), "clone")
+		Expect(git.Clone("file://"+repo, "--upload-pack=touch", dir)).To(MatchError(ContainSubstring("invalid git ref")))
+		Expect(dir).NotTo(BeADirectory())
+	})
+
+	It("doesn't parse urls as options", func() {
+		marker := filepath.Join(GinkgoT().TempDir(), "marker")
+		// without the separator, git would clone the repository at the dir with the url as its upload-pack option
+		Expect(git.Clone("--upload-pack=touch "+marker+";", "", repo)).NotTo(Succeed())
+		Expect(marker).NotTo(BeAnExistingFile())
+	})
+})
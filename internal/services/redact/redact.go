@@ -0,0 +1,112 @@
+package redact
+
+import (
+	"cmp"
+	"encoding/base64"
+	"encoding/hex"
+	stdio "io"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+)
+
+const (
+	// Mask replaces the redacted values.
+	Mask = "****"
+	// MinLength is the length that a value must have to be registered. Shorter values would mask too much of the
+	// output to be useful.
+	MinLength = 4
+)
+
+var (
+	mu       sync.RWMutex
+	values   = make(map[string]struct{})
+	replacer *strings.Replacer
+)
+
+// Register adds the secret value and its common encodings to the values that are masked for the rest of the
+// process. Values shorter than MinLength are ignored.
+func Register(value string) {
+	value = strings.TrimSpace(value)
+	if len(value) < MinLength {
+		return
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for _, v := range encodings(value) {
+		values[v] = struct{}{}
+	}
+	replacer = newReplacer()
+}
+
+// Reset removes all registered values.
+func Reset() {
+	mu.Lock()
+	defer mu.Unlock()
+	values = make(map[string]struct{})
+	replacer = nil
+}
+
+// String returns s with every registered value replaced by Mask.
+func String(s string) string {
+	mu.RLock()
+	defer mu.RUnlock()
+	if replacer == nil {
+		return s
+	}
+	return replacer.Replace(s)
+}
+
+// Writer returns a writer that masks the registered values before writing to w. Values are only masked when they
+// are written in a single call.
+func Writer(w stdio.Writer) stdio.Writer {
+	return &writer{w: w}
+}
+
+type writer struct {
+	w stdio.Writer
+}
+
+func (rw *writer) Write(p []byte) (int, error) {
+	if _, err := stdio.WriteString(rw.w, String(string(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func encodings(value string) []string {
+	encoded := []string{
+		value,
+		base64.StdEncoding.EncodeToString([]byte(value)),
+		base64.RawStdEncoding.EncodeToString([]byte(value)),
+		base64.URLEncoding.EncodeToString([]byte(value)),
+		base64.RawURLEncoding.EncodeToString([]byte(value)),
+		hex.EncodeToString([]byte(value)),
+		url.QueryEscape(value),
+		url.PathEscape(value),
+	}
+	slices.Sort(encoded)
+	return slices.Compact(encoded)
+}
+
+// newReplacer must be called with the lock held. Longer values are replaced first so that a value that contains
+// another registered value is masked as a whole.
+func newReplacer() *strings.Replacer {
+	sorted := make([]string, 0, len(values))
+	for v := range values {
+		sorted = append(sorted, v)
+	}
+	slices.SortFunc(sorted, func(a, b string) int {
+		if n := cmp.Compare(len(b), len(a)); n != 0 {
+			return n
+		}
+		return strings.Compare(a, b)
+	})
+	oldnew := make([]string, 0, len(sorted)*2)
+	for _, v := range sorted {
+		oldnew = append(oldnew, v, Mask)
+	}
+	return strings.NewReplacer(oldnew...)
+}
@@ -0,0 +1,69 @@
+package redact_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/flowexec/flow/internal/services/redact"
+)
+
+func TestRedact(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Redact Suite")
+}
+
+var _ = Describe("Redact", func() {
+	BeforeEach(func() {
+		redact.Reset()
+	})
+
+	AfterEach(func() {
+		redact.Reset()
+	})
+
+	Describe("String", func() {
+		It("should not change the string when no values are registered", func() {
+			Expect(redact.String("token=s3cr3t")).To(Equal("token=s3cr3t"))
+		})
+
+		It("should mask the registered values", func() {
+			redact.Register("s3cr3t")
+			redact.Register("hunter2")
+			Expect(redact.String("token=s3cr3t pass=hunter2")).To(Equal("token=**** pass=****"))
+		})
+
+		It("should mask the encoded values", func() {
+			redact.Register("p@ss word")
+			encoded := base64.StdEncoding.EncodeToString([]byte("p@ss word"))
+			Expect(redact.String("b64=" + encoded)).To(Equal("b64=****"))
+			Expect(redact.String("q=p%40ss+word")).To(Equal("q=****"))
+			Expect(redact.String("hex=7040737320776f7264")).To(Equal("hex=****"))
+		})
+
+		It("should mask values that contain other values as a whole", func() {
+			redact.Register("secret")
+			redact.Register("secret-value")
+			Expect(redact.String("v=secret-value")).To(Equal("v=****"))
+		})
+
+		It("should ignore short values", func() {
+			redact.Register("abc")
+			Expect(redact.String("abcdef")).To(Equal("abcdef"))
+		})
+	})
+
+	Describe("Writer", func() {
+		It("should mask the registered values before writing", func() {
+			redact.Register("s3cr3t")
+			var buf bytes.Buffer
+			n, err := redact.Writer(&buf).Write([]byte("token=s3cr3t\n"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(len("token=s3cr3t\n")))
+			Expect(buf.String()).To(Equal("token=****\n"))
+		})
+	})
+})
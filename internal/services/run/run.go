@@ -2,6 +2,7 @@ package run
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	stdio "io"
 	"os"
@@ -12,14 +13,41 @@ import (
 	"mvdan.cc/sh/v3/expand"
 	"mvdan.cc/sh/v3/interp"
 	"mvdan.cc/sh/v3/syntax"
+
+	"github.com/flowexec/flow/internal/services/redact"
 )
 
 func init() {
 	setupColorEnvironment()
 }
 
-// RunCmd executes a command in the current shell in a specific directory.
+// ExitError is returned when a command or file exits with a non-zero status.
+type ExitError struct {
+	Code   uint8
+	source string
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("%s exited with non-zero status %d", e.source, e.Code)
+}
+
+// ExitCode returns the exit status that caused the error. Errors that were not caused by a non-zero exit status
+// return 1 and a nil error returns 0.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return int(exitErr.Code)
+	}
+	return 1
+}
+
+// RunCmd executes a command in the current shell in a specific directory. The command is interrupted when the
+// context is done.
 func RunCmd(
+	ctx context.Context,
 	commandStr, dir string,
 	envList []string,
 	logMode io.LogMode,
@@ -29,7 +57,6 @@ func RunCmd(
 ) error {
 	logger.Debugf("running command in dir (%s):\n%s", dir, strings.TrimSpace(commandStr))
 
-	ctx := context.Background()
 	parser := syntax.NewParser()
 	reader := strings.NewReader(strings.TrimSpace(commandStr))
 	prog, err := parser.Parse(reader, "")
@@ -62,7 +89,7 @@ func RunCmd(
 	err = runner.Run(ctx, prog)
 	if err != nil {
 		if code, isExit := interp.IsExitStatus(err); isExit {
-			return fmt.Errorf("command exited with non-zero status %d", code)
+			return &ExitError{Code: code, source: "command"}
 		}
 		return fmt.Errorf("encountered an error executing command - %w", err)
 	}
@@ -70,8 +97,10 @@ func RunCmd(
 	return nil
 }
 
-// RunFile executes a file in the current shell in a specific directory.
+// RunFile executes a file in the current shell in a specific directory. The file execution is interrupted when the
+// context is done.
 func RunFile(
+	ctx context.Context,
 	filename, dir string,
 	envList []string,
 	logMode io.LogMode,
@@ -81,7 +110,6 @@ func RunFile(
 ) error {
 	logger.Debugf("executing file (%s)", filepath.Join(dir, filename))
 
-	ctx := context.Background()
 	fullPath := filepath.Join(dir, filename)
 	if _, err := os.Stat(fullPath); os.IsNotExist(err) {
 		return fmt.Errorf("file does not exist - %s", fullPath)
@@ -122,7 +150,7 @@ func RunFile(
 	err = runner.Run(ctx, prog)
 	if err != nil {
 		if code, isExit := interp.IsExitStatus(err); isExit {
-			return fmt.Errorf("file execution exited with non-zero status %d", code)
+			return &ExitError{Code: code, source: "file execution"}
 		}
 		return fmt.Errorf("encountered an error executing file - %w", err)
 	}
@@ -130,11 +158,11 @@ func RunFile(
 }
 
 func stdOutWriter(mode io.LogMode, logger io.Logger, logFields ...any) stdio.Writer {
-	return io.StdOutWriter{LogFields: logFields, Logger: logger, LogMode: &mode}
+	return redact.Writer(io.StdOutWriter{LogFields: logFields, Logger: logger, LogMode: &mode})
 }
 
 func stdErrWriter(mode io.LogMode, logger io.Logger, logFields ...any) stdio.Writer {
-	return io.StdErrWriter{LogFields: logFields, Logger: logger, LogMode: &mode}
+	return redact.Writer(io.StdErrWriter{LogFields: logFields, Logger: logger, LogMode: &mode})
 }
 
 func setupColorEnvironment() {
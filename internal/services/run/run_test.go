@@ -1,6 +1,9 @@
 package run_test
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
@@ -42,7 +45,7 @@ var _ = Describe("Run", func() {
 				logger.EXPECT().LogMode().DoAndReturn(func() tuikitIO.LogMode {
 					return tuikitIO.Hidden
 				}).AnyTimes()
-				err := run.RunCmd("echo \"foo\"", "", nil, tuikitIO.Hidden, logger, os.Stdin, nil)
+				err := run.RunCmd(context.Background(), "echo \"foo\"", "", nil, tuikitIO.Hidden, logger, os.Stdin, nil)
 				Expect(err).NotTo(HaveOccurred())
 			})
 		})
@@ -54,7 +57,7 @@ var _ = Describe("Run", func() {
 				}).AnyTimes()
 				logger.EXPECT().Print("foo").Times(1)
 				logger.EXPECT().Print("\n").Times(1)
-				err := run.RunCmd("echo \"foo\"", "", nil, tuikitIO.Text, logger, os.Stdin, nil)
+				err := run.RunCmd(context.Background(), "echo \"foo\"", "", nil, tuikitIO.Text, logger, os.Stdin, nil)
 				Expect(err).NotTo(HaveOccurred())
 			})
 		})
@@ -65,7 +68,7 @@ var _ = Describe("Run", func() {
 					return tuikitIO.Logfmt
 				}).AnyTimes()
 				logger.EXPECT().Infof("foo", gomock.Any()).Times(1)
-				err := run.RunCmd("echo \"foo\"", "", nil, tuikitIO.Logfmt, logger, os.Stdin, nil)
+				err := run.RunCmd(context.Background(), "echo \"foo\"", "", nil, tuikitIO.Logfmt, logger, os.Stdin, nil)
 				Expect(err).NotTo(HaveOccurred())
 			})
 		})
@@ -76,7 +79,7 @@ var _ = Describe("Run", func() {
 					return tuikitIO.JSON
 				}).AnyTimes()
 				logger.EXPECT().Infof("foo", gomock.Any()).Times(1)
-				err := run.RunCmd("echo \"foo\"", "", nil, tuikitIO.JSON, logger, os.Stdin, nil)
+				err := run.RunCmd(context.Background(), "echo \"foo\"", "", nil, tuikitIO.JSON, logger, os.Stdin, nil)
 				Expect(err).NotTo(HaveOccurred())
 			})
 		})
@@ -88,7 +91,7 @@ var _ = Describe("Run", func() {
 				}).AnyTimes()
 				fields := map[string]interface{}{"key": "value"}
 				logger.EXPECT().Infox("foo", "key", "value").Times(1)
-				err := run.RunCmd("echo \"foo\"", "", nil, tuikitIO.JSON, logger, os.Stdin, fields)
+				err := run.RunCmd(context.Background(), "echo \"foo\"", "", nil, tuikitIO.JSON, logger, os.Stdin, fields)
 				Expect(err).NotTo(HaveOccurred())
 			})
 		})
@@ -100,7 +103,7 @@ var _ = Describe("Run", func() {
 				}).AnyTimes()
 				env := []string{"key=value"}
 				logger.EXPECT().Infof("value", gomock.Any()).Times(1)
-				err := run.RunCmd("echo \"$key\"", "", env, tuikitIO.JSON, logger, os.Stdin, nil)
+				err := run.RunCmd(context.Background(), "echo \"$key\"", "", env, tuikitIO.JSON, logger, os.Stdin, nil)
 				Expect(err).NotTo(HaveOccurred())
 			})
 		})
@@ -131,8 +134,24 @@ var _ = Describe("Run", func() {
 			logger.EXPECT().Print("\n").Times(1)
 			filename := filepath.Base(testfile.Name())
 			filedir := filepath.Dir(testfile.Name())
-			err := run.RunFile(filename, filedir, nil, tuikitIO.Logfmt, logger, os.Stdin, nil)
+			err := run.RunFile(context.Background(), filename, filedir, nil, tuikitIO.Logfmt, logger, os.Stdin, nil)
 			Expect(err).NotTo(HaveOccurred())
 		})
 	})
+
+	Describe("ExitCode", func() {
+		It("should return the exit status of the command", func() {
+			err := run.RunCmd(context.Background(), "exit 3", "", nil, tuikitIO.Hidden, logger, os.Stdin, nil)
+			Expect(err).To(MatchError("command exited with non-zero status 3"))
+			Expect(run.ExitCode(fmt.Errorf("wrapped - %w", err))).To(Equal(3))
+		})
+
+		It("should return 0 when there is no error", func() {
+			Expect(run.ExitCode(nil)).To(Equal(0))
+		})
+
+		It("should return 1 for other errors", func() {
+			Expect(run.ExitCode(errors.New("failed"))).To(Equal(1))
+		})
+	})
 })
@@ -0,0 +1,107 @@
+package search
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sahilm/fuzzy"
+
+	"github.com/flowexec/flow/internal/cache"
+	"github.com/flowexec/flow/types/executable"
+)
+
+const (
+	// descriptionScore is the score of a query term found in the description. Descriptions are only matched by
+	// substring since a fuzzy match of a few characters would match almost any description.
+	descriptionScore = 5
+	// exactNameScore is added when a query term is the name or an alias of the executable.
+	exactNameScore = 25
+)
+
+// Score returns how relevant the executable is for the query. Each whitespace separated term of the query is fuzzy
+// matched against the name, reference, aliases and tags of the executable, and searched for in its description.
+// The executable matches only when every term matches.
+func Score(exec *executable.Executable, query string) (int, bool) {
+	terms := strings.Fields(query)
+	if len(terms) == 0 {
+		return 0, true
+	}
+
+	names := append([]string{exec.Name, exec.Ref().String()}, exec.Aliases...)
+	description := strings.ToLower(exec.Description)
+	var total int
+	for _, term := range terms {
+		best, matched := 0, false
+		for _, candidates := range [][]string{names, exec.Tags} {
+			if matches := fuzzy.Find(term, candidates); len(matches) > 0 {
+				if !matched || matches[0].Score > best {
+					best = matches[0].Score
+				}
+				matched = true
+			}
+		}
+		if strings.Contains(description, strings.ToLower(term)) && (!matched || best < descriptionScore) {
+			best, matched = descriptionScore, true
+		}
+		if !matched {
+			return 0, false
+		}
+		if strings.EqualFold(exec.Name, term) || slices.ContainsFunc(exec.Aliases, func(a string) bool {
+			return strings.EqualFold(a, term)
+		}) {
+			best += exactNameScore
+		}
+		total += best
+	}
+	return total, true
+}
+
+// Rank returns the executables that match the query, ordered by relevance. Favorites are listed first and the
+// relevance of executables that were run recently is raised. Without a query, executables that are not favorites
+// are ordered by their reference.
+func Rank(
+	execs executable.ExecutableList, query string, usage *cache.UsageData, now time.Time,
+) executable.ExecutableList {
+	type ranked struct {
+		exec     *executable.Executable
+		favorite bool
+		score    float64
+	}
+	hasQuery := strings.TrimSpace(query) != ""
+	results := make([]ranked, 0, len(execs))
+	for _, e := range execs {
+		score, ok := Score(e, query)
+		if !ok {
+			continue
+		}
+		r := ranked{exec: e, score: float64(score)}
+		if usage != nil {
+			r.favorite = usage.IsFavorite(e.Ref())
+			if hasQuery {
+				r.score += usage.Frecency(e.Ref(), now)
+			}
+		}
+		results = append(results, r)
+	}
+
+	slices.SortStableFunc(results, func(a, b ranked) int {
+		switch {
+		case a.favorite != b.favorite && a.favorite:
+			return -1
+		case a.favorite != b.favorite:
+			return 1
+		case a.score != b.score:
+			return cmp.Compare(b.score, a.score)
+		default:
+			return strings.Compare(a.exec.Ref().String(), b.exec.Ref().String())
+		}
+	})
+
+	ranking := make(executable.ExecutableList, 0, len(results))
+	for _, r := range results {
+		ranking = append(ranking, r.exec)
+	}
+	return ranking
+}
@@ -0,0 +1,103 @@
+package search_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/flowexec/flow/internal/cache"
+	"github.com/flowexec/flow/internal/services/search"
+	"github.com/flowexec/flow/types/executable"
+)
+
+func TestSearch(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Search Suite")
+}
+
+func newExec(verb executable.Verb, name string, aliases, tags []string, description string) *executable.Executable {
+	e := &executable.Executable{
+		Verb:        verb,
+		Name:        name,
+		Aliases:     aliases,
+		Tags:        tags,
+		Description: description,
+	}
+	e.SetContext("ws", "/ws", "", "/ws/test.flow")
+	return e
+}
+
+func refs(execs executable.ExecutableList) []string {
+	out := make([]string, 0, len(execs))
+	for _, e := range execs {
+		out = append(out, e.Ref().String())
+	}
+	return out
+}
+
+var _ = Describe("Search", func() {
+	var (
+		backup = newExec("run", "db-backup", []string{"bk"}, []string{"database"}, "Backs up the production database")
+		deploy = newExec("deploy", "api", nil, []string{"production"}, "Deploys the API service")
+		build  = newExec("build", "docs", nil, nil, "Builds the documentation site")
+		now    = time.Now()
+	)
+
+	Describe("Score", func() {
+		It("should fuzzy match names", func() {
+			_, ok := search.Score(backup, "dbbkp")
+			Expect(ok).To(BeTrue())
+		})
+
+		It("should match aliases, tags and descriptions", func() {
+			for _, query := range []string{"bk", "database", "production database"} {
+				_, ok := search.Score(backup, query)
+				Expect(ok).To(BeTrue(), query)
+			}
+		})
+
+		It("should require every term to match", func() {
+			_, ok := search.Score(backup, "backup zzz")
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should score exact names above partial matches", func() {
+			exact, _ := search.Score(newExec("run", "test", nil, nil, ""), "test")
+			partial, _ := search.Score(newExec("run", "e2e-tests", nil, nil, ""), "test")
+			Expect(exact).To(BeNumerically(">", partial))
+		})
+	})
+
+	Describe("Rank", func() {
+		It("should order executables by reference without a query", func() {
+			ranked := search.Rank(executable.ExecutableList{deploy, backup, build}, "", nil, now)
+			Expect(refs(ranked)).To(Equal([]string{"build ws/docs", "deploy ws/api", "run ws/db-backup"}))
+		})
+
+		It("should list favorites first", func() {
+			usage := &cache.UsageData{Favorites: []executable.Ref{deploy.Ref()}}
+			ranked := search.Rank(executable.ExecutableList{backup, build, deploy}, "", usage, now)
+			Expect(refs(ranked)).To(Equal([]string{"deploy ws/api", "build ws/docs", "run ws/db-backup"}))
+		})
+
+		It("should filter out executables that don't match the query", func() {
+			ranked := search.Rank(executable.ExecutableList{backup, build, deploy}, "production", nil, now)
+			Expect(refs(ranked)).To(ConsistOf("deploy ws/api", "run ws/db-backup"))
+		})
+
+		It("should raise recently run executables", func() {
+			a := newExec("run", "test-unit", nil, nil, "")
+			b := newExec("run", "test-e2e", nil, nil, "")
+			usage := &cache.UsageData{}
+			for range 5 {
+				usage.RecordRun(a.Ref(), now)
+			}
+			Expect(refs(search.Rank(executable.ExecutableList{a, b}, "test", nil, now))).
+				To(Equal([]string{"run ws/test-e2e", "run ws/test-unit"}))
+			Expect(refs(search.Rank(executable.ExecutableList{a, b}, "test", usage, now))).
+				To(Equal([]string{"run ws/test-unit", "run ws/test-e2e"}))
+		})
+	})
+})
@@ -15,44 +15,70 @@ import (
 	"github.com/flowexec/flow/types/executable"
 )
 
-func copyAllArtifacts(
+// artifactFile is a single file resolved from a template artifact.
+type artifactFile struct {
+	name       string
+	src        string
+	dst        string
+	asTemplate bool
+}
+
+// render returns the content that will be written to the artifact's destination.
+func (f artifactFile) render(templateData expressionData, partials map[string]string) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Clean(f.src))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read artifact")
+	}
+	if !f.asTemplate {
+		return data, nil
+	}
+	buf, err := processAsGoTemplate(f.name, string(data), templateData, partials)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to render artifact")
+	}
+	return buf.Bytes(), nil
+}
+
+func resolveAllArtifacts(
 	artifacts []executable.Artifact,
 	wsDir, srcDir, dstDir string,
 	templateData expressionData,
-) error {
+) ([]artifactFile, error) {
+	var files []artifactFile
 	var errs []error
 	for i, a := range artifacts {
-		if err := copyArtifact(
-			fmt.Sprintf("artifact-%d", i), wsDir, srcDir, dstDir, a, templateData,
-		); err != nil {
+		resolved, err := resolveArtifact(fmt.Sprintf("artifact-%d", i), wsDir, srcDir, dstDir, a, templateData)
+		if err != nil {
 			errs = append(errs, err)
+			continue
 		}
+		files = append(files, resolved...)
 	}
 	if len(errs) > 0 {
-		return errors.Errorf("errors copying artifacts: %v", errs)
+		return nil, errors.Errorf("errors resolving artifacts: %v", errs)
 	}
-	return nil
+	return files, nil
 }
 
 //nolint:gocognit
-func copyArtifact(
+func resolveArtifact(
 	name, wsPath, srcDir, dstDir string,
 	artifact executable.Artifact,
 	templateData expressionData,
-) error {
+) ([]artifactFile, error) {
 	srcPath, err := parseSourcePath(name, srcDir, wsPath, artifact, templateData)
 	if err != nil {
-		return errors.Wrap(err, "unable to parse source path")
+		return nil, errors.Wrap(err, "unable to parse source path")
 	}
 
 	if artifact.If != "" {
 		eval, err := expr.IsTruthy(artifact.If, templateData)
 		if err != nil {
-			return errors.Wrap(err, "unable to evaluate if condition")
+			return nil, errors.Wrap(err, "unable to evaluate if condition")
 		}
 		if !eval {
 			logger.Log().Debugf("skipping artifact %s", name)
-			return nil
+			return nil, nil
 		}
 	}
 
@@ -60,30 +86,36 @@ func copyArtifact(
 	if strings.Contains(srcName, "*") {
 		matches, err := filepath.Glob(srcPath)
 		if err != nil {
-			return errors.Wrap(err, "unable to glob source path")
+			return nil, errors.Wrap(err, "unable to glob source path")
 		}
+		var files []artifactFile
 		var errs []error
 		for i, match := range matches {
 			m := artifact
 			m.SrcName = filepath.Base(match)
 			m.SrcDir = filepath.Dir(match)
-			mErr := copyArtifact(fmt.Sprintf("%s-%d", name, i), wsPath, srcDir, dstDir, m, templateData)
+			m.If = ""
+			resolved, mErr := resolveArtifact(fmt.Sprintf("%s-%d", name, i), wsPath, srcDir, dstDir, m, templateData)
 			if mErr != nil {
 				errs = append(errs, mErr)
+				continue
 			}
+			files = append(files, resolved...)
 		}
 		if len(errs) > 0 {
-			return errors.Errorf("errors copying artifact from pattern: %v", errs)
+			return nil, errors.Errorf("errors copying artifact from pattern: %v", errs)
 		}
+		return files, nil
 	}
 
 	info, err := os.Stat(srcPath)
 	switch {
 	case os.IsNotExist(err):
-		return errors.Errorf("file does not exist: %s", srcPath)
+		return nil, errors.Errorf("file does not exist: %s", srcPath)
 	case err != nil:
-		return errors.Wrap(err, "unable to stat src file")
+		return nil, errors.Wrap(err, "unable to stat src file")
 	case info.IsDir():
+		var files []artifactFile
 		err := filepath.WalkDir(srcPath, func(path string, entry fs.DirEntry, err error) error {
 			if err != nil {
 				return err
@@ -94,12 +126,20 @@ func copyArtifact(
 			a := artifact
 			a.SrcName = filepath.Base(path)
 			a.SrcDir = filepath.Dir(path)
+			a.DstName = ""
+			a.If = ""
 			aName := fmt.Sprintf("%s-%s", name, a.SrcName)
-			return copyArtifact(aName, wsPath, srcDir, dstDir, a, templateData)
+			resolved, err := resolveArtifact(aName, wsPath, srcDir, dstDir, a, templateData)
+			if err != nil {
+				return err
+			}
+			files = append(files, resolved...)
+			return nil
 		})
 		if err != nil {
-			return errors.Wrap(err, "unable to walk directory")
+			return nil, errors.Wrap(err, "unable to walk directory")
 		}
+		return files, nil
 	}
 	if artifact.DstName == "" {
 		artifact.DstName = srcName
@@ -111,23 +151,77 @@ func copyArtifact(
 		templateData,
 	)
 	if err != nil {
-		return errors.Wrap(err, "unable to parse destination path")
+		return nil, errors.Wrap(err, "unable to parse destination path")
 	}
 
-	if err := os.MkdirAll(dstDir, 0750); err != nil {
-		if !os.IsExist(err) {
-			return errors.Wrap(err, "unable to create destination directory")
+	return []artifactFile{{name: name, src: srcPath, dst: dstPath, asTemplate: artifact.AsTemplate}}, nil
+}
+
+// checkDestinations returns an error for each artifact destination and flow file that already exists.
+func checkDestinations(t *target, files []artifactFile) error {
+	dsts := make([]string, 0, len(files)+1)
+	for _, f := range files {
+		dsts = append(dsts, f.dst)
+	}
+	if t.template.Template != "" {
+		dsts = append(dsts, t.fullPath)
+	}
+	var errs []error
+	for _, dst := range dsts {
+		if _, err := OverwritePolicyFail.shouldWrite(dst); err != nil {
+			errs = append(errs, err)
 		}
-		return errors.Wrap(err, "unable to create destination directory")
+	}
+	if len(errs) > 0 {
+		return errors.Errorf("errors writing template files: %v", errs)
+	}
+	return nil
+}
+
+func copyAllArtifacts(t *target, files []artifactFile, policy OverwritePolicy, changes *changeSet) error {
+	var errs []error
+	for _, f := range files {
+		if err := copyArtifact(t, f, policy, changes); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Errorf("errors copying artifacts: %v", errs)
+	}
+	return nil
+}
+
+func copyArtifact(t *target, f artifactFile, policy OverwritePolicy, changes *changeSet) error {
+	if err := changes.mkdirAll(filepath.Dir(f.dst)); err != nil {
+		return err
+	}
+
+	logger.Log().Debugx("copying artifact", "name", f.name, "src", f.src, "dst", f.dst)
+	if write, err := policy.shouldWrite(f.dst); err != nil {
+		return err
+	} else if !write {
+		return nil
+	}
+	if err := changes.record(f.dst); err != nil {
+		return err
+	}
+	if !f.asTemplate {
+		if err := filesystem.CopyFile(f.src, f.dst); err != nil {
+			return errors.Wrap(err, "unable to copy artifact")
+		}
+		return nil
 	}
 
-	logger.Log().Debugx("copying artifact", "name", name, "src", srcPath, "dst", dstPath)
-	if _, e := os.Stat(dstPath); e == nil {
-		// TODO: Add a flag to overwrite existing files
-		logger.Log().Warnx("Overwriting existing file", "dst", dstPath)
+	data, err := f.render(t.data, t.partials)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(f.src)
+	if err != nil {
+		return errors.Wrap(err, "unable to stat src file")
 	}
-	if err := filesystem.CopyFile(srcPath, dstPath); err != nil {
-		return errors.Wrap(err, "unable to copy artifact")
+	if err := os.WriteFile(f.dst, data, info.Mode().Perm()); err != nil {
+		return errors.Wrap(err, "unable to write artifact")
 	}
 	return nil
 }
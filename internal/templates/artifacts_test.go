@@ -0,0 +1,56 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/flowexec/flow/types/executable"
+)
+
+var _ = Describe("writeFiles", func() {
+	var srcDir, dstDir string
+
+	BeforeEach(func() {
+		srcDir = GinkgoT().TempDir()
+		dstDir = GinkgoT().TempDir()
+		Expect(os.WriteFile(filepath.Join(srcDir, "a.txt"), []byte("a"), 0600)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(srcDir, "b.txt"), []byte("b"), 0600)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(dstDir, "b.txt"), []byte("existing"), 0600)).To(Succeed())
+	})
+
+	newTarget := func() *target {
+		template := &executable.Template{
+			Artifacts: []executable.Artifact{
+				{SrcName: "a.txt", DstDir: filepath.Join(dstDir, "nested")},
+				{SrcName: "b.txt", DstDir: dstDir},
+			},
+		}
+		template.SetContext("test", filepath.Join(srcDir, "test.flow.tmpl"))
+		return &target{
+			template:    template,
+			flowfileDir: dstDir,
+			fullPath:    filepath.Join(dstDir, "test.flow"),
+			data:        expressionData{},
+		}
+	}
+
+	It("should not write any artifact when a destination exists and existing files fail", func() {
+		err := writeFiles(newTarget(), OverwritePolicyFail, &changeSet{})
+		Expect(err).To(MatchError(ContainSubstring("already exists")))
+		Expect(filepath.Join(dstDir, "nested")).ToNot(BeAnExistingFile())
+		data, err := os.ReadFile(filepath.Join(dstDir, "b.txt"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal("existing"))
+	})
+
+	It("should skip existing destinations and write the others", func() {
+		Expect(writeFiles(newTarget(), OverwritePolicySkip, &changeSet{})).To(Succeed())
+		Expect(filepath.Join(dstDir, "nested", "a.txt")).To(BeAnExistingFile())
+		data, err := os.ReadFile(filepath.Join(dstDir, "b.txt"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal("existing"))
+	})
+})
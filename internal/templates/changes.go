@@ -0,0 +1,93 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/pkg/errors"
+
+	"github.com/flowexec/flow/internal/logger"
+)
+
+// changeSet records the files and directories written while generating a template so that they can be rolled back.
+type changeSet struct {
+	dirs        []string
+	created     []string
+	overwritten map[string]originalFile
+}
+
+type originalFile struct {
+	data []byte
+	mode os.FileMode
+}
+
+// mkdirAll creates the directory and any missing parents, recording the directories that did not exist.
+func (c *changeSet) mkdirAll(dir string) error {
+	var missing []string
+	for d := filepath.Clean(dir); ; d = filepath.Dir(d) {
+		if _, err := os.Stat(d); err == nil {
+			break
+		}
+		missing = append(missing, d)
+		if filepath.Dir(d) == d {
+			break
+		}
+	}
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return errors.Wrap(err, "unable to create destination directory")
+	}
+	slices.Reverse(missing)
+	c.dirs = append(c.dirs, missing...)
+	return nil
+}
+
+// record must be called before the file at path is written.
+func (c *changeSet) record(path string) error {
+	info, err := os.Stat(path)
+	switch {
+	case os.IsNotExist(err):
+		c.created = append(c.created, path)
+		return nil
+	case err != nil:
+		return errors.Wrap(err, "unable to stat destination file")
+	}
+	if _, ok := c.overwritten[path]; ok || slices.Contains(c.created, path) {
+		return nil
+	}
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return errors.Wrap(err, "unable to read destination file")
+	}
+	if c.overwritten == nil {
+		c.overwritten = make(map[string]originalFile)
+	}
+	c.overwritten[path] = originalFile{data: data, mode: info.Mode().Perm()}
+	return nil
+}
+
+// rollback removes the created files and directories and restores the content of overwritten files.
+func (c *changeSet) rollback() error {
+	var errs []error
+	for i := len(c.created) - 1; i >= 0; i-- {
+		logger.Log().Debugx("removing generated file", "path", c.created[i])
+		if err := os.Remove(c.created[i]); err != nil && !os.IsNotExist(err) {
+			errs = append(errs, err)
+		}
+	}
+	for path, original := range c.overwritten {
+		logger.Log().Debugx("restoring overwritten file", "path", path)
+		if err := os.WriteFile(path, original.data, original.mode); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for i := len(c.dirs) - 1; i >= 0; i-- {
+		if err := os.Remove(c.dirs[i]); err != nil && !os.IsNotExist(err) {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Errorf("errors rolling back generated files: %v", errs)
+	}
+	return nil
+}
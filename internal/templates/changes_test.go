@@ -0,0 +1,42 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestTemplates(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Templates Suite")
+}
+
+var _ = Describe("changeSet", func() {
+	var dir string
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+	})
+
+	It("should remove created files and directories and restore overwritten files", func() {
+		existing := filepath.Join(dir, "existing.txt")
+		Expect(os.WriteFile(existing, []byte("original"), 0600)).To(Succeed())
+		created := filepath.Join(dir, "nested", "child", "created.txt")
+
+		changes := &changeSet{}
+		Expect(changes.mkdirAll(filepath.Dir(created))).To(Succeed())
+		Expect(changes.record(created)).To(Succeed())
+		Expect(os.WriteFile(created, []byte("created"), 0600)).To(Succeed())
+		Expect(changes.record(existing)).To(Succeed())
+		Expect(os.WriteFile(existing, []byte("changed"), 0600)).To(Succeed())
+
+		Expect(changes.rollback()).To(Succeed())
+		Expect(filepath.Join(dir, "nested")).ToNot(BeAnExistingFile())
+		data, err := os.ReadFile(existing)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal("original"))
+	})
+})
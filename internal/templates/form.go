@@ -40,7 +40,7 @@ func showForm(ctx *context.Context, fields executable.FormFields) error {
 			Type:           t,
 			Group:          uint(f.Group),
 			Description:    f.Description,
-			Default:        f.Default,
+			Default:        f.Value(),
 			Title:          f.Prompt,
 			Placeholder:    f.Default,
 			Required:       f.Required,
@@ -0,0 +1,61 @@
+package templates
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/flowexec/flow/internal/filesystem"
+	"github.com/flowexec/flow/internal/utils"
+	"github.com/flowexec/flow/types/executable"
+)
+
+// resolveTemplate returns a copy of the template with every template that it extends merged into it. The extends
+// value can either be the name of a registered template or a path relative to the extending template.
+func resolveTemplate(
+	template *executable.Template,
+	registered map[string]string,
+	env map[string]string,
+) (*executable.Template, error) {
+	resolved := *template
+	visited := map[string]bool{filepath.Clean(template.Location()): true}
+	child := &resolved
+	for child.Extends != "" {
+		path, ok := registered[child.Extends]
+		if !ok {
+			path = utils.ExpandPath(child.Extends, filepath.Dir(child.Location()), env)
+		}
+		path = filepath.Clean(path)
+		if visited[path] {
+			return nil, fmt.Errorf("template %s has a circular extends chain", template.Name())
+		}
+		visited[path] = true
+
+		parent, err := filesystem.LoadFlowFileTemplate("", path)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("unable to load parent template %s", child.Extends))
+		}
+		resolved.Extend(parent)
+		child = parent
+	}
+	resolved.Extends = ""
+	return &resolved, nil
+}
+
+// loadPartials reads the partial files of the template. Relative paths are resolved from the template's directory.
+func loadPartials(template *executable.Template) (map[string]string, error) {
+	partials := make(map[string]string, len(template.Partials))
+	for name, path := range template.Partials {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(filepath.Dir(template.Location()), path)
+		}
+		data, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("unable to read partial %s", name))
+		}
+		partials[name] = string(data)
+	}
+	return partials, nil
+}
@@ -0,0 +1,96 @@
+package templates
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/flowexec/flow/internal/logger"
+	"github.com/flowexec/flow/types/executable"
+)
+
+// OverwritePolicy determines how files that already exist are handled when rendering a template.
+type OverwritePolicy string
+
+const (
+	OverwritePolicyOverwrite OverwritePolicy = "overwrite"
+	OverwritePolicySkip      OverwritePolicy = "skip"
+	OverwritePolicyFail      OverwritePolicy = "fail"
+)
+
+type Options struct {
+	// Values are used as the answers to the template's form fields. Keys must match a form field key.
+	Values map[string]string
+	// NoInput disables the interactive form. Fields without a value fall back to their default.
+	NoInput bool
+	// Overwrite is applied to the flow file and every artifact that already exists. Defaults to overwrite.
+	Overwrite OverwritePolicy
+	// Transactional removes the created files and restores the overwritten files if writing the files or running
+	// a post-run executable fails.
+	Transactional bool
+}
+
+// LoadValuesFile reads a YAML (or JSON) file of form field answers.
+func LoadValuesFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read values file")
+	}
+	raw := make(map[string]any)
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, errors.Wrap(err, "unable to decode values file")
+	}
+	values := make(map[string]string, len(raw))
+	for k, v := range raw {
+		switch val := v.(type) {
+		case nil:
+			values[k] = ""
+		case map[string]any, []any:
+			return nil, fmt.Errorf("value for %s must be a scalar", k)
+		default:
+			values[k] = fmt.Sprintf("%v", val)
+		}
+	}
+	return values, nil
+}
+
+// applyFormValues sets the form field values from the provided answers. It returns true if every field has an
+// answer.
+func applyFormValues(fields executable.FormFields, values map[string]string) (bool, error) {
+	for k, v := range values {
+		f := fields.Find(k)
+		if f == nil {
+			return false, fmt.Errorf("template has no form field %s", k)
+		}
+		f.Set(v)
+	}
+	for _, f := range fields {
+		if _, ok := values[f.Key]; !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// shouldWrite applies the overwrite policy to the destination path. It returns false if the file should be left
+// untouched.
+func (p OverwritePolicy) shouldWrite(dst string) (bool, error) {
+	if _, err := os.Stat(dst); err != nil {
+		return true, nil
+	}
+	switch p {
+	case OverwritePolicySkip:
+		logger.Log().Infox("Skipping existing file", "dst", dst)
+		return false, nil
+	case OverwritePolicyFail:
+		return false, fmt.Errorf("file %s already exists", dst)
+	case OverwritePolicyOverwrite, "":
+		logger.Log().Warnx("Overwriting existing file", "dst", dst)
+		return true, nil
+	default:
+		return false, fmt.Errorf("unsupported overwrite policy %s", p)
+	}
+}
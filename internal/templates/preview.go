@@ -0,0 +1,222 @@
+package templates
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/aymanbagabas/go-udiff"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/flowexec/flow/internal/context"
+	"github.com/flowexec/flow/types/executable"
+	"github.com/flowexec/flow/types/workspace"
+)
+
+// FileChange describes what generating a template would do to a single file.
+type FileChange string
+
+const (
+	FileChangeCreate    FileChange = "create"
+	FileChangeModify    FileChange = "modify"
+	FileChangeUnchanged FileChange = "unchanged"
+	FileChangeSkip      FileChange = "skip"
+)
+
+// PreviewFile is a file that would be written when generating a template.
+type PreviewFile struct {
+	Path   string
+	Change FileChange
+	// Diff is the unified diff against the existing file. It is only set for modified files.
+	Diff string
+}
+
+// Preview is the in-memory result of rendering a template without writing any files or running its executables.
+type Preview struct {
+	Dir   string
+	Files []PreviewFile
+	// SkippedPreRun and SkippedPostRun are the number of pre-run and post-run executables that were not run.
+	SkippedPreRun  int
+	SkippedPostRun int
+}
+
+// PreviewTemplate renders the template's flowfile and artifacts into memory and compares them against the files
+// in the output directory. The template's pre-run and post-run executables are not run.
+func PreviewTemplate(
+	ctx *context.Context,
+	template *executable.Template,
+	ws *workspace.Workspace,
+	flowfileName, flowfileDir string,
+	opts Options,
+) (*Preview, error) {
+	t, err := prepareTarget(ctx, template, ws, flowfileName, flowfileDir, opts)
+	if err != nil {
+		return nil, err
+	}
+	template = t.template
+
+	preview := &Preview{
+		Dir:            t.flowfileDir,
+		SkippedPreRun:  len(template.PreRun),
+		SkippedPostRun: len(template.PostRun),
+	}
+	files, err := resolveAllArtifacts(
+		template.Artifacts,
+		t.wsPath,
+		filepath.Dir(template.Location()),
+		t.flowfileDir,
+		t.data,
+	)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		data, err := f.render(t.data, t.partials)
+		if err != nil {
+			return nil, err
+		}
+		pf, err := previewFile(f.dst, data, opts.Overwrite)
+		if err != nil {
+			return nil, err
+		}
+		preview.Files = append(preview.Files, pf)
+	}
+
+	if template.Template != "" {
+		flowfile, err := templateToFlowfile(template, t.data, t.partials)
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		if err := yaml.NewEncoder(&buf).Encode(flowfile); err != nil {
+			return nil, errors.Wrap(err, "unable to encode flowfile")
+		}
+		pf, err := previewFile(t.fullPath, buf.Bytes(), opts.Overwrite)
+		if err != nil {
+			return nil, err
+		}
+		preview.Files = append(preview.Files, pf)
+	}
+
+	slices.SortFunc(preview.Files, func(a, b PreviewFile) int { return strings.Compare(a.Path, b.Path) })
+	return preview, nil
+}
+
+func previewFile(path string, data []byte, policy OverwritePolicy) (PreviewFile, error) {
+	pf := PreviewFile{Path: path}
+	existing, err := os.ReadFile(filepath.Clean(path))
+	switch {
+	case os.IsNotExist(err):
+		pf.Change = FileChangeCreate
+		return pf, nil
+	case err != nil:
+		return pf, errors.Wrap(err, "unable to read existing file")
+	}
+
+	switch policy {
+	case OverwritePolicySkip:
+		pf.Change = FileChangeSkip
+		return pf, nil
+	case OverwritePolicyFail:
+		return pf, fmt.Errorf("file %s already exists", path)
+	case OverwritePolicyOverwrite, "":
+	default:
+		return pf, fmt.Errorf("unsupported overwrite policy %s", policy)
+	}
+
+	switch {
+	case bytes.Equal(existing, data):
+		pf.Change = FileChangeUnchanged
+	case isBinary(existing) || isBinary(data):
+		pf.Change = FileChangeModify
+		pf.Diff = fmt.Sprintf("Binary file %s differs\n", path)
+	default:
+		pf.Change = FileChangeModify
+		pf.Diff = udiff.Unified(path, path, string(existing), string(data))
+	}
+	return pf, nil
+}
+
+func isBinary(data []byte) bool {
+	return bytes.IndexByte(data, 0) != -1
+}
+
+// String returns a tree of the files that would be written followed by the diffs of any modified files.
+func (p *Preview) String() string {
+	var sb strings.Builder
+	sb.WriteString(p.Dir + "\n")
+	entries := make(map[string]FileChange, len(p.Files))
+	paths := make([]string, 0, len(p.Files))
+	for _, f := range p.Files {
+		rel, err := filepath.Rel(p.Dir, f.Path)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			rel = f.Path
+		}
+		rel = filepath.ToSlash(rel)
+		entries[rel] = f.Change
+		paths = append(paths, rel)
+	}
+	writeTree(&sb, "", buildTree(paths), entries, "")
+
+	fmt.Fprintf(
+		&sb, "\n%d file(s) previewed. Skipped %d pre-run and %d post-run executable(s).\n",
+		len(p.Files), p.SkippedPreRun, p.SkippedPostRun,
+	)
+	for _, f := range p.Files {
+		if f.Diff != "" {
+			sb.WriteString("\n" + f.Diff)
+		}
+	}
+	return sb.String()
+}
+
+type treeNode struct {
+	children map[string]*treeNode
+}
+
+func buildTree(paths []string) *treeNode {
+	root := &treeNode{children: map[string]*treeNode{}}
+	for _, p := range paths {
+		node := root
+		for _, part := range strings.Split(strings.TrimPrefix(p, "/"), "/") {
+			child, ok := node.children[part]
+			if !ok {
+				child = &treeNode{children: map[string]*treeNode{}}
+				node.children[part] = child
+			}
+			node = child
+		}
+	}
+	return root
+}
+
+func writeTree(sb *strings.Builder, prefix string, node *treeNode, entries map[string]FileChange, path string) {
+	names := make([]string, 0, len(node.children))
+	for name := range node.children {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for i, name := range names {
+		child := node.children[name]
+		childPath := name
+		if path != "" {
+			childPath = path + "/" + name
+		}
+		branch, indent := "├── ", "│   "
+		if i == len(names)-1 {
+			branch, indent = "└── ", "    "
+		}
+		line := prefix + branch + name
+		if change, ok := entries[childPath]; ok {
+			line += fmt.Sprintf(" (%s)", change)
+		} else if change, ok := entries["/"+childPath]; ok {
+			line += fmt.Sprintf(" (%s)", change)
+		}
+		sb.WriteString(line + "\n")
+		writeTree(sb, prefix+indent, child, entries, childPath)
+	}
+}
@@ -0,0 +1,187 @@
+package registry
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	downloadTimeout = 5 * time.Minute
+	// maxArchiveSize limits the size of downloaded archives and their extracted files.
+	maxArchiveSize = 512 << 20
+)
+
+// fetchArchive downloads the archive to a temporary file in dir, extracts it into dest and returns the archive's
+// sha256 checksum.
+func fetchArchive(src *Source, dir, dest string) (string, error) {
+	client := http.Client{Timeout: downloadTimeout}
+	resp, err := client.Get(src.URL)
+	if err != nil {
+		return "", fmt.Errorf("unable to download %s - %w", src.URL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unable to download %s - unexpected status %s", src.URL, resp.Status)
+	}
+
+	f, err := os.CreateTemp(dir, "archive-*"+src.archiveExt())
+	if err != nil {
+		return "", fmt.Errorf("unable to create archive file - %w", err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	hash := sha256.New()
+	n, err := io.Copy(io.MultiWriter(f, hash), io.LimitReader(resp.Body, maxArchiveSize+1))
+	if err != nil {
+		return "", fmt.Errorf("unable to download %s - %w", src.URL, err)
+	} else if n > maxArchiveSize {
+		return "", fmt.Errorf("archive %s exceeds the maximum size", src.URL)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	if src.archiveExt() == ".zip" {
+		err = extractZip(f, n, dest)
+	} else {
+		err = extractTarGz(f, dest)
+	}
+	if err != nil {
+		return "", fmt.Errorf("unable to extract %s - %w", src.URL, err)
+	}
+	if err := stripSingleRootDir(dest); err != nil {
+		return "", err
+	}
+	return "sha256:" + hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func extractTarGz(r io.Reader, dest string) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		target, err := extractPath(dest, hdr.Name)
+		if err != nil {
+			return err
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0750); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := writeFile(target, tr, hdr.FileInfo().Mode()); err != nil {
+				return err
+			}
+		default:
+			// links and special files are not supported in templates
+		}
+	}
+}
+
+func extractZip(r io.ReaderAt, size int64, dest string) error {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return err
+	}
+	for _, zf := range zr.File {
+		target, err := extractPath(dest, zf.Name)
+		if err != nil {
+			return err
+		}
+		if zf.FileInfo().IsDir() {
+			if err := os.MkdirAll(target, 0750); err != nil {
+				return err
+			}
+			continue
+		}
+		if !zf.Mode().IsRegular() {
+			continue
+		}
+		rc, err := zf.Open()
+		if err != nil {
+			return err
+		}
+		err = writeFile(target, rc, zf.Mode())
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// extractPath returns the destination of an archive entry, rejecting entries that would be written outside of dest.
+func extractPath(dest, name string) (string, error) {
+	target := filepath.Join(dest, filepath.FromSlash(name))
+	if target != dest && !strings.HasPrefix(target, filepath.Clean(dest)+string(os.PathSeparator)) {
+		return "", fmt.Errorf("archive entry %s is outside of the destination", name)
+	}
+	return target, nil
+}
+
+func writeFile(target string, r io.Reader, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0750); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(filepath.Clean(target), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode.Perm()|0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	n, err := io.Copy(f, io.LimitReader(r, maxArchiveSize+1))
+	if err != nil {
+		return err
+	} else if n > maxArchiveSize {
+		return fmt.Errorf("archive entry %s exceeds the maximum size", target)
+	}
+	return nil
+}
+
+// stripSingleRootDir moves the contents of dir up a level when the archive contains a single top-level directory,
+// as is the case for archives of git repositories.
+func stripSingleRootDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	if len(entries) != 1 || !entries[0].IsDir() {
+		return nil
+	}
+	// the root is renamed first in case it contains an entry with the same name
+	root := filepath.Join(dir, ".flow-archive-root")
+	if err := os.Rename(filepath.Join(dir, entries[0].Name()), root); err != nil {
+		return err
+	}
+	children, err := os.ReadDir(root)
+	if err != nil {
+		return err
+	}
+	for _, c := range children {
+		if err := os.Rename(filepath.Join(root, c.Name()), filepath.Join(dir, c.Name())); err != nil {
+			return err
+		}
+	}
+	return os.Remove(root)
+}
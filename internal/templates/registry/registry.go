@@ -0,0 +1,219 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/flowexec/flow/internal/filesystem"
+	"github.com/flowexec/flow/internal/services/git"
+	"github.com/flowexec/flow/types/executable"
+)
+
+const (
+	lockFileName = "template.lock.yaml"
+	srcDirName   = "src"
+)
+
+// Lock records where an installed template was fetched from and the exact revision that is cached.
+type Lock struct {
+	Name   string `yaml:"name"`
+	Source string `yaml:"source"`
+	// Resolved is the git commit or archive checksum of the cached template.
+	Resolved string `yaml:"resolved"`
+	// Version is the template version from its manifest, if any.
+	Version string `yaml:"version,omitempty"`
+	// Template is the path to the cached template file.
+	Template    string    `yaml:"template"`
+	InstalledAt time.Time `yaml:"installedAt"`
+}
+
+// Dir returns the cache directory of an installed template.
+func Dir(name string) string {
+	return filepath.Join(filesystem.TemplatesCacheDir(), name)
+}
+
+// LoadLock returns the lock of an installed template, or nil if the template was not installed from a remote source.
+func LoadLock(name string) (*Lock, error) {
+	data, err := os.ReadFile(filepath.Join(Dir(name), lockFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to read template lock - %w", err)
+	}
+	lock := &Lock{}
+	if err := yaml.Unmarshal(data, lock); err != nil {
+		return nil, fmt.Errorf("unable to decode template lock - %w", err)
+	}
+	return lock, nil
+}
+
+// Install fetches the template from the source and caches it under the flow cache directory, replacing any
+// previously installed version. The template's manifest is validated against the template and flow version before
+// the cache is updated.
+func Install(name string, src *Source, flowVersion string) (*Lock, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) || name == "." || name == ".." {
+		return nil, fmt.Errorf("invalid template name %s", name)
+	}
+	if err := os.MkdirAll(filesystem.TemplatesCacheDir(), 0750); err != nil {
+		return nil, fmt.Errorf("unable to create templates cache directory - %w", err)
+	}
+	tmpDir, err := os.MkdirTemp(filesystem.TemplatesCacheDir(), "."+name+"-*")
+	if err != nil {
+		return nil, fmt.Errorf("unable to create temporary directory - %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	srcDir := filepath.Join(tmpDir, srcDirName)
+	var resolved string
+	switch src.Kind {
+	case SourceKindGit:
+		if err := git.Clone(src.URL, src.Ref, srcDir); err != nil {
+			return nil, err
+		}
+		if resolved, err = git.HeadCommit(srcDir); err != nil {
+			return nil, err
+		}
+		if err := os.RemoveAll(filepath.Join(srcDir, ".git")); err != nil {
+			return nil, fmt.Errorf("unable to clean up git directory - %w", err)
+		}
+	case SourceKindArchive:
+		if resolved, err = fetchArchive(src, tmpDir, srcDir); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported template source kind %s", src.Kind)
+	}
+
+	templateDir := filepath.Join(srcDir, filepath.FromSlash(src.Subdir))
+	manifest, templateFile, err := loadManifest(templateDir)
+	if err != nil {
+		return nil, err
+	}
+	tmpl, err := filesystem.LoadFlowFileTemplate(name, templateFile)
+	if err != nil {
+		return nil, err
+	}
+	if err := tmpl.Validate(); err != nil {
+		return nil, err
+	}
+	if err := manifest.ValidateTemplate(tmpl); err != nil {
+		return nil, fmt.Errorf("template does not match its manifest - %w", err)
+	}
+	if err := manifest.CheckFlowVersion(flowVersion); err != nil {
+		return nil, err
+	}
+
+	relTemplate, err := filepath.Rel(tmpDir, templateFile)
+	if err != nil {
+		return nil, err
+	}
+	dir := Dir(name)
+	lock := &Lock{
+		Name:        name,
+		Source:      src.String(),
+		Resolved:    resolved,
+		Version:     manifest.Version,
+		Template:    filepath.Join(dir, relTemplate),
+		InstalledAt: time.Now().UTC(),
+	}
+	if err := writeLock(tmpDir, lock); err != nil {
+		return nil, err
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return nil, fmt.Errorf("unable to remove previously installed template - %w", err)
+	}
+	if err := os.Rename(tmpDir, dir); err != nil {
+		return nil, fmt.Errorf("unable to cache template - %w", err)
+	}
+	return lock, nil
+}
+
+// Update re-installs a template from its recorded source. If ref is set, the template is moved to that ref;
+// otherwise the recorded ref (or archive URL) is fetched again.
+func Update(name, ref, flowVersion string) (*Lock, error) {
+	lock, err := LoadLock(name)
+	if err != nil {
+		return nil, err
+	} else if lock == nil {
+		return nil, fmt.Errorf("template %s was not installed from a remote source", name)
+	}
+	src, err := ParseSource(lock.Source)
+	if err != nil {
+		return nil, err
+	}
+	if ref != "" {
+		if src.Kind != SourceKindGit {
+			return nil, fmt.Errorf("template %s is installed from an archive; refs are only supported for git", name)
+		}
+		src = src.WithRef(ref)
+	}
+	return Install(name, src, flowVersion)
+}
+
+// loadManifest loads the manifest in dir and returns the path to its template file. A missing manifest is treated
+// as an empty one, in which case the directory must contain exactly one template file.
+func loadManifest(dir string) (*executable.TemplateManifest, string, error) {
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return nil, "", fmt.Errorf("template directory %s not found in source", filepath.Base(dir))
+	}
+	manifest := &executable.TemplateManifest{}
+	for _, fn := range executable.TemplateManifestFileNames {
+		data, err := os.ReadFile(filepath.Join(dir, fn))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, "", fmt.Errorf("unable to read template manifest - %w", err)
+		}
+		if err := yaml.Unmarshal(data, manifest); err != nil {
+			return nil, "", fmt.Errorf("unable to decode template manifest - %w", err)
+		}
+		break
+	}
+
+	if manifest.Template != "" {
+		templateFile := filepath.Join(dir, filepath.FromSlash(manifest.Template))
+		if !strings.HasPrefix(templateFile, filepath.Clean(dir)+string(os.PathSeparator)) {
+			return nil, "", fmt.Errorf("manifest template %s must be within the template directory", manifest.Template)
+		}
+		return manifest, templateFile, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, "", err
+	}
+	var found []string
+	for _, e := range entries {
+		if !e.IsDir() && executable.HasFlowFileTemplateExt(e.Name()) {
+			found = append(found, e.Name())
+		}
+	}
+	switch len(found) {
+	case 0:
+		return nil, "", errors.New("no template file found in source")
+	case 1:
+		return manifest, filepath.Join(dir, found[0]), nil
+	default:
+		return nil, "", fmt.Errorf(
+			"multiple template files found in source (%s); set the template in the manifest",
+			strings.Join(found, ", "),
+		)
+	}
+}
+
+func writeLock(dir string, lock *Lock) error {
+	data, err := yaml.Marshal(lock)
+	if err != nil {
+		return fmt.Errorf("unable to encode template lock - %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, lockFileName), data, 0600); err != nil {
+		return fmt.Errorf("unable to write template lock - %w", err)
+	}
+	return nil
+}
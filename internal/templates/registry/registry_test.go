@@ -0,0 +1,198 @@
+package registry_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/flowexec/flow/internal/filesystem"
+	"github.com/flowexec/flow/internal/templates/registry"
+)
+
+const (
+	testTemplate = `form:
+  - key: Name
+    prompt: Name?
+    required: true
+template: |
+  namespace: {{ .Name }}
+`
+	testManifest = `name: service
+version: 1.0.0
+flowVersion: ">=1.0.0"
+fields:
+  - key: Name
+    required: true
+`
+)
+
+func TestRegistry(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Template Registry Suite")
+}
+
+var _ = Describe("ParseSource", func() {
+	DescribeTable("valid sources",
+		func(src string, expected registry.Source) {
+			s, err := registry.ParseSource(src)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*s).To(Equal(expected))
+			Expect(s.String()).To(Equal(src))
+		},
+		Entry("git url", "git+https://github.com/org/repo",
+			registry.Source{Kind: registry.SourceKindGit, URL: "https://github.com/org/repo"}),
+		Entry("git url with subdir and ref", "git+https://github.com/org/repo//templates/service@v1.2.0",
+			registry.Source{
+				Kind: registry.SourceKindGit, URL: "https://github.com/org/repo", Subdir: "templates/service", Ref: "v1.2.0",
+			}),
+		Entry("git ssh url with ref", "git+ssh://git@github.com/org/repo.git@main",
+			registry.Source{Kind: registry.SourceKindGit, URL: "ssh://git@github.com/org/repo.git", Ref: "main"}),
+		Entry("archive with subdir", "https://example.com/templates.tar.gz//service",
+			registry.Source{Kind: registry.SourceKindArchive, URL: "https://example.com/templates.tar.gz", Subdir: "service"}),
+	)
+
+	DescribeTable("invalid sources",
+		func(src string) {
+			_, err := registry.ParseSource(src)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("local path", "./templates/service"),
+		Entry("unsupported archive", "https://example.com/templates.rar"),
+		Entry("subdir outside of source", "git+https://github.com/org/repo//../other"),
+	)
+})
+
+var _ = Describe("Install", func() {
+	BeforeEach(func() {
+		Expect(os.Setenv(filesystem.FlowCacheDirEnvVar, GinkgoT().TempDir())).To(Succeed())
+		DeferCleanup(func() { Expect(os.Unsetenv(filesystem.FlowCacheDirEnvVar)).To(Succeed()) })
+	})
+
+	When("the source is a git repository", func() {
+		var repoDir string
+
+		gitCmd := func(args ...string) string {
+			cmd := exec.Command("git", args...)
+			cmd.Dir = repoDir
+			cmd.Env = append(os.Environ(),
+				"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+				"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+			)
+			out, err := cmd.CombinedOutput()
+			Expect(err).NotTo(HaveOccurred(), string(out))
+			return string(bytes.TrimSpace(out))
+		}
+
+		BeforeEach(func() {
+			if _, err := exec.LookPath("git"); err != nil {
+				Skip("git is not installed")
+			}
+			repoDir = GinkgoT().TempDir()
+			Expect(os.MkdirAll(filepath.Join(repoDir, "service"), 0750)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(repoDir, "service", "service.flow.tmpl"), []byte(testTemplate), 0600)).
+				To(Succeed())
+			Expect(os.WriteFile(filepath.Join(repoDir, "service", "flow.template.yaml"), []byte(testManifest), 0600)).
+				To(Succeed())
+			gitCmd("init", "--quiet", "--initial-branch", "main")
+			gitCmd("add", ".")
+			gitCmd("commit", "--quiet", "-m", "v1")
+			gitCmd("tag", "v1")
+		})
+
+		It("should cache and pin the template", func() {
+			src, err := registry.ParseSource("git+file://" + repoDir + "//service@v1")
+			Expect(err).NotTo(HaveOccurred())
+			lock, err := registry.Install("service", src, "1.0.0")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lock.Resolved).To(Equal(gitCmd("rev-parse", "HEAD")))
+			Expect(lock.Version).To(Equal("1.0.0"))
+			Expect(lock.Template).To(HavePrefix(registry.Dir("service")))
+			Expect(lock.Template).To(BeAnExistingFile())
+
+			loaded, err := registry.LoadLock("service")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(loaded.Source).To(Equal(src.String()))
+		})
+
+		It("should move the template to a new ref when updated", func() {
+			src, err := registry.ParseSource("git+file://" + repoDir + "//service@v1")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = registry.Install("service", src, "")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(os.WriteFile(filepath.Join(repoDir, "service", "README.md"), []byte("v2"), 0600)).To(Succeed())
+			gitCmd("add", ".")
+			gitCmd("commit", "--quiet", "-m", "v2")
+			lock, err := registry.Update("service", "main", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lock.Resolved).To(Equal(gitCmd("rev-parse", "HEAD")))
+			Expect(lock.Source).To(HaveSuffix("@main"))
+			Expect(filepath.Join(filepath.Dir(lock.Template), "README.md")).To(BeAnExistingFile())
+		})
+
+		It("should fail when the flow version does not satisfy the manifest", func() {
+			src, err := registry.ParseSource("git+file://" + repoDir + "//service")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = registry.Install("service", src, "0.9.0")
+			Expect(err).To(MatchError(ContainSubstring("requires flow >=1.0.0")))
+			Expect(registry.Dir("service")).NotTo(BeADirectory())
+		})
+	})
+
+	When("the source is an archive", func() {
+		It("should extract and cache the template", func() {
+			archive := tarGz(map[string]string{
+				"templates-1.0.0/service/service.flow.tmpl":  testTemplate,
+				"templates-1.0.0/service/flow.template.yaml": testManifest,
+			})
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write(archive)
+			}))
+			DeferCleanup(server.Close)
+
+			src, err := registry.ParseSource(server.URL + "/templates.tar.gz//service")
+			Expect(err).NotTo(HaveOccurred())
+			lock, err := registry.Install("service", src, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lock.Resolved).To(HavePrefix("sha256:"))
+			Expect(lock.Template).To(Equal(filepath.Join(registry.Dir("service"), "src", "service", "service.flow.tmpl")))
+			Expect(lock.Template).To(BeAnExistingFile())
+		})
+
+		It("should reject entries outside of the destination", func() {
+			archive := tarGz(map[string]string{"../escape.flow.tmpl": testTemplate})
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write(archive)
+			}))
+			DeferCleanup(server.Close)
+
+			src, err := registry.ParseSource(server.URL + "/templates.tgz")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = registry.Install("service", src, "")
+			Expect(err).To(MatchError(ContainSubstring("outside of the destination")))
+		})
+	})
+})
+
+func tarGz(files map[string]string) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		Expect(tw.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(content))})).To(Succeed())
+		_, err := tw.Write([]byte(content))
+		Expect(err).NotTo(HaveOccurred())
+	}
+	Expect(tw.Close()).To(Succeed())
+	Expect(gz.Close()).To(Succeed())
+	return buf.Bytes()
+}
@@ -0,0 +1,114 @@
+package registry
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+type SourceKind string
+
+const (
+	SourceKindGit     SourceKind = "git"
+	SourceKindArchive SourceKind = "archive"
+
+	gitSourcePrefix = "git+"
+)
+
+var archiveExts = []string{".tar.gz", ".tgz", ".zip"}
+
+// Source is a remote location that a template can be installed from.
+//
+// Git sources use the format `git+URL[//SUBDIR][@REF]` (e.g. `git+https://github.com/org/repo//service@v1.2.0`).
+// Archive sources use the format `URL[//SUBDIR]` where the URL points to a `.tar.gz`, `.tgz` or `.zip` file.
+type Source struct {
+	Kind   SourceKind
+	URL    string
+	Subdir string
+	Ref    string
+}
+
+func ParseSource(src string) (*Source, error) {
+	src = strings.TrimSpace(src)
+	s := &Source{}
+	switch {
+	case strings.HasPrefix(src, gitSourcePrefix):
+		s.Kind = SourceKindGit
+		src = strings.TrimPrefix(src, gitSourcePrefix)
+	case strings.HasPrefix(src, "http://") || strings.HasPrefix(src, "https://"):
+		s.Kind = SourceKindArchive
+	default:
+		return nil, fmt.Errorf("unsupported template source %s; expected git+URL or an archive URL", src)
+	}
+
+	// the subdir separator is the first `//` after the scheme
+	start := 0
+	if i := strings.Index(src, "://"); i >= 0 {
+		start = i + len("://")
+	}
+	s.URL = src
+	if i := strings.Index(src[start:], "//"); i >= 0 {
+		s.URL = src[:start+i]
+		s.Subdir = src[start+i+2:]
+	}
+
+	if s.Kind == SourceKindGit {
+		// only an `@` in the last path segment is a ref; others are part of the url (e.g. git@host)
+		target := &s.URL
+		if s.Subdir != "" {
+			target = &s.Subdir
+		}
+		if i := strings.LastIndex(*target, "@"); i > strings.LastIndex(*target, "/") {
+			s.Ref = (*target)[i+1:]
+			*target = (*target)[:i]
+		}
+	}
+
+	if s.URL == "" || s.URL == "https:" || s.URL == "http:" {
+		return nil, fmt.Errorf("template source %s is missing a url", src)
+	}
+	if s.Kind == SourceKindArchive && s.archiveExt() == "" {
+		return nil, fmt.Errorf("unsupported archive %s; expected one of %s", s.URL, strings.Join(archiveExts, ", "))
+	}
+	if s.Subdir != "" {
+		s.Subdir = strings.Trim(s.Subdir, "/")
+		if clean := path.Clean(s.Subdir); clean == ".." || strings.HasPrefix(clean, "../") {
+			return nil, fmt.Errorf("template source subdirectory %s must be within the source", s.Subdir)
+		}
+	}
+	return s, nil
+}
+
+// WithRef returns a copy of the source that points to a different ref.
+func (s Source) WithRef(ref string) *Source {
+	s.Ref = ref
+	return &s
+}
+
+func (s Source) String() string {
+	var b strings.Builder
+	if s.Kind == SourceKindGit {
+		b.WriteString(gitSourcePrefix)
+	}
+	b.WriteString(s.URL)
+	if s.Subdir != "" {
+		b.WriteString("//" + s.Subdir)
+	}
+	if s.Ref != "" {
+		b.WriteString("@" + s.Ref)
+	}
+	return b.String()
+}
+
+func (s Source) archiveExt() string {
+	u := strings.ToLower(s.URL)
+	if i := strings.IndexAny(u, "?#"); i >= 0 {
+		u = u[:i]
+	}
+	for _, ext := range archiveExts {
+		if strings.HasSuffix(u, ext) {
+			return ext
+		}
+	}
+	return ""
+}
@@ -25,12 +25,135 @@ import (
 	"github.com/flowexec/flow/types/workspace"
 )
 
+// target is the resolved template, output location and expression data of a template being rendered.
+type target struct {
+	template     *executable.Template
+	partials     map[string]string
+	wsPath       string
+	flowfileName string
+	flowfileDir  string
+	fullPath     string
+	data         expressionData
+}
+
 func ProcessTemplate(
 	ctx *context.Context,
 	template *executable.Template,
 	ws *workspace.Workspace,
 	flowfileName, flowfileDir string,
+	opts Options,
 ) error {
+	t, err := prepareTarget(ctx, template, ws, flowfileName, flowfileDir, opts)
+	if err != nil {
+		return err
+	}
+	template = t.template
+
+	if opts.Overwrite == OverwritePolicyFail && template.Template != "" {
+		// fail before running any pre-run executables or copying artifacts
+		if _, err := opts.Overwrite.shouldWrite(t.fullPath); err != nil {
+			return err
+		}
+	}
+
+	summary := engine.ResultSummary{}
+	defer logStepSummary(&summary)
+
+	preRun, err := runExecutables(
+		ctx, ws, "pre-run", filepath.Dir(template.Location()), template.PreRun, t.data,
+	)
+	summary.Results = append(summary.Results, preRun.Results...)
+	if err != nil {
+		return err
+	}
+
+	changes := &changeSet{}
+	if err := writeFiles(t, opts.Overwrite, changes); err != nil {
+		return rollbackOnError(err, changes, opts)
+	}
+	postRun, err := runExecutables(ctx, ws, "post-run", t.flowfileDir, template.PostRun, t.data)
+	summary.Results = append(summary.Results, postRun.Results...)
+	if err != nil {
+		return rollbackOnError(err, changes, opts)
+	}
+
+	return nil
+}
+
+// writeFiles copies the template's artifacts and writes the rendered flow file.
+func writeFiles(t *target, policy OverwritePolicy, changes *changeSet) error {
+	template := t.template
+	files, err := resolveAllArtifacts(
+		template.Artifacts,
+		t.wsPath,
+		filepath.Dir(template.Location()),
+		t.flowfileDir,
+		t.data,
+	)
+	if err != nil {
+		return err
+	}
+	if policy == OverwritePolicyFail {
+		// fail before anything is written so that no partial output is left
+		if err := checkDestinations(t, files); err != nil {
+			return err
+		}
+	}
+	if err := copyAllArtifacts(t, files, policy, changes); err != nil {
+		return err
+	}
+	if template.Template == "" {
+		return nil
+	}
+
+	flowfile, err := templateToFlowfile(template, t.data, t.partials)
+	if err != nil {
+		return err
+	}
+	write, err := policy.shouldWrite(t.fullPath)
+	if err != nil || !write {
+		return err
+	}
+	if err := changes.mkdirAll(t.flowfileDir); err != nil {
+		return err
+	}
+	if err := changes.record(t.fullPath); err != nil {
+		return err
+	}
+	if err := filesystem.WriteFlowFile(t.fullPath, flowfile); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("unable to write flowfile %s from template", t.flowfileName))
+	}
+	return nil
+}
+
+// rollbackOnError reverts the written files when generating in transactional mode.
+func rollbackOnError(err error, changes *changeSet, opts Options) error {
+	if !opts.Transactional {
+		return err
+	}
+	logger.Log().Warnf("Rolling back generated files")
+	if rbErr := changes.rollback(); rbErr != nil {
+		return errors.Wrap(err, rbErr.Error())
+	}
+	return err
+}
+
+func logStepSummary(summary *engine.ResultSummary) {
+	if len(summary.Results) == 0 {
+		return
+	}
+	logger.Log().PlainTextInfo("Template executables:\n" + strings.TrimSuffix(summary.Steps(), "\n"))
+}
+
+// prepareTarget collects the form values and resolves the flowfile name, output directory and expression data used
+// when rendering the template.
+func prepareTarget(
+	ctx *context.Context,
+	template *executable.Template,
+	ws *workspace.Workspace,
+	flowfileName, flowfileDir string,
+	opts Options,
+) (*target, error) {
 	if flowfileName == "" {
 		flowfileName = fmt.Sprintf("executables_%s", time.Now().Format("20060102150405"))
 	}
@@ -39,149 +162,180 @@ func ProcessTemplate(
 		flowfileName += executable.FlowFileExt
 	}
 
-	formMap := make(map[string]string)
-	if template.Form != nil {
-		if err := showForm(ctx, template.Form); err != nil {
-			return err
-		}
-		formMap = template.Form.ValueMap()
-	}
-
 	env := os.Environ()
 	envMap := make(map[string]string)
 	for _, e := range env {
 		pair := strings.SplitN(e, "=", 2)
 		envMap[pair[0]] = pair[1]
 	}
-	flowfileDir = utils.ExpandDirectory(flowfileDir, ws.Location(), template.Location(), envMap)
-	fullPath := filepath.Join(flowfileDir, flowfileName)
-	logger.Log().Debugx(
-		fmt.Sprintf("processing %s template", flowfileName),
-		"template", template.Location(), "output", fullPath,
-	)
-
-	dataMap := newExpressionData(
-		ws.AssignedName(), ws.Location(),
-		flowfileName, flowfileDir, fullPath, template.Location(),
-		envMap, formMap,
-	)
 
-	if err := runExecutables(
-		ctx, ws, "pre-run", filepath.Dir(template.Location()), template.PreRun, dataMap,
-	); err != nil {
-		return err
+	template, err := resolveTemplate(template, ctx.Config.Templates, envMap)
+	if err != nil {
+		return nil, err
 	}
-	if err := copyAllArtifacts(
-		template.Artifacts,
-		ws.Location(),
-		filepath.Dir(template.Location()),
-		flowfileDir,
-		dataMap,
-	); err != nil {
-		return err
+	partials, err := loadPartials(template)
+	if err != nil {
+		return nil, err
 	}
 
-	if template.Template != "" {
-		flowfile, err := templateToFlowfile(template, dataMap)
+	formMap := make(map[string]string)
+	if template.Form != nil {
+		answered, err := applyFormValues(template.Form, opts.Values)
 		if err != nil {
-			return err
+			return nil, err
 		}
-
-		if _, e := os.Stat(fullPath); e == nil {
-			// TODO: Add a flag to overwrite existing files
-			logger.Log().Warnx("Overwriting existing file", "dst", fullPath)
+		if !answered && !opts.NoInput {
+			if err := showForm(ctx, template.Form); err != nil {
+				return nil, err
+			}
 		}
-
-		if err := filesystem.WriteFlowFile(fullPath, flowfile); err != nil {
-			return errors.Wrap(err, fmt.Sprintf("unable to write flowfile %s from template", flowfileName))
+		if err := template.Form.ValidateValues(); err != nil {
+			return nil, errors.Wrap(err, "invalid template form values")
 		}
-	}
-	if err := runExecutables(ctx, ws, "post-run", flowfileDir, template.PostRun, dataMap); err != nil {
-		return err
+		formMap = template.Form.ValueMap()
 	}
 
-	return nil
+	flowfileDir = utils.ExpandDirectory(flowfileDir, ws.Location(), template.Location(), envMap)
+	fullPath := filepath.Join(flowfileDir, flowfileName)
+	logger.Log().Debugx(
+		fmt.Sprintf("processing %s template", flowfileName),
+		"template", template.Location(), "output", fullPath,
+	)
+
+	return &target{
+		template:     template,
+		partials:     partials,
+		wsPath:       ws.Location(),
+		flowfileName: flowfileName,
+		flowfileDir:  flowfileDir,
+		fullPath:     fullPath,
+		data: newExpressionData(
+			ws.AssignedName(), ws.Location(),
+			flowfileName, flowfileDir, fullPath, template.Location(),
+			envMap, formMap,
+		),
+	}, nil
 }
 
-//nolint:gocognit
+// runExecutables runs the executables of a template stage in order. Executables with a false if condition are
+// skipped. The remaining executables are not run after a failure unless the failed executable has continueOnError set.
 func runExecutables(
 	ctx *context.Context,
 	ws *workspace.Workspace,
 	stage, flowfileDir string,
 	execs []executable.TemplateRefConfig,
 	templateData expressionData,
-) error {
+) (engine.ResultSummary, error) {
 	logger.Log().Debugf("running %d %s executables", len(execs), stage)
+	summary := engine.ResultSummary{}
 	for i, e := range execs {
-		if e.If != "" {
-			eval, err := expr.IsTruthy(e.If, templateData)
-			if err != nil {
-				return errors.Wrap(err, "unable to evaluate if condition")
-			}
-			if !eval {
-				logger.Log().Debugf("skipping %s executable %d", stage, i)
-				return nil
-			}
-		}
-		var exec *executable.Executable
+		result := engine.Result{ID: stepID(stage, i, e)}
+		skipped, err := runExecutable(ctx, ws, stage, flowfileDir, i, e, templateData)
 		switch {
-		case e.Ref != "":
-			var err error
-			ref, err := processAsGoTemplate(flowfileDir, string(e.Ref), templateData)
-			if err != nil {
-				return errors.Wrap(err, fmt.Sprintf("unable to process %s executable %d", stage, i))
-			}
-			exec, err = execUtils.ExecutableForRef(ctx, executable.Ref(ref.String()))
-			if err != nil {
-				return err
-			}
-		case e.Cmd != "":
-			cmd, err := processAsGoTemplate(flowfileDir, e.Cmd, templateData)
+		case skipped:
+			logger.Log().Debugf("skipping %s executable %d", stage, i)
+			result.Skipped = true
+		case err != nil && e.ContinueOnError:
+			logger.Log().Warnx(fmt.Sprintf("%s executable %d failed, continuing", stage, i), "err", err)
+			result.Error = err
+		case err != nil:
+			result.Error = err
+			summary.Results = append(summary.Results, result)
+			return summary, err
+		}
+		summary.Results = append(summary.Results, result)
+	}
+	return summary, nil
+}
+
+// runExecutable runs a single template executable. It returns true if the executable was skipped because its if
+// condition is false.
+//
+//nolint:gocognit
+func runExecutable(
+	ctx *context.Context,
+	ws *workspace.Workspace,
+	stage, flowfileDir string,
+	i int,
+	e executable.TemplateRefConfig,
+	templateData expressionData,
+) (bool, error) {
+	if e.If != "" {
+		eval, err := expr.IsTruthy(e.If, templateData)
+		if err != nil {
+			return false, errors.Wrap(err, "unable to evaluate if condition")
+		}
+		if !eval {
+			return true, nil
+		}
+	}
+	var exec *executable.Executable
+	switch {
+	case e.Ref != "":
+		var err error
+		ref, err := processAsGoTemplate(flowfileDir, string(e.Ref), templateData)
+		if err != nil {
+			return false, errors.Wrap(err, fmt.Sprintf("unable to process %s executable %d", stage, i))
+		}
+		exec, err = execUtils.ExecutableForRef(ctx, executable.Ref(ref.String()))
+		if err != nil {
+			return false, err
+		}
+	case e.Cmd != "":
+		cmd, err := processAsGoTemplate(flowfileDir, e.Cmd, templateData)
+		if err != nil {
+			return false, errors.Wrap(err, fmt.Sprintf("unable to process %s executable %d", stage, i))
+		}
+		exec = execUtils.ExecutableForCmd(templateParent(ws.AssignedName(), ws.Location(), flowfileDir), cmd.String(), i)
+	default:
+		return false, fmt.Errorf("%s executable must have a ref or cmd", stage)
+	}
+	inputEnv := make(map[string]string)
+	ee := expressionEnv(templateData)
+	maps.Copy(inputEnv, ee)
+	//nolint:nestif
+	if len(e.Args) > 0 {
+		args := make([]string, 0)
+		for _, arg := range e.Args {
+			a, err := processAsGoTemplate(flowfileDir, arg, templateData)
 			if err != nil {
-				return errors.Wrap(err, fmt.Sprintf("unable to process %s executable %d", stage, i))
+				return false, errors.Wrap(err, fmt.Sprintf("unable to process %s executable %d", stage, i))
 			}
-			exec = execUtils.ExecutableForCmd(templateParent(ws.AssignedName(), ws.Location(), flowfileDir), cmd.String(), i)
-		default:
-			return errors.New("post-run executable must have a ref or cmd")
+			args = append(args, a.String())
 		}
-		inputEnv := make(map[string]string)
-		ee := expressionEnv(templateData)
-		maps.Copy(inputEnv, ee)
-		//nolint:nestif
-		if len(e.Args) > 0 {
-			args := make([]string, 0)
-			for _, arg := range e.Args {
-				a, err := processAsGoTemplate(flowfileDir, arg, templateData)
-				if err != nil {
-					return errors.Wrap(err, fmt.Sprintf("unable to process %s executable %d", stage, i))
-				}
-				args = append(args, a.String())
-			}
-			execEnv := exec.Env()
-			if execEnv == nil || execEnv.Args == nil {
-				logger.Log().Warnf(
-					"executable %s has no arguments defined, skipping argument processing",
-					exec.Ref().String(),
-				)
-			} else {
-				a, err := argUtils.BuildArgsEnvMap(execEnv.Args, args, ee)
-				if err != nil {
-					logger.Log().Error(err, "unable to process arguments")
-				}
-				maps.Copy(inputEnv, a)
+		execEnv := exec.Env()
+		if execEnv == nil || execEnv.Args == nil {
+			logger.Log().Warnf(
+				"executable %s has no arguments defined, skipping argument processing",
+				exec.Ref().String(),
+			)
+		} else {
+			a, err := argUtils.BuildArgsEnvMap(execEnv.Args, args, ee)
+			if err != nil {
+				logger.Log().Error(err, "unable to process arguments")
 			}
-		}
-		if exec.Exec != nil {
-			exec.Exec.SetLogFields(map[string]interface{}{
-				"stage": stage,
-				"step":  i + 1,
-			})
-		}
-		if err := runner.Exec(ctx, exec, engine.NewExecEngine(), inputEnv); err != nil {
-			return errors.Wrap(err, fmt.Sprintf("unable to execute %s executable %d", stage, i))
+			maps.Copy(inputEnv, a)
 		}
 	}
-	return nil
+	if exec.Exec != nil {
+		exec.Exec.SetLogFields(map[string]interface{}{
+			"stage": stage,
+			"step":  i + 1,
+		})
+	}
+	if err := runner.Exec(ctx, exec, engine.NewExecEngine(), inputEnv); err != nil {
+		return false, errors.Wrap(err, fmt.Sprintf("unable to execute %s executable %d", stage, i))
+	}
+	return false, nil
+}
+
+// stepID returns the identifier of a template executable that is used in the generation summary.
+func stepID(stage string, i int, e executable.TemplateRefConfig) string {
+	desc := string(e.Ref)
+	if desc == "" {
+		desc, _, _ = strings.Cut(strings.TrimSpace(e.Cmd), "\n")
+	}
+	return fmt.Sprintf("%s %d (%s)", stage, i+1, desc)
 }
 
 func parseSourcePath(
@@ -223,8 +377,9 @@ func parseDestinationPath(
 func templateToFlowfile(
 	t *executable.Template,
 	templateData expressionData,
+	partials map[string]string,
 ) (*executable.FlowFile, error) {
-	buf, err := processAsGoTemplate(t.Name(), t.Template, templateData)
+	buf, err := processAsGoTemplate(t.Name(), t.Template, templateData, partials)
 	if err != nil {
 		return nil, errors.Wrap(err, fmt.Sprintf("flowfile template %s", t.Name()))
 	}
@@ -237,8 +392,13 @@ func templateToFlowfile(
 	return flowfile, nil
 }
 
-func processAsGoTemplate(fileName, txt string, data expressionData) (*bytes.Buffer, error) {
+func processAsGoTemplate(fileName, txt string, data expressionData, partials ...map[string]string) (*bytes.Buffer, error) {
 	tmpl := expr.NewTemplate(fileName, data)
+	for _, p := range partials {
+		for name, partial := range p {
+			tmpl.AddPartial(name, partial)
+		}
+	}
 	if err := tmpl.Parse(txt); err != nil {
 		return nil, errors.Wrap(err, fmt.Sprintf("unable to parse %s template", fileName))
 	}
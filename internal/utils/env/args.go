@@ -19,13 +19,13 @@ func BuildArgsEnvMap(
 	return argsToEnvMap(al), nil
 }
 
-func parseArgs(args []string) (flagArgs map[string]string, posArgs []string) {
-	flagArgs = make(map[string]string)
+func parseArgs(args []string) (flagArgs map[string][]string, posArgs []string) {
+	flagArgs = make(map[string][]string)
 	posArgs = make([]string, 0)
 	for i := 0; i < len(args); i++ {
 		split := strings.Split(args[i], "=")
 		if len(split) >= 2 {
-			flagArgs[split[0]] = strings.Join(split[1:], "=")
+			flagArgs[split[0]] = append(flagArgs[split[0]], strings.Join(split[1:], "="))
 			continue
 		}
 		posArgs = append(posArgs, args[i])
@@ -59,7 +59,7 @@ func resolveArgValues(
 
 func setArgValues(
 	args executable.ArgumentList,
-	flagArgs map[string]string,
+	flagArgs map[string][]string,
 	posArgs []string,
 	env map[string]string,
 ) error {
@@ -74,8 +74,9 @@ func setArgValues(
 		}
 
 		if arg.Flag != "" {
-			if val, ok := flagArgs[arg.Flag]; ok {
-				arg.Set(val)
+			if vals, ok := flagArgs[arg.Flag]; ok {
+				// list arguments can be repeated, all other types use the last value
+				arg.SetValues(vals...)
 				args[i] = arg
 			}
 		} else if arg.Pos != nil && *arg.Pos != 0 {
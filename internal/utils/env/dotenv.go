@@ -0,0 +1,147 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/flowexec/flow/types/executable"
+)
+
+var dotEnvKeyRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_.]*$`)
+
+type dotEnvValue struct {
+	key, value string
+	// literal values are single-quoted and are never interpolated
+	literal bool
+}
+
+// LoadEnvFiles reads the env files in order and returns the merged variables. Later files override earlier ones.
+// Values are only interpolated for files with `interpolate` set; references are resolved against the variables
+// loaded before them, then the base map and finally the current process environment.
+func LoadEnvFiles(files executable.EnvFileList, base map[string]string) (map[string]string, error) {
+	envMap := make(map[string]string)
+	lookup := func(key string) string {
+		if val, found := envMap[key]; found {
+			return val
+		}
+		if val, found := base[key]; found {
+			return val
+		}
+		return os.Getenv(key)
+	}
+
+	for _, file := range files {
+		path := file.ExpandPath(base)
+		f, err := os.Open(path)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) && file.Optional {
+				continue
+			}
+			return nil, fmt.Errorf("unable to open env file %s: %w", file.Path, err)
+		}
+		values, err := parseDotEnv(f)
+		_ = f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse env file %s: %w", file.Path, err)
+		}
+		for _, v := range values {
+			if file.Interpolate && !v.literal {
+				envMap[v.key] = os.Expand(v.value, lookup)
+			} else {
+				envMap[v.key] = v.value
+			}
+		}
+	}
+	return envMap, nil
+}
+
+// parseDotEnv supports `KEY=VALUE` lines with an optional `export` prefix, `#` comments, single-quoted literal
+// values and double-quoted values with escape sequences that may span multiple lines.
+func parseDotEnv(r io.Reader) ([]dotEnvValue, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+
+	var values []dotEnvValue
+	for i := 0; i < len(lines); i++ {
+		lineNum := i + 1
+		line := strings.TrimSpace(lines[i])
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, rawVal, found := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !found {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", lineNum)
+		}
+		if !dotEnvKeyRegex.MatchString(key) {
+			return nil, fmt.Errorf("line %d: invalid key %q", lineNum, key)
+		}
+		rawVal = strings.TrimSpace(rawVal)
+
+		switch {
+		case strings.HasPrefix(rawVal, "'"):
+			end := strings.Index(rawVal[1:], "'")
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated single-quoted value", lineNum)
+			}
+			values = append(values, dotEnvValue{key: key, value: rawVal[1 : end+1], literal: true})
+		case strings.HasPrefix(rawVal, `"`):
+			val, consumed, err := parseDoubleQuoted(rawVal[1:], lines[i+1:])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNum, err)
+			}
+			i += consumed
+			values = append(values, dotEnvValue{key: key, value: val})
+		default:
+			if idx := strings.Index(rawVal, " #"); idx >= 0 {
+				rawVal = strings.TrimSpace(rawVal[:idx])
+			}
+			values = append(values, dotEnvValue{key: key, value: rawVal})
+		}
+	}
+	return values, nil
+}
+
+// parseDoubleQuoted reads a double-quoted value that starts in s and may continue into the following lines.
+// It returns the unescaped value and the number of additional lines consumed.
+func parseDoubleQuoted(s string, rest []string) (string, int, error) {
+	var b strings.Builder
+	consumed := 0
+	for {
+		for j := 0; j < len(s); j++ {
+			switch c := s[j]; {
+			case c == '\\' && j+1 < len(s):
+				j++
+				switch s[j] {
+				case 'n':
+					b.WriteByte('\n')
+				case 't':
+					b.WriteByte('\t')
+				case 'r':
+					b.WriteByte('\r')
+				default:
+					b.WriteByte(s[j])
+				}
+			case c == '"':
+				return b.String(), consumed, nil
+			default:
+				b.WriteByte(c)
+			}
+		}
+		if consumed >= len(rest) {
+			return "", consumed, errors.New("unterminated double-quoted value")
+		}
+		b.WriteByte('\n')
+		s = rest[consumed]
+		consumed++
+	}
+}
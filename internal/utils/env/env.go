@@ -2,6 +2,7 @@ package env
 
 import (
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
 	"strings"
@@ -20,6 +21,16 @@ func SetEnv(
 	promptedEnv map[string]string,
 ) error {
 	var errs []error
+	fileEnv, err := BuildEnvFilesEnvMap(exec.EnvFiles, promptedEnv, nil)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	for key, val := range fileEnv {
+		if err := os.Setenv(key, val); err != nil {
+			errs = append(errs, fmt.Errorf("failed to set env %s: %w", key, err))
+		}
+	}
+
 	for _, param := range exec.Params {
 		if param.OutputFile != "" {
 			// CreateTempEnvFiles will handle outputFile parameters
@@ -108,7 +119,15 @@ func CreateTempEnvFiles(
 	return cb, nil
 }
 
-// BuildEnvMap constructs a map of environment variables based on the executable parameters and arguments.
+// BuildEnvMap constructs a map of environment variables based on the executable env files, parameters and arguments.
+// Values are applied with the following precedence, from lowest to highest:
+//   - the default flow environment (defaultEnv)
+//   - env files, in the order they are defined
+//   - parameters, where the executable's own params override the flow file's params and env, which override the
+//     workspace's params and env (see executable.Executable.Env)
+//   - arguments
+//
+// Values from inputEnv (e.g. `--param` overrides) take precedence over env files and parameters of the same key.
 func BuildEnvMap(
 	currentVault string,
 	exec *executable.ExecutableEnvironment,
@@ -125,6 +144,20 @@ func BuildEnvMap(
 		}
 	}
 
+	fileEnv, err := BuildEnvFilesEnvMap(exec.EnvFiles, inputEnv, envMap)
+	if err != nil {
+		return nil, err
+	}
+
+	// args only fall back to values that were explicitly provided so env file values are only visible to them
+	// when they were overridden by the input env
+	resolvedEnv := make(map[string]string)
+	for k, v := range fileEnv {
+		if _, ok := inputEnv[k]; ok {
+			resolvedEnv[k] = v
+		}
+	}
+
 	for _, param := range exec.Params {
 		if param.OutputFile != "" && param.EnvKey == "" {
 			continue
@@ -135,16 +168,19 @@ func BuildEnvMap(
 			errs = append(errs, err)
 			continue
 		}
-		envMap[param.EnvKey] = val
+		resolvedEnv[param.EnvKey] = val
 	}
 
-	argEnvMap, err := BuildArgsEnvMap(exec.Args, args, envMap)
+	argLookupEnv := maps.Clone(envMap)
+	maps.Copy(argLookupEnv, resolvedEnv)
+	argEnvMap, err := BuildArgsEnvMap(exec.Args, args, argLookupEnv)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build args env map: %w", err)
 	}
-	for key, val := range argEnvMap {
-		envMap[key] = val
-	}
+
+	maps.Copy(envMap, fileEnv)
+	maps.Copy(envMap, resolvedEnv)
+	maps.Copy(envMap, argEnvMap)
 
 	if len(errs) > 0 {
 		return nil, fmt.Errorf("failed to get values for parameters: %v", errs)
@@ -152,6 +188,28 @@ func BuildEnvMap(
 	return envMap, nil
 }
 
+// BuildEnvFilesEnvMap loads the env files into a map of environment variables. Keys that are also set in inputEnv
+// use the input value instead.
+func BuildEnvFilesEnvMap(
+	files executable.EnvFileList,
+	inputEnv map[string]string,
+	baseEnv map[string]string,
+) (map[string]string, error) {
+	if len(files) == 0 {
+		return nil, nil
+	}
+	fileEnv, err := LoadEnvFiles(files, baseEnv)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load env files: %w", err)
+	}
+	for k := range fileEnv {
+		if val, ok := inputEnv[k]; ok {
+			fileEnv[k] = val
+		}
+	}
+	return fileEnv, nil
+}
+
 // EnvMapToEnvList converts a map of environment variables to a slice of strings in the format "KEY=VALUE".
 func EnvMapToEnvList(envMap map[string]string) []string {
 	envList := make([]string, 0, len(envMap))
@@ -178,6 +236,9 @@ func DefaultEnv(ctx *context.Context, executable *executable.Executable) map[str
 	envMap["FLOW_RUNNER"] = "true"
 	envMap["FLOW_CURRENT_WORKSPACE"] = ctx.CurrentWorkspace.AssignedName()
 	envMap["FLOW_CURRENT_NAMESPACE"] = ctx.Config.CurrentNamespace
+	if ctx.CurrentProfile != "" {
+		envMap["FLOW_CURRENT_PROFILE"] = ctx.CurrentProfile
+	}
 	if ctx.ProcessTmpDir != "" {
 		envMap["FLOW_TMP_DIRECTORY"] = ctx.ProcessTmpDir
 	}
@@ -180,6 +180,44 @@ var _ = Describe("Env", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(envMap).To(Equal(map[string]string{"flag1": "value1=value2"}))
 		})
+
+		It("should join repeated list flag arguments", func() {
+			args := executable.ArgumentList{
+				{EnvKey: "TAGS", Flag: "tag", Type: executable.ArgumentTypeList},
+				{EnvKey: "ENVS", Flag: "env", Type: executable.ArgumentTypeList, Separator: " "},
+			}
+			inputVals := []string{"tag=a", "env=dev", "tag=b", "env=prod"}
+			envMap, err := env.BuildArgsEnvMap(args, inputVals, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(envMap).To(Equal(map[string]string{"TAGS": "a,b", "ENVS": "dev prod"}))
+		})
+
+		It("should return an error for invalid typed values", func() {
+			args := executable.ArgumentList{
+				{EnvKey: "TARGET", Flag: "target", Type: executable.ArgumentTypeEnum, Options: []string{"dev", "prod"}},
+			}
+			_, err := env.BuildArgsEnvMap(args, []string{"target=staging"}, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("must be one of [dev, prod]"))
+		})
+
+		It("should use the default values when the executable is run again without arguments", func() {
+			e := &executable.Executable{
+				Verb: "deploy",
+				Name: "app",
+				Exec: &executable.ExecExecutableType{
+					Cmd:  "echo $TARGET",
+					Args: executable.ArgumentList{{EnvKey: "TARGET", Flag: "target", Default: "dev"}},
+				},
+			}
+			envMap, err := env.BuildArgsEnvMap(e.RunCopy().Env().Args, []string{"target=prod"}, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(envMap).To(Equal(map[string]string{"TARGET": "prod"}))
+
+			envMap, err = env.BuildArgsEnvMap(e.RunCopy().Env().Args, nil, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(envMap).To(Equal(map[string]string{"TARGET": "dev"}))
+		})
 	})
 
 	Describe("ResolveParameterValue", func() {
@@ -275,6 +313,94 @@ var _ = Describe("Env", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(envMap).To(Equal(map[string]string{"TEST_KEY": "test", "TEST_KEY_2": "test2", "TEST_KEY_3": "test3"}))
 		})
+
+		It("should apply env files with the lowest precedence after the default env", func() {
+			path := filepath.Join(GinkgoTB().TempDir(), ".env")
+			Expect(os.WriteFile(path, []byte("FILE_ONLY=file\nPARAM_KEY=file\nARG_KEY=file\nINPUT_KEY=file"), 0600)).
+				To(Succeed())
+			exec := &executable.ExecutableEnvironment{
+				EnvFiles: executable.EnvFileList{{Path: path}},
+				Params:   []executable.Parameter{{EnvKey: "PARAM_KEY", Text: "param"}},
+				Args:     []executable.Argument{{EnvKey: "ARG_KEY", Flag: "flag"}},
+			}
+			inputEnv := map[string]string{"INPUT_KEY": "input"}
+			defaultEnv := map[string]string{"FILE_ONLY": "default"}
+			envMap, err := env.BuildEnvMap("", exec, []string{"flag=arg"}, inputEnv, defaultEnv)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(envMap).To(Equal(map[string]string{
+				"FILE_ONLY": "file",
+				"PARAM_KEY": "param",
+				"ARG_KEY":   "arg",
+				"INPUT_KEY": "input",
+			}))
+		})
+	})
+
+	Describe("LoadEnvFiles", func() {
+		var tmpDir string
+
+		BeforeEach(func() {
+			tmpDir = GinkgoTB().TempDir()
+		})
+
+		writeEnvFile := func(name, content string) string {
+			path := filepath.Join(tmpDir, name)
+			Expect(os.WriteFile(path, []byte(content), 0600)).To(Succeed())
+			return path
+		}
+
+		It("should parse dotenv files", func() {
+			path := writeEnvFile(".env", `# comment
+export KEY1=value1
+KEY2 = value2 # inline comment
+KEY3='single $KEY1'
+KEY4="double\tquoted"
+KEY5="multi
+line"
+`)
+			envMap, err := env.LoadEnvFiles(executable.EnvFileList{{Path: path}}, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(envMap).To(Equal(map[string]string{
+				"KEY1": "value1",
+				"KEY2": "value2",
+				"KEY3": "single $KEY1",
+				"KEY4": "double\tquoted",
+				"KEY5": "multi\nline",
+			}))
+		})
+
+		It("should override values with later files", func() {
+			first := writeEnvFile(".env", "KEY1=first\nKEY2=first")
+			second := writeEnvFile(".env.local", "KEY2=second")
+			envMap, err := env.LoadEnvFiles(executable.EnvFileList{{Path: first}, {Path: second}}, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(envMap).To(Equal(map[string]string{"KEY1": "first", "KEY2": "second"}))
+		})
+
+		It("should interpolate values when enabled", func() {
+			path := writeEnvFile(".env", "HOST=localhost\nURL=http://${HOST}:${PORT}\nRAW='$HOST'")
+			files := executable.EnvFileList{{Path: path, Interpolate: true}}
+			envMap, err := env.LoadEnvFiles(files, map[string]string{"PORT": "8080"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(envMap["URL"]).To(Equal("http://localhost:8080"))
+			Expect(envMap["RAW"]).To(Equal("$HOST"))
+		})
+
+		It("should only skip missing files that are optional", func() {
+			missing := filepath.Join(tmpDir, "missing.env")
+			envMap, err := env.LoadEnvFiles(executable.EnvFileList{{Path: missing, Optional: true}}, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(envMap).To(BeEmpty())
+
+			_, err = env.LoadEnvFiles(executable.EnvFileList{{Path: missing}}, nil)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should return an error for malformed lines", func() {
+			path := writeEnvFile(".env", "KEY1=value\nnot a variable")
+			_, err := env.LoadEnvFiles(executable.EnvFileList{{Path: path}}, nil)
+			Expect(err).To(MatchError(ContainSubstring("line 2")))
+		})
 	})
 
 	Describe("DefaultEnv", func() {
@@ -3,6 +3,7 @@ package env
 import (
 	"errors"
 
+	"github.com/flowexec/flow/internal/services/redact"
 	"github.com/flowexec/flow/internal/vault"
 	vaultV2 "github.com/flowexec/flow/internal/vault/v2"
 	"github.com/flowexec/flow/types/executable"
@@ -30,7 +31,12 @@ func ResolveParameterValue(
 		}
 		return val, nil
 	case param.SecretRef != "":
-		return resolveSecretValue(currentVault, param.SecretRef)
+		val, err := resolveSecretValue(currentVault, param.SecretRef)
+		if err != nil {
+			return "", err
+		}
+		redact.Register(val)
+		return val, nil
 	case param.OutputFile != "":
 		return "", errors.New("outputFile parameter value should be resolved using ResolveParameterFileValue")
 	default:
@@ -8,14 +8,18 @@ import (
 	"github.com/flowexec/flow/types/executable"
 )
 
+// ExecutableForRef returns a copy of the referenced executable. The executable is copied so that the fields that
+// are set for a run, like the directory and logger of a step, don't change the cached executable or the other steps
+// that reference it.
 func ExecutableForRef(ctx *context.Context, ref executable.Ref) (*executable.Executable, error) {
 	executableRef := context.ExpandRef(ctx, ref)
-	exec, err := ctx.ExecutableCache.GetExecutableByRef(executableRef)
+	cached, err := ctx.ExecutableCache.GetExecutableByRef(executableRef)
 	if err != nil {
 		return nil, err
-	} else if exec == nil {
+	} else if cached == nil {
 		return nil, fmt.Errorf("executable missing ref='%s'", ref)
 	}
+	exec := cached.RunCopy()
 
 	if exec.Exec != nil {
 		fields := map[string]interface{}{
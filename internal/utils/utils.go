@@ -22,6 +22,15 @@ import (
 // - relative path -> fallbackPath (directory portion) + path
 // - ${envVar} -> expanded to the value from env map.
 func ExpandPath(path, fallbackDir string, env map[string]string) string {
+	targetPath := expandPath(path, fallbackDir, env)
+	if err := validateSecurePath(targetPath); err != nil {
+		logger.Log().Fatalx("path failed security validation", "path", targetPath, "err", err)
+		return "" // Shouldn't get here with fatal logger, but just in case
+	}
+	return targetPath
+}
+
+func expandPath(path, fallbackDir string, env map[string]string) string {
 	var targetPath string
 	switch {
 	case path == "":
@@ -55,12 +64,6 @@ func ExpandPath(path, fallbackDir string, env map[string]string) string {
 		}
 		return val
 	})
-
-	if err := validateSecurePath(targetPath); err != nil {
-		logger.Log().Fatalx("path failed security validation", "path", targetPath, "err", err)
-		return "" // Shouldn't get here with fatal logger, but just in case
-	}
-
 	return filepath.Clean(targetPath)
 }
 
@@ -85,6 +88,15 @@ func ExpandDirectory(dir, wsPath, execPath string, env map[string]string) string
 	return expandedPath
 }
 
+// ExpandWorkspacePath expands the path with the rules of ExpandDirectory and without the security validation of
+// ExpandPath. It is used for paths that are given as input, which may refer to any file.
+func ExpandWorkspacePath(path, wsPath, execPath string, env map[string]string) string {
+	if wsPath != "" && strings.HasPrefix(path, "//") {
+		return strings.Replace(path, "//", wsPath+"/", 1)
+	}
+	return expandPath(path, filepath.Dir(execPath), env)
+}
+
 func isHiddenDir(path string) bool {
 	// Regex to match hidden directories like .config
 	// This assumes that filenames starting with a dot (.) are hidden directories - this may not be universally true
@@ -71,6 +71,20 @@ var _ = Describe("Utils", func() {
 		})
 	})
 
+	Describe("ExpandWorkspacePath", func() {
+		DescribeTable("with different inputs",
+			func(path string, expected string) {
+				Expect(utils.ExpandWorkspacePath(path, wsDir, execPath, nil)).To(Equal(expected))
+			},
+			Entry("empty path", "", execDir),
+			Entry("path starts with //", "//dir/file.txt", filepath.Join(wsDir, "dir", "file.txt")),
+			Entry("path starts with ./", "./file.txt", filepath.Join(testWorkingDir, "file.txt")),
+			Entry("default case", "dir/file.txt", filepath.Join(execDir, "dir", "file.txt")),
+			Entry("system path", "/etc/hosts", "/etc/hosts"),
+			Entry("parent dir", "../file.txt", filepath.Join(filepath.Dir(execDir), "file.txt")),
+		)
+	})
+
 	Describe("PathFromWd", func() {
 		When("path is a subdirectory", func() {
 			It("returns the relative path", func() {
@@ -28,13 +28,13 @@ var _ = Describe("browse TUI", func() {
 		container *tuikit.Container
 
 		runChan chan string
-		runFunc func(ref string) error
+		runFunc execIO.RunFunc
 	)
 
 	BeforeEach(func() {
 		ctx = utils.NewContext(stdCtx.Background(), GinkgoTB())
 		runChan = make(chan string, 1)
-		runFunc = func(ref string) error {
+		runFunc = func(ref string, _, _ []string) error {
 			runChan <- ref
 			return nil
 		}
@@ -4,11 +4,14 @@ package tests_test
 
 import (
 	stdCtx "context"
+	"os"
+	"path/filepath"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	"github.com/flowexec/flow/tests/utils"
+	"github.com/flowexec/flow/types/workspace"
 )
 
 var _ = Describe("exec e2e", func() {
@@ -51,6 +54,65 @@ var _ = Describe("exec e2e", func() {
 		})
 	})
 
+	When("input is disabled", func() {
+		It("should use the default value of prompt parameters", func() {
+			runner := utils.NewE2ECommandRunner()
+			stdOut := ctx.StdOut()
+			Expect(runner.Run(
+				ctx.Context, "exec", "examples:with-params", "--no-input", "--param", "PARAM2=value2",
+			)).To(Succeed())
+			out, _ := readFileContent(stdOut)
+			Expect(out).To(ContainSubstring("value1"))
+			Expect(out).To(ContainSubstring("default3"))
+		})
+	})
+
+	When("a profile is selected", func() {
+		It("should run the executable with the profile values", func() {
+			ctx.CurrentWorkspace.Profiles = workspace.WorkspaceProfiles{
+				"dev": {Env: map[string]string{"PARAM1": "profile-value"}},
+			}
+			runner := utils.NewE2ECommandRunner()
+			stdOut := ctx.StdOut()
+			Expect(runner.Run(
+				ctx.Context, "exec", "examples:with-params", "--no-input", "--profile", "dev",
+				"--param", "PARAM2=value2",
+			)).To(Succeed())
+			out, _ := readFileContent(stdOut)
+			Expect(out).To(ContainSubstring("profile-value"))
+			Expect(out).To(ContainSubstring("value2"))
+			Expect(out).To(ContainSubstring("default3"))
+		})
+	})
+
+	Describe("shell completion", func() {
+		It("should complete the flag arguments of the executable", func() {
+			runner := utils.NewE2ECommandRunner()
+			stdOut := ctx.StdOut()
+			Expect(runner.Run(ctx.Context, "__complete", "exec", "examples:with-args", "")).To(Succeed())
+			out, _ := readFileContent(stdOut)
+			Expect(out).To(ContainSubstring("x="))
+		})
+
+		It("should complete the executable of the verb that is completed", func() {
+			runner := utils.NewE2ECommandRunner()
+			stdOut := ctx.StdOut()
+			Expect(runner.Run(ctx.Context, "__complete", "build", "examples:with-args", "")).To(Succeed())
+			out, _ := readFileContent(stdOut)
+			Expect(out).NotTo(ContainSubstring("x="))
+		})
+
+		It("should complete the parameter keys of the executable", func() {
+			runner := utils.NewE2ECommandRunner()
+			stdOut := ctx.StdOut()
+			Expect(runner.Run(ctx.Context, "__complete", "exec", "examples:with-params", "--param", "")).To(Succeed())
+			out, _ := readFileContent(stdOut)
+			Expect(out).To(ContainSubstring("PARAM1="))
+			Expect(out).To(ContainSubstring("PARAM2="))
+			Expect(out).To(ContainSubstring("PARAM3="))
+		})
+	})
+
 	Describe("file parameter and argument output files", func() {
 		It("should create temporary files for file arguments", func() {
 			runner := utils.NewE2ECommandRunner()
@@ -71,4 +133,23 @@ var _ = Describe("exec e2e", func() {
 			Expect(out).To(ContainSubstring("flow completed"))
 		})
 	})
+
+	Describe("result reports", func() {
+		It("should write the reports of the execution", func() {
+			runner := utils.NewE2ECommandRunner()
+			dir := GinkgoT().TempDir()
+			jsonPath, junitPath := filepath.Join(dir, "results.json"), filepath.Join(dir, "junit", "results.xml")
+			Expect(runner.Run(
+				ctx.Context, "exec", "examples:simple-print", "--report", "json="+jsonPath, "--report", "junit="+junitPath,
+			)).To(Succeed())
+
+			jsonReport, err := os.ReadFile(filepath.Clean(jsonPath))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(jsonReport)).To(ContainSubstring(`"status": "succeeded"`))
+			junitReport, err := os.ReadFile(filepath.Clean(junitPath))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(junitReport)).To(ContainSubstring(`<testsuites name="run default/examples:simple-print"`))
+			Expect(string(junitReport)).To(ContainSubstring(`failures="0"`))
+		})
+	})
 })
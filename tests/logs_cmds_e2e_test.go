@@ -40,6 +40,16 @@ var _ = Describe("logs e2e", Ordered, func() {
 		})
 	})
 
+	When("filtering logs (flow logs --status)", func() {
+		It("should display the matching logs in yaml format", func() {
+			stdOut := ctx.StdOut()
+			Expect(run.Run(ctx.Context, "logs", "--status", "success", "--since", "24h", "-o", "yaml")).To(Succeed())
+			out, err := readFileContent(stdOut)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(ContainSubstring("logs:"))
+		})
+	})
+
 	When("viewing last log entry (flow logs --last)", func() {
 		It("should display the last log entry", func() {
 			// TODO: test that log archiving works
@@ -167,4 +167,111 @@ var _ = Describe("flowfile template commands e2e", Ordered, func() {
 			Expect(out).To(ContainSubstring(fmt.Sprintf("Template '%s' rendered successfully", name)))
 		})
 	})
+
+	When("Rendering a template without input (flow template generate --no-input)", func() {
+		It("should use the provided values and defaults", func() {
+			name := "no-input"
+			outputDir := filepath.Join(ctx.CurrentWorkspace.Location(), "output")
+			Expect(run.Run(
+				ctx.Context, "template", "generate", name, "-t", template.Name(), "-o", outputDir,
+				"--no-input", "--set", "Msg=from cli",
+			)).To(Succeed())
+			data, err := os.ReadFile(filepath.Join(outputDir, name+executable.FlowFileExt))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(ContainSubstring("echo 'from cli'"))
+		})
+
+		It("should not overwrite existing files when skip is set", func() {
+			name := "no-input"
+			outputDir := filepath.Join(ctx.CurrentWorkspace.Location(), "output")
+			Expect(run.Run(
+				ctx.Context, "template", "generate", name, "-t", template.Name(), "-o", outputDir,
+				"--no-input", "--set", "Msg=changed", "--skip",
+			)).To(Succeed())
+			data, err := os.ReadFile(filepath.Join(outputDir, name+executable.FlowFileExt))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(ContainSubstring("echo 'from cli'"))
+		})
+	})
+
+	When("Rendering a template that extends another (flow template generate)", func() {
+		It("should inherit the parent template and include partials", func() {
+			name := "child"
+			outputDir := filepath.Join(ctx.CurrentWorkspace.Location(), "child")
+			childDir, err := os.MkdirTemp("", "flowfile-template-child")
+			Expect(err).NotTo(HaveOccurred())
+			childTmpl := fmt.Sprintf(`extends: %s
+partials:
+  greeting: greeting.txt
+artifacts:
+  - srcName: notes.txt
+    asTemplate: true
+`, template.Name())
+			Expect(os.WriteFile(filepath.Join(childDir, "child.flow.tmpl"), []byte(childTmpl), 0644)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(childDir, "greeting.txt"), []byte("hello {{ name }}"), 0644)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(childDir, "notes.txt"), []byte(`{{ template "greeting" }}`), 0644)).To(Succeed())
+
+			Expect(run.Run(
+				ctx.Context, "template", "generate", name, "-f", filepath.Join(childDir, "child.flow.tmpl"),
+				"-o", outputDir, "--no-input",
+			)).To(Succeed())
+			data, err := os.ReadFile(filepath.Join(outputDir, "notes.txt"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal("hello child.flow"))
+			Expect(filepath.Join(outputDir, "artifact1")).To(BeAnExistingFile())
+			Expect(filepath.Join(outputDir, name+executable.FlowFileExt)).To(BeAnExistingFile())
+		})
+	})
+
+	When("Rendering a template with conditional and failing post-run executables (flow template generate)", func() {
+		It("should only skip the executables with a false condition", func() {
+			name := "steps"
+			outputDir := filepath.Join(ctx.CurrentWorkspace.Location(), "steps")
+			tmplDir, err := os.MkdirTemp("", "flowfile-template-steps")
+			Expect(err).NotTo(HaveOccurred())
+			stepsTmpl := `postRun:
+  - cmd: touch {{ directory }}/skipped
+    if: "false"
+  - cmd: exit 1
+    continueOnError: true
+  - cmd: touch {{ directory }}/marker
+template: |
+  executables: []
+`
+			Expect(os.WriteFile(filepath.Join(tmplDir, "steps.flow.tmpl"), []byte(stepsTmpl), 0644)).To(Succeed())
+
+			stdOut := ctx.StdOut()
+			Expect(run.Run(
+				ctx.Context, "template", "generate", name, "-f", filepath.Join(tmplDir, "steps.flow.tmpl"),
+				"-o", outputDir, "--no-input", "--transactional",
+			)).To(Succeed())
+			Expect(filepath.Join(outputDir, "marker")).To(BeAnExistingFile())
+			Expect(filepath.Join(outputDir, "skipped")).ToNot(BeAnExistingFile())
+			out, err := readFileContent(stdOut)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(ContainSubstring("post-run 1 (touch {{ directory }}/skipped): skipped"))
+			Expect(out).To(ContainSubstring("post-run 2 (exit 1): failed"))
+			Expect(out).To(ContainSubstring("post-run 3 (touch {{ directory }}/marker): succeeded"))
+		})
+	})
+
+	When("Previewing a template (flow template generate --preview)", func() {
+		It("should show the changes without writing files", func() {
+			name := "no-input"
+			outputDir := filepath.Join(ctx.CurrentWorkspace.Location(), "output")
+			stdOut := ctx.StdOut()
+			Expect(run.Run(
+				ctx.Context, "template", "generate", name, "-t", template.Name(), "-o", outputDir,
+				"--no-input", "--set", "Msg=previewed", "--preview",
+			)).To(Succeed())
+			out, err := readFileContent(stdOut)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(ContainSubstring("artifact2-renamed (unchanged)"))
+			Expect(out).To(ContainSubstring(name + executable.FlowFileExt + " (modify)"))
+			Expect(out).To(ContainSubstring("+        cmd: echo 'previewed'"))
+			data, err := os.ReadFile(filepath.Join(outputDir, name+executable.FlowFileExt))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(ContainSubstring("echo 'from cli'"))
+		})
+	})
 })
@@ -175,7 +175,7 @@ func ExecWithParams(opts ...Option) *executable.Executable {
 	params := executable.ParameterList{
 		{EnvKey: "PARAM1", Text: "value1"},
 		{EnvKey: "PARAM2", SecretRef: "flow-example-secret"},
-		{EnvKey: "PARAM3", Prompt: "Enter a value"},
+		{EnvKey: "PARAM3", Prompt: "Enter a value", Default: "default3"},
 	}
 	var paramCmds []string
 	for _, param := range params {
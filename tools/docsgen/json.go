@@ -16,19 +16,22 @@ const (
 )
 
 func generateJSONSchemas() {
-	sm := schema.RegisteredSchemaMap()
-	for fn, s := range sm {
+	for _, fn := range schema.SchemaFilesForDocs {
 		if slices.Contains(TopLevelPages, fn.Title()) {
+			// each page is merged from freshly loaded schemas so that the other pages don't change its definitions
+			sm := schema.RegisteredSchemaMap()
+			s := sm[fn]
 			updateFileID(s, fn)
-			for key, value := range s.Properties {
+			for _, key := range schema.SortedKeys(s.Properties) {
+				value := s.Properties[key]
 				if !value.Ext.IsExported() {
 					delete(s.Properties, key)
 					continue
 				}
 				schema.MergeSchemas(s, value, fn, sm)
 			}
-			for _, value := range s.Definitions {
-				schema.MergeSchemas(s, value, fn, sm)
+			for _, key := range schema.SortedKeys(s.Definitions) {
+				schema.MergeSchemas(s, s.Definitions[key], fn, sm)
 			}
 
 			s.Title = fn.Title()
@@ -9,8 +9,6 @@ import (
 	"strings"
 	"text/template"
 
-	"golang.org/x/exp/maps"
-
 	"github.com/flowexec/flow/tools/docsgen/schema"
 )
 
@@ -43,9 +41,11 @@ type topLevelPage struct {
 func generateMarkdownDocs() {
 	dir := filepath.Join(rootDir(), DocsDir, mdDir)
 	typeTemplate := templateFileData("type.md.tmpl")
-	sm := schema.RegisteredSchemaMap()
-	for fn, s := range sm {
+	for _, fn := range schema.SchemaFilesForDocs {
 		if slices.Contains(TopLevelPages, fn.Title()) {
+			// each page is merged from freshly loaded schemas so that the other pages don't change its definitions
+			sm := schema.RegisteredSchemaMap()
+			s := sm[fn]
 			page := newTopLevelPage(fn, s, sm)
 			if page == nil {
 				continue
@@ -79,20 +79,19 @@ func newTopLevelPage(f schema.FileName, s *schema.JSONSchema, sm map[schema.File
 	if s.Type != "object" {
 		return nil
 	}
-	pOrder := maps.Keys(s.Properties)
-	slices.Sort(pOrder)
-	dOrder := maps.Keys(s.Definitions)
-	slices.Sort(dOrder)
+	pOrder := schema.SortedKeys(s.Properties)
+	dOrder := schema.SortedKeys(s.Definitions)
 
-	for key, value := range s.Properties {
+	for _, key := range pOrder {
+		value := s.Properties[key]
 		if !value.Ext.IsExported() {
 			delete(s.Properties, key)
 			continue
 		}
 		schema.MergeSchemas(s, value, f, sm)
 	}
-	for _, value := range s.Definitions {
-		schema.MergeSchemas(s, value, f, sm)
+	for _, key := range dOrder {
+		schema.MergeSchemas(s, s.Definitions[key], f, sm)
 	}
 
 	return &topLevelPage{
@@ -1,7 +1,10 @@
 package schema
 
 import (
+	"slices"
 	"strings"
+
+	"golang.org/x/exp/maps"
 )
 
 type FieldKey string
@@ -45,18 +48,54 @@ func (e SchemaExt) IsExported() bool {
 	return e.Identifier == "" || (e.Identifier[0] >= 'A' && e.Identifier[0] <= 'Z')
 }
 
+// DeepCopy returns a copy of the schema that doesn't share any of its nested schemas, so that merging it into
+// another schema doesn't change the registered schema that it was copied from.
+func (s *JSONSchema) DeepCopy() *JSONSchema {
+	if s == nil {
+		return nil
+	}
+	c := *s
+	c.Required = slices.Clone(s.Required)
+	c.Enum = slices.Clone(s.Enum)
+	c.Definitions = copySchemaMap(s.Definitions)
+	c.Properties = copySchemaMap(s.Properties)
+	c.AdditionalProperties = s.AdditionalProperties.DeepCopy()
+	c.Items = s.Items.DeepCopy()
+	return &c
+}
+
+func copySchemaMap(m map[FieldKey]*JSONSchema) map[FieldKey]*JSONSchema {
+	if m == nil {
+		return nil
+	}
+	c := make(map[FieldKey]*JSONSchema, len(m))
+	for key, value := range m {
+		c[key] = value.DeepCopy()
+	}
+	return c
+}
+
+// SortedKeys returns the keys of the schema map in a stable order.
+func SortedKeys(m map[FieldKey]*JSONSchema) []FieldKey {
+	keys := maps.Keys(m)
+	slices.Sort(keys)
+	return keys
+}
+
 //nolint:all
 func MergeSchemas(dst, src *JSONSchema, dstFile FileName, schemaMap map[FileName]*JSONSchema) {
 	if src.Items != nil {
 		MergeSchemas(dst, src.Items, dstFile, schemaMap)
 	}
-	for _, value := range src.Definitions {
+	for _, key := range SortedKeys(src.Definitions) {
+		value := src.Definitions[key]
 		if value.Ref.IsRoot() {
 			continue
 		}
 		MergeSchemas(dst, value, dstFile, schemaMap)
 	}
-	for key, value := range src.Properties {
+	for _, key := range SortedKeys(src.Properties) {
+		value := src.Properties[key]
 		if !value.Ext.IsExported() {
 			delete(src.Properties, FieldKey(value.Ext.Identifier))
 			continue
@@ -72,7 +111,8 @@ func MergeSchemas(dst, src *JSONSchema, dstFile FileName, schemaMap map[FileName
 		if src.Items != nil {
 			MergeSchemas(dst, src.Items, dstFile, schemaMap)
 		}
-		for key, value := range src.Properties {
+		for _, key := range SortedKeys(src.Properties) {
+			value := src.Properties[key]
 			if !value.Ext.IsExported() {
 				delete(src.Properties, key)
 				continue
@@ -80,7 +120,8 @@ func MergeSchemas(dst, src *JSONSchema, dstFile FileName, schemaMap map[FileName
 			MergeSchemas(dst, value, dstFile, schemaMap)
 			src.Properties[key].Ref = convertToLocalSchemaRef(value.Ref, dstFile)
 		}
-		for i, value := range src.Definitions {
+		for _, i := range SortedKeys(src.Definitions) {
+			value := src.Definitions[i]
 			MergeSchemas(dst, value, dstFile, schemaMap)
 			src.Definitions[i].Ref = convertToLocalSchemaRef(value.Ref, dstFile)
 		}
@@ -96,7 +137,8 @@ func MergeSchemas(dst, src *JSONSchema, dstFile FileName, schemaMap map[FileName
 		}
 	case src.Ref.ExternalFile() == "":
 		// the ref is a local definition and exists in the destination schema
-		for key, value := range src.Properties {
+		for _, key := range SortedKeys(src.Properties) {
+			value := src.Properties[key]
 			if !value.Ext.IsExported() {
 				delete(src.Properties, key)
 				continue
@@ -104,26 +146,31 @@ func MergeSchemas(dst, src *JSONSchema, dstFile FileName, schemaMap map[FileName
 			MergeSchemas(dst, value, dstFile, schemaMap)
 		}
 	default:
-		// the ref is an external definition
-		for fn, s := range schemaMap {
+		// the ref is an external definition. The match is copied since its refs are rewritten for the destination
+		// schema and the registered schema can be merged into other schemas as well.
+		files := maps.Keys(schemaMap)
+		slices.Sort(files)
+		for _, fn := range files {
+			s := schemaMap[fn]
 			if src.Ref.ExternalFile().Title() == fn.Title() {
 				if FieldKey(fn.Title()) == src.Ref.Key() {
 					// root level reference
-					match = s
+					match = s.DeepCopy()
 					match.ID = ""
 				} else {
 					def, found := s.Definitions[src.Ref.Key()]
 					if !found {
 						continue
 					}
-					match = def
+					match = def.DeepCopy()
 				}
 				if match.Items != nil {
 					match.Items.Ref = expandLocalSchemaRef(match.Items.Ref, fn)
 					MergeSchemas(dst, match.Items, dstFile, schemaMap)
 					match.Items.Ref = convertToLocalSchemaRef(match.Items.Ref, dstFile)
 				}
-				for _, value := range match.Properties {
+				for _, key := range SortedKeys(match.Properties) {
+					value := match.Properties[key]
 					if !value.Ext.IsExported() {
 						continue
 					}
@@ -147,7 +194,8 @@ func MergeSchemas(dst, src *JSONSchema, dstFile FileName, schemaMap map[FileName
 		d.Definitions = nil
 		dst.Definitions[src.Ref.Key()] = &d
 	}
-	for key, value := range match.Properties {
+	for _, key := range SortedKeys(match.Properties) {
+		value := match.Properties[key]
 		if !value.Ext.IsExported() {
 			delete(match.Properties, key)
 			continue
@@ -157,8 +205,8 @@ func MergeSchemas(dst, src *JSONSchema, dstFile FileName, schemaMap map[FileName
 	if match.Items != nil {
 		MergeSchemas(dst, match.Items, dstFile, schemaMap)
 	}
-	for _, value := range match.Definitions {
-		MergeSchemas(dst, value, dstFile, schemaMap)
+	for _, key := range SortedKeys(match.Definitions) {
+		MergeSchemas(dst, match.Definitions[key], dstFile, schemaMap)
 	}
 }
 
@@ -39,6 +39,7 @@ var _ = Describe("MergeSchemas", func() {
 				Definitions: map[schema.FieldKey]*schema.JSONSchema{
 					"MyString": {Type: "string"},
 					"MyBool":   {Type: "boolean"},
+					"MyList":   {Type: "array", Items: &schema.JSONSchema{Ref: "#/definitions/MyBool"}},
 				},
 				Required: []string{"MyString"},
 			},
@@ -115,4 +116,21 @@ var _ = Describe("MergeSchemas", func() {
 			Expect(src.Ref).To(Equal(schema.Ref("#/definitions/OtherMyString")))
 		})
 	})
+
+	Context("when the external schema is merged into more than one schema", func() {
+		It("should not change the registered schema", func() {
+			src := &schema.JSONSchema{Ref: "../alfa/schema.yaml#/definitions/MyList"}
+			schema.MergeSchemas(dst, src, dstFile, schemaMap)
+			Expect(dst.Definitions).To(HaveKey(schema.FieldKey("AlfaMyList")))
+			Expect(dst.Definitions["AlfaMyList"].Items.Ref).To(Equal(schema.Ref("#/definitions/AlfaMyBool")))
+			Expect(schemaMap["alfa/schema.yaml"].Definitions["MyList"].Items.Ref).
+				To(Equal(schema.Ref("#/definitions/MyBool")))
+
+			other := &schema.JSONSchema{Definitions: map[schema.FieldKey]*schema.JSONSchema{}}
+			otherSrc := &schema.JSONSchema{Ref: "../alfa/schema.yaml#/definitions/MyList"}
+			schema.MergeSchemas(other, otherSrc, "delta/schema.yaml", schemaMap)
+			Expect(other.Definitions).To(HaveKey(schema.FieldKey("AlfaMyBool")))
+			Expect(other.Definitions["AlfaMyList"].Items.Ref).To(Equal(schema.Ref("#/definitions/AlfaMyBool")))
+		})
+	})
 })
@@ -2,6 +2,7 @@
 
 package config
 
+import "github.com/flowexec/flow/types/executable"
 import "github.com/flowexec/tuikit/io"
 import "time"
 
@@ -82,6 +83,13 @@ type Config struct {
 	//
 	CurrentNamespace string `json:"currentNamespace,omitempty" yaml:"currentNamespace,omitempty" mapstructure:"currentNamespace,omitempty"`
 
+	// The name of the profile used when running executables. The profile is only
+	// applied to executables in
+	// workspaces that define a profile with this name. It can be overridden with the
+	// `--profile` flag.
+	//
+	CurrentProfile string `json:"currentProfile,omitempty" yaml:"currentProfile,omitempty" mapstructure:"currentProfile,omitempty"`
+
 	// The name of the current vault. This should match a key in the `vaults` map.
 	CurrentVault *string `json:"currentVault,omitempty" yaml:"currentVault,omitempty" mapstructure:"currentVault,omitempty"`
 
@@ -104,15 +112,30 @@ type Config struct {
 	//
 	DefaultTimeout time.Duration `json:"defaultTimeout,omitempty" yaml:"defaultTimeout,omitempty" mapstructure:"defaultTimeout,omitempty"`
 
+	// Hooks that are run around every executable. Hook commands are run from the root
+	// of the executable's workspace.
+	// The executable reference, its result status and duration are available to the
+	// hooks in the
+	// `FLOW_EXECUTABLE_REF`, `FLOW_EXECUTABLE_STATUS` and `FLOW_EXECUTABLE_DURATION`
+	// environment variables.
+	//
+	Hooks *executable.Hooks `json:"hooks,omitempty" yaml:"hooks,omitempty" mapstructure:"hooks,omitempty"`
+
 	// Interactive corresponds to the JSON schema field "interactive".
 	Interactive *Interactive `json:"interactive,omitempty" yaml:"interactive,omitempty" mapstructure:"interactive,omitempty"`
 
+	// LogRetention corresponds to the JSON schema field "logRetention".
+	LogRetention *LogRetention `json:"logRetention,omitempty" yaml:"logRetention,omitempty" mapstructure:"logRetention,omitempty"`
+
 	// A map of flowfile template names to their paths.
 	Templates ConfigTemplates `json:"templates,omitempty" yaml:"templates,omitempty" mapstructure:"templates,omitempty"`
 
 	// The theme of the interactive UI.
 	Theme ConfigTheme `json:"theme,omitempty" yaml:"theme,omitempty" mapstructure:"theme,omitempty"`
 
+	// Tracing corresponds to the JSON schema field "tracing".
+	Tracing *Tracing `json:"tracing,omitempty" yaml:"tracing,omitempty" mapstructure:"tracing,omitempty"`
+
 	// A map of vault names to their paths. The path should be a valid absolute path
 	// to the vault file created by flow.
 	Vaults ConfigVaults `json:"vaults,omitempty" yaml:"vaults,omitempty" mapstructure:"vaults,omitempty"`
@@ -168,3 +191,50 @@ type Interactive struct {
 	// Whether to play a sound when a command completes.
 	SoundOnCompletion *bool `json:"soundOnCompletion,omitempty" yaml:"soundOnCompletion,omitempty" mapstructure:"soundOnCompletion,omitempty"`
 }
+
+// Retention policy for the execution log archive. Log entries that exceed any of
+// the limits are removed,
+// starting with the oldest, when running `flow logs prune` and before each
+// execution.
+type LogRetention struct {
+	// The maximum age of a log entry. This should be a valid duration string (e.g.
+	// `168h`).
+	//
+	MaxAge *time.Duration `json:"maxAge,omitempty" yaml:"maxAge,omitempty" mapstructure:"maxAge,omitempty"`
+
+	// The maximum number of log entries to keep.
+	MaxCount *int `json:"maxCount,omitempty" yaml:"maxCount,omitempty" mapstructure:"maxCount,omitempty"`
+
+	// The maximum total size of the log entries (e.g. `100MB`).
+	MaxSize *string `json:"maxSize,omitempty" yaml:"maxSize,omitempty" mapstructure:"maxSize,omitempty"`
+}
+
+// Configurations for the trace export of executions. Each execution is exported as
+// a trace with spans for the
+// executable, its serial and parallel steps, retries, requests and hooks.
+type Tracing struct {
+	// The directory that traces are written to when no endpoint is set. Defaults to
+	// the `traces` directory in
+	// the flow cache directory.
+	//
+	Directory *string `json:"directory,omitempty" yaml:"directory,omitempty" mapstructure:"directory,omitempty"`
+
+	// Enabled corresponds to the JSON schema field "enabled".
+	Enabled bool `json:"enabled" yaml:"enabled" mapstructure:"enabled"`
+
+	// The base URL of the OTLP/HTTP collector that traces are exported to (e.g.
+	// `http://localhost:4318`).
+	// The `/v1/traces` path is appended unless the URL already ends with it.
+	// If unset, the `OTEL_EXPORTER_OTLP_TRACES_ENDPOINT` and
+	// `OTEL_EXPORTER_OTLP_ENDPOINT` environment variables
+	// are used. When no endpoint is set, traces are written as OTLP JSON files to the
+	// traces directory.
+	//
+	Endpoint *string `json:"endpoint,omitempty" yaml:"endpoint,omitempty" mapstructure:"endpoint,omitempty"`
+
+	// Headers that are sent with the export requests, e.g. for authentication.
+	Headers TracingHeaders `json:"headers,omitempty" yaml:"headers,omitempty" mapstructure:"headers,omitempty"`
+}
+
+// Headers that are sent with the export requests, e.g. for authentication.
+type TracingHeaders map[string]string
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"slices"
 
+	"github.com/dustin/go-humanize"
 	tuikitIO "github.com/flowexec/tuikit/io"
 	"golang.org/x/exp/maps"
 	"gopkg.in/yaml.v3"
@@ -31,10 +32,30 @@ func (c *Config) Validate() error {
 	if err := c.DefaultLogMode.Validate(); err != nil {
 		return err
 	}
+	if err := c.Hooks.Validate(); err != nil {
+		return fmt.Errorf("hooks validation failed - %w", err)
+	}
+	if c.LogRetention != nil {
+		if _, err := c.LogRetention.MaxSizeBytes(); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
 
+// MaxSizeBytes returns the parsed maxSize limit. It returns 0 when no size limit is set.
+func (r *LogRetention) MaxSizeBytes() (uint64, error) {
+	if r.MaxSize == nil || *r.MaxSize == "" {
+		return 0, nil
+	}
+	size, err := humanize.ParseBytes(*r.MaxSize)
+	if err != nil {
+		return 0, fmt.Errorf("invalid log retention max size %s - %w", *r.MaxSize, err)
+	}
+	return size, nil
+}
+
 func (c *Config) SetDefaults() {
 	if c.Workspaces == nil {
 		c.Workspaces = make(map[string]string)
@@ -104,6 +125,9 @@ func (c *Config) Markdown() string {
 	} else {
 		mkdwn += "*No namespace is set*\n\n"
 	}
+	if c.CurrentProfile != "" {
+		mkdwn += fmt.Sprintf("**Current profile**: %s\n\n", c.CurrentProfile)
+	}
 	if c.DefaultTimeout != 0 {
 		mkdwn += fmt.Sprintf("**Default timeout**: %s\n", c.DefaultTimeout)
 	}
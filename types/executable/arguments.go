@@ -2,23 +2,71 @@ package executable
 
 import (
 	"fmt"
+	"os"
+	"regexp"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/flowexec/flow/internal/utils"
 )
 
+const DefaultArgListSeparator = ","
+
 func (a *Argument) Set(value string) {
 	a.value = value
 }
 
+// SetValues sets the value of the argument from one or more inputs. Multiple inputs are only
+// supported for `list` arguments; for all other types, the last input wins.
+func (a *Argument) SetValues(values ...string) {
+	if len(values) == 0 {
+		return
+	}
+	if a.Type == ArgumentTypeList {
+		a.value = strings.Join(values, a.ListSeparator())
+		return
+	}
+	a.value = values[len(values)-1]
+}
+
+// SetContext sets the location information used to expand `path` arguments.
+func (a *Argument) SetContext(workspacePath, flowFilePath string) {
+	a.workspacePath = workspacePath
+	a.flowFilePath = flowFilePath
+}
+
+// Value returns the value that should be passed to the executable. The default is used if the argument was not
+// set, and `path` arguments are expanded to an absolute path.
 func (a *Argument) Value() string {
-	if a.value == "" {
-		return a.Default
+	val := a.rawValue()
+	if a.Type == ArgumentTypePath && val != "" {
+		return a.ExpandPath(val)
 	}
-	return a.value
+	return val
 }
 
-func (a *Argument) Validate() error {
+func (a *Argument) ListSeparator() string {
+	if a.Separator == "" {
+		return DefaultArgListSeparator
+	}
+	return a.Separator
+}
+
+// Name returns a human-readable identifier for the argument (its flag or position).
+func (a *Argument) Name() string {
+	switch {
+	case a.Flag != "":
+		return a.Flag
+	case a.Pos != nil:
+		return fmt.Sprintf("pos %d", *a.Pos)
+	default:
+		return a.EnvKey
+	}
+}
+
+func (a *Argument) ValidateConfig() error {
 	if err := utils.ValidateOneOf("argument type", a.Flag, a.Pos); err != nil {
 		return err
 	}
@@ -29,6 +77,19 @@ func (a *Argument) Validate() error {
 	if err := validateArgType(a.Type); err != nil {
 		return fmt.Errorf("%s - %w", a.EnvKey, err)
 	}
+	if a.Type == ArgumentTypeEnum {
+		if len(a.Options) == 0 {
+			return fmt.Errorf("%s - options must be set for enum arguments", a.EnvKey)
+		}
+		if a.Default != "" && !slices.Contains(a.Options, a.Default) {
+			return fmt.Errorf("%s - default value (%s) is not one of the options", a.EnvKey, a.Default)
+		}
+	}
+	if a.Validate != "" {
+		if _, err := regexp.Compile(a.Validate); err != nil {
+			return fmt.Errorf("%s - invalid validation expression: %w", a.EnvKey, err)
+		}
+	}
 	return nil
 }
 
@@ -37,30 +98,78 @@ func (a *Argument) ValidateValue() error {
 		return fmt.Errorf("required argument not set")
 	}
 
+	val := a.rawValue()
+	if val == "" {
+		return nil
+	}
+
 	switch a.Type {
 	case ArgumentTypeInt:
-		if _, err := strconv.Atoi(a.value); err != nil {
+		if _, err := strconv.Atoi(val); err != nil {
 			return fmt.Errorf("value is not an integer")
 		}
 	case ArgumentTypeFloat:
-		if _, err := strconv.ParseFloat(a.value, 64); err != nil {
+		if _, err := strconv.ParseFloat(val, 64); err != nil {
 			return fmt.Errorf("value is not a float")
 		}
 	case ArgumentTypeBool:
-		if _, err := strconv.ParseBool(a.value); err != nil {
+		if _, err := strconv.ParseBool(val); err != nil {
 			return fmt.Errorf("value is not a boolean")
 		}
-	case ArgumentTypeString, "":
+	case ArgumentTypeDuration:
+		if _, err := time.ParseDuration(val); err != nil {
+			return fmt.Errorf("value is not a duration")
+		}
+	case ArgumentTypeEnum:
+		if !slices.Contains(a.Options, val) {
+			return fmt.Errorf("value (%s) must be one of [%s]", val, strings.Join(a.Options, ", "))
+		}
+	case ArgumentTypePath:
+		if _, err := os.Stat(a.ExpandPath(val)); err != nil {
+			return fmt.Errorf("path %s does not exist", val)
+		}
+	case ArgumentTypeString, ArgumentTypeList, "":
 		// no-op
 	default:
 		// no-op, assume string
 	}
+
+	if a.Validate != "" {
+		r, err := regexp.Compile(a.Validate)
+		if err != nil {
+			return fmt.Errorf("invalid validation expression: %w", err)
+		}
+		values := []string{val}
+		if a.Type == ArgumentTypeList {
+			values = strings.Split(val, a.ListSeparator())
+		}
+		for _, v := range values {
+			if !r.MatchString(v) {
+				return fmt.Errorf("value (%s) does not match %s", v, a.Validate)
+			}
+		}
+	}
 	return nil
 }
 
+func (a *Argument) rawValue() string {
+	if a.value == "" {
+		return a.Default
+	}
+	return a.value
+}
+
+// ExpandPath expands the path using the same rules as the executable directory: `//` is relative to the
+// workspace root, `./` is relative to the current working directory and all other relative paths, including an
+// empty path, are relative to the flow file directory.
+func (a *Argument) ExpandPath(path string) string {
+	return utils.ExpandWorkspacePath(path, a.workspacePath, a.flowFilePath, nil)
+}
+
 func validateArgType(t ArgumentType) error {
 	switch t {
-	case ArgumentTypeString, ArgumentTypeInt, ArgumentTypeBool, ArgumentTypeFloat:
+	case ArgumentTypeString, ArgumentTypeInt, ArgumentTypeBool, ArgumentTypeFloat,
+		ArgumentTypeEnum, ArgumentTypePath, ArgumentTypeList, ArgumentTypeDuration:
 		return nil
 	case "":
 		// type is assumed to be a string
@@ -70,10 +179,17 @@ func validateArgType(t ArgumentType) error {
 	}
 }
 
+// SetContext sets the location information of all arguments in the list.
+func (al ArgumentList) SetContext(workspacePath, flowFilePath string) {
+	for i := range al {
+		al[i].SetContext(workspacePath, flowFilePath)
+	}
+}
+
 func (al *ArgumentList) Validate() error {
 	var errs []error
 	for _, arg := range *al {
-		if err := arg.Validate(); err != nil {
+		if err := arg.ValidateConfig(); err != nil {
 			errs = append(
 				errs,
 				fmt.Errorf("argument (envKey=%s outputFile=%s) validation failed - %w", arg.EnvKey, arg.OutputFile, err),
@@ -105,7 +221,7 @@ func (al *ArgumentList) ValidateValues() error {
 	var errs []error
 	for _, arg := range *al {
 		if err := arg.ValidateValue(); err != nil {
-			errs = append(errs, fmt.Errorf("argument %s validation failed - %w", arg.EnvKey, err))
+			errs = append(errs, fmt.Errorf("argument %s (%s) validation failed - %w", arg.Name(), arg.EnvKey, err))
 		}
 	}
 	if len(errs) > 0 {
@@ -0,0 +1,106 @@
+package executable_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/flowexec/flow/types/executable"
+)
+
+var _ = Describe("Argument", func() {
+	Describe("ValidateConfig", func() {
+		It("should require options for enum arguments", func() {
+			arg := executable.Argument{EnvKey: "TARGET", Flag: "target", Type: executable.ArgumentTypeEnum}
+			Expect(arg.ValidateConfig()).To(MatchError(ContainSubstring("options must be set")))
+		})
+
+		It("should reject a default that is not one of the options", func() {
+			arg := executable.Argument{
+				EnvKey: "TARGET", Flag: "target", Type: executable.ArgumentTypeEnum,
+				Options: []string{"dev", "prod"}, Default: "staging",
+			}
+			Expect(arg.ValidateConfig()).To(MatchError(ContainSubstring("not one of the options")))
+		})
+
+		It("should reject an invalid validation expression", func() {
+			arg := executable.Argument{EnvKey: "NAME", Flag: "name", Validate: "["}
+			Expect(arg.ValidateConfig()).To(HaveOccurred())
+		})
+	})
+
+	DescribeTable("ValidateValue",
+		func(arg executable.Argument, value string, valid bool) {
+			arg.EnvKey = "KEY"
+			arg.Flag = "key"
+			arg.Set(value)
+			if valid {
+				Expect(arg.ValidateValue()).To(Succeed())
+			} else {
+				Expect(arg.ValidateValue()).ToNot(Succeed())
+			}
+		},
+		Entry("valid int", executable.Argument{Type: executable.ArgumentTypeInt}, "42", true),
+		Entry("invalid int", executable.Argument{Type: executable.ArgumentTypeInt}, "forty", false),
+		Entry("unset int with default", executable.Argument{Type: executable.ArgumentTypeInt, Default: "1"}, "", true),
+		Entry("valid duration", executable.Argument{Type: executable.ArgumentTypeDuration}, "1h30m", true),
+		Entry("invalid duration", executable.Argument{Type: executable.ArgumentTypeDuration}, "90", false),
+		Entry("valid enum",
+			executable.Argument{Type: executable.ArgumentTypeEnum, Options: []string{"a", "b"}}, "b", true),
+		Entry("invalid enum",
+			executable.Argument{Type: executable.ArgumentTypeEnum, Options: []string{"a", "b"}}, "c", false),
+		Entry("matching validation", executable.Argument{Validate: `^v\d+$`}, "v2", true),
+		Entry("non-matching validation", executable.Argument{Validate: `^v\d+$`}, "2", false),
+		Entry("list items matching validation",
+			executable.Argument{Type: executable.ArgumentTypeList, Validate: `^[a-z]+$`}, "a,b,c", true),
+		Entry("list item not matching validation",
+			executable.Argument{Type: executable.ArgumentTypeList, Validate: `^[a-z]+$`}, "a,B,c", false),
+		Entry("required and unset", executable.Argument{Required: true}, "", false),
+	)
+
+	Describe("path arguments", func() {
+		var (
+			wsDir        string
+			flowFilePath string
+		)
+
+		BeforeEach(func() {
+			wsDir = GinkgoT().TempDir()
+			Expect(os.MkdirAll(filepath.Join(wsDir, "sub"), 0750)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(wsDir, "sub", "input.txt"), []byte("data"), 0600)).To(Succeed())
+			flowFilePath = filepath.Join(wsDir, "sub", "test.flow")
+		})
+
+		It("should expand the value relative to the flow file", func() {
+			arg := executable.Argument{EnvKey: "FILE", Flag: "file", Type: executable.ArgumentTypePath}
+			arg.SetContext(wsDir, flowFilePath)
+			arg.Set("input.txt")
+			Expect(arg.ValidateValue()).To(Succeed())
+			Expect(arg.Value()).To(Equal(filepath.Join(wsDir, "sub", "input.txt")))
+		})
+
+		It("should expand the value relative to the workspace root", func() {
+			arg := executable.Argument{EnvKey: "FILE", Flag: "file", Type: executable.ArgumentTypePath}
+			arg.SetContext(wsDir, flowFilePath)
+			arg.Set("//sub/input.txt")
+			Expect(arg.Value()).To(Equal(filepath.Join(wsDir, "sub", "input.txt")))
+		})
+
+		It("should allow paths in system directories", func() {
+			arg := executable.Argument{EnvKey: "FILE", Flag: "file", Type: executable.ArgumentTypePath}
+			arg.SetContext(wsDir, flowFilePath)
+			arg.Set("/dev/null")
+			Expect(arg.ValidateValue()).To(Succeed())
+			Expect(arg.Value()).To(Equal("/dev/null"))
+		})
+
+		It("should fail when the path does not exist", func() {
+			arg := executable.Argument{EnvKey: "FILE", Flag: "file", Type: executable.ArgumentTypePath}
+			arg.SetContext(wsDir, flowFilePath)
+			arg.Set("missing.txt")
+			Expect(arg.ValidateValue()).To(MatchError(ContainSubstring("does not exist")))
+		})
+	})
+})
@@ -0,0 +1,53 @@
+package executable
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/flowexec/flow/internal/utils"
+)
+
+// SetContext sets the location information used to expand the env file path.
+func (f *EnvFile) SetContext(workspacePath, flowFilePath string) {
+	f.workspacePath = workspacePath
+	f.flowFilePath = flowFilePath
+}
+
+// ExpandPath expands the env file path using the same rules as the executable directory: `//` is relative to the
+// workspace root, `./` is relative to the current working directory and all other relative paths are relative to
+// the flow file directory.
+func (f *EnvFile) ExpandPath(env map[string]string) string {
+	if f.workspacePath != "" && strings.HasPrefix(f.Path, "//") {
+		return filepath.Clean(strings.Replace(f.Path, "//", f.workspacePath+"/", 1))
+	}
+	return utils.ExpandPath(f.Path, filepath.Dir(f.flowFilePath), env)
+}
+
+func (f *EnvFile) Validate() error {
+	if f.Path == "" {
+		return errors.New("must specify path for env file")
+	}
+	return nil
+}
+
+// SetContext sets the location information of all env files in the list.
+func (fl EnvFileList) SetContext(workspacePath, flowFilePath string) {
+	for i := range fl {
+		fl[i].SetContext(workspacePath, flowFilePath)
+	}
+}
+
+func (fl EnvFileList) Validate() error {
+	var errs []error
+	for i, f := range fl {
+		if err := f.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("env file %d validation failed - %w", i, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%d env file validation errors: %v", len(errs), errs)
+	}
+	return nil
+}
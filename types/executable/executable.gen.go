@@ -2,6 +2,7 @@
 
 package executable
 
+import "context"
 import "github.com/flowexec/flow/types/common"
 import "github.com/flowexec/tuikit/io"
 import "time"
@@ -21,6 +22,13 @@ type Argument struct {
 	//
 	Flag string `json:"flag,omitempty" yaml:"flag,omitempty" mapstructure:"flag,omitempty"`
 
+	// flowFilePath corresponds to the JSON schema field "flowFilePath".
+	flowFilePath string `json:"flowFilePath,omitempty" yaml:"flowFilePath,omitempty" mapstructure:"flowFilePath,omitempty"`
+
+	// The allowed values for the argument. Required when the argument type is
+	// `enum`.
+	Options []string `json:"options,omitempty" yaml:"options,omitempty" mapstructure:"options,omitempty"`
+
 	// A path where the argument value will be temporarily written to disk.
 	// The file will be created before execution and cleaned up afterwards.
 	//
@@ -39,12 +47,28 @@ type Argument struct {
 	//
 	Required bool `json:"required,omitempty" yaml:"required,omitempty" mapstructure:"required,omitempty"`
 
-	// The type of the argument. This is used to determine how to parse the value of
-	// the argument.
+	// The separator used to join the values of a `list` argument.
+	Separator string `json:"separator,omitempty" yaml:"separator,omitempty" mapstructure:"separator,omitempty"`
+
+	// The type of the argument. This is used to determine how to parse and validate
+	// the value of the argument.
+	// `enum` values must be one of the `options`, `path` values must exist and are
+	// expanded relative to the
+	// flow file, and `list` flags can be repeated with their values joined by the
+	// `separator`.
+	//
 	Type ArgumentType `json:"type,omitempty" yaml:"type,omitempty" mapstructure:"type,omitempty"`
 
+	// A regular expression to validate the argument value against.
+	// For `list` arguments, each value is validated individually.
+	//
+	Validate string `json:"validate,omitempty" yaml:"validate,omitempty" mapstructure:"validate,omitempty"`
+
 	// value corresponds to the JSON schema field "value".
 	value string `json:"value,omitempty" yaml:"value,omitempty" mapstructure:"value,omitempty"`
+
+	// workspacePath corresponds to the JSON schema field "workspacePath".
+	workspacePath string `json:"workspacePath,omitempty" yaml:"workspacePath,omitempty" mapstructure:"workspacePath,omitempty"`
 }
 
 type ArgumentList []Argument
@@ -52,10 +76,31 @@ type ArgumentList []Argument
 type ArgumentType string
 
 const ArgumentTypeBool ArgumentType = "bool"
+const ArgumentTypeDuration ArgumentType = "duration"
+const ArgumentTypeEnum ArgumentType = "enum"
 const ArgumentTypeFloat ArgumentType = "float"
 const ArgumentTypeInt ArgumentType = "int"
+const ArgumentTypeList ArgumentType = "list"
+const ArgumentTypePath ArgumentType = "path"
 const ArgumentTypeString ArgumentType = "string"
 
+// Requires a confirmation before the executable is run. Executables with the
+// `protected` tag or a destructive verb
+// (`destroy`, `purge` or `undeploy`) always require a confirmation, even if this
+// is not set.
+// The confirmation can be skipped with the `--yes` flag.
+type Confirmation struct {
+	// The message displayed when asking for the confirmation.
+	Message string `json:"message,omitempty" yaml:"message,omitempty" mapstructure:"message,omitempty"`
+
+	// A phrase that must be typed to confirm the run, e.g. the name of the workspace
+	// or environment.
+	// Environment variables like `$FLOW_CURRENT_WORKSPACE` are expanded in the
+	// phrase.
+	//
+	Phrase string `json:"phrase,omitempty" yaml:"phrase,omitempty" mapstructure:"phrase,omitempty"`
+}
+
 // The directory to execute the command in.
 // If unset, the directory of the flow file will be used.
 // If set to `f:tmp`, a temporary directory will be created for the process.
@@ -65,6 +110,39 @@ const ArgumentTypeString ArgumentType = "string"
 // Environment variables in the path will be expended at runtime.
 type Directory string
 
+// A dotenv file that is loaded into the environment of the executable.
+type EnvFile struct {
+	// flowFilePath corresponds to the JSON schema field "flowFilePath".
+	flowFilePath string `json:"flowFilePath,omitempty" yaml:"flowFilePath,omitempty" mapstructure:"flowFilePath,omitempty"`
+
+	// If set to true, `$VAR` and `${VAR}` references in the file's values will be
+	// expanded using the variables
+	// loaded before it and the current environment.
+	//
+	Interpolate bool `json:"interpolate,omitempty" yaml:"interpolate,omitempty" mapstructure:"interpolate,omitempty"`
+
+	// If set to true, the file will be skipped when it does not exist instead of
+	// failing the execution.
+	Optional bool `json:"optional,omitempty" yaml:"optional,omitempty" mapstructure:"optional,omitempty"`
+
+	// The path to the dotenv file. Relative paths are resolved from the flow file
+	// directory.
+	// If prefixed with `./`, the path will be relative to the current working
+	// directory.
+	// If prefixed with `//`, the path will be relative to the workspace root.
+	//
+	Path string `json:"path" yaml:"path" mapstructure:"path"`
+
+	// workspacePath corresponds to the JSON schema field "workspacePath".
+	workspacePath string `json:"workspacePath,omitempty" yaml:"workspacePath,omitempty" mapstructure:"workspacePath,omitempty"`
+}
+
+// A list of dotenv files to load into the environment of the executable. Files
+// are loaded in order, with
+// later files overriding earlier ones. Their values are overridden by params,
+// args and `--param` overrides.
+type EnvFileList []EnvFile
+
 // Standard executable type. Runs a command/file in a subprocess.
 type ExecExecutableType struct {
 	// Args corresponds to the JSON schema field "args".
@@ -86,6 +164,9 @@ type ExecExecutableType struct {
 	// logFields corresponds to the JSON schema field "logFields".
 	logFields map[string]interface{} `json:"logFields,omitempty" yaml:"logFields,omitempty" mapstructure:"logFields,omitempty"`
 
+	// logger corresponds to the JSON schema field "logger".
+	logger io.Logger `json:"logger,omitempty" yaml:"logger,omitempty" mapstructure:"logger,omitempty"`
+
 	// The log mode to use when running the executable.
 	// This can either be `hidden`, `json`, `logfmt` or `text`
 	//
@@ -93,6 +174,9 @@ type ExecExecutableType struct {
 
 	// Params corresponds to the JSON schema field "params".
 	Params ParameterList `json:"params,omitempty" yaml:"params,omitempty" mapstructure:"params,omitempty"`
+
+	// runCtx corresponds to the JSON schema field "runCtx".
+	runCtx context.Context `json:"runCtx,omitempty" yaml:"runCtx,omitempty" mapstructure:"runCtx,omitempty"`
 }
 
 // The executable schema defines the structure of an executable in the Flow CLI.
@@ -102,21 +186,36 @@ type Executable struct {
 	// Aliases corresponds to the JSON schema field "aliases".
 	Aliases ExecutableAliases `json:"aliases,omitempty" yaml:"aliases,omitempty" mapstructure:"aliases,omitempty"`
 
+	// Confirm corresponds to the JSON schema field "confirm".
+	Confirm *Confirmation `json:"confirm,omitempty" yaml:"confirm,omitempty" mapstructure:"confirm,omitempty"`
+
 	// A description of the executable.
 	// This description is rendered as markdown in the interactive UI.
 	//
 	Description string `json:"description,omitempty" yaml:"description,omitempty" mapstructure:"description,omitempty"`
 
+	// EnvFile corresponds to the JSON schema field "envFile".
+	EnvFile EnvFileList `json:"envFile,omitempty" yaml:"envFile,omitempty" mapstructure:"envFile,omitempty"`
+
 	// Exec corresponds to the JSON schema field "exec".
 	Exec *ExecExecutableType `json:"exec,omitempty" yaml:"exec,omitempty" mapstructure:"exec,omitempty"`
 
 	// flowFilePath corresponds to the JSON schema field "flowFilePath".
 	flowFilePath string `json:"flowFilePath,omitempty" yaml:"flowFilePath,omitempty" mapstructure:"flowFilePath,omitempty"`
 
+	// Hooks corresponds to the JSON schema field "hooks".
+	Hooks *Hooks `json:"hooks,omitempty" yaml:"hooks,omitempty" mapstructure:"hooks,omitempty"`
+
 	// inheritedDescription corresponds to the JSON schema field
 	// "inheritedDescription".
 	inheritedDescription string `json:"inheritedDescription,omitempty" yaml:"inheritedDescription,omitempty" mapstructure:"inheritedDescription,omitempty"`
 
+	// inheritedEnvFile corresponds to the JSON schema field "inheritedEnvFile".
+	inheritedEnvFile EnvFileList `json:"inheritedEnvFile,omitempty" yaml:"inheritedEnvFile,omitempty" mapstructure:"inheritedEnvFile,omitempty"`
+
+	// inheritedParams corresponds to the JSON schema field "inheritedParams".
+	inheritedParams ParameterList `json:"inheritedParams,omitempty" yaml:"inheritedParams,omitempty" mapstructure:"inheritedParams,omitempty"`
+
 	// Launch corresponds to the JSON schema field "launch".
 	Launch *LaunchExecutableType `json:"launch,omitempty" yaml:"launch,omitempty" mapstructure:"launch,omitempty"`
 
@@ -175,6 +274,49 @@ type ExecutableTags common.Tags
 
 type ExecutableVisibility common.Visibility
 
+// A step that is run as part of an executable's lifecycle hooks.
+type Hook struct {
+	// Arguments to pass to the executable.
+	Args []string `json:"args,omitempty" yaml:"args,omitempty" mapstructure:"args,omitempty"`
+
+	// The command to execute.
+	// One of `cmd` or `ref` must be set.
+	//
+	Cmd string `json:"cmd,omitempty" yaml:"cmd,omitempty" mapstructure:"cmd,omitempty"`
+
+	// A reference to another executable to run.
+	// One of `cmd` or `ref` must be set.
+	//
+	Ref Ref `json:"ref,omitempty" yaml:"ref,omitempty" mapstructure:"ref,omitempty"`
+}
+
+// A list of steps that are run in order. The executables can be defined by it's
+// exec `cmd` or `ref`.
+type HookList []Hook
+
+// Steps that are run around the executable. Hook steps inherit the environment of
+// the executable.
+// If a `before` step fails, the executable is not run.
+type Hooks struct {
+	// Steps that are run after the executable succeeds.
+	After HookList `json:"after,omitempty" yaml:"after,omitempty" mapstructure:"after,omitempty"`
+
+	// Steps that are run before the executable. The executable is not run if one of
+	// them fails.
+	Before HookList `json:"before,omitempty" yaml:"before,omitempty" mapstructure:"before,omitempty"`
+
+	// Steps that are always run after the executable, including when it fails, times
+	// out or is cancelled.
+	//
+	Finally HookList `json:"finally,omitempty" yaml:"finally,omitempty" mapstructure:"finally,omitempty"`
+
+	// Steps that are run when the executable or one of its hooks fails.
+	// The error message is available in the `FLOW_EXECUTABLE_ERROR` environment
+	// variable.
+	//
+	OnFailure HookList `json:"onFailure,omitempty" yaml:"onFailure,omitempty" mapstructure:"onFailure,omitempty"`
+}
+
 // Launches an application or opens a URI.
 type LaunchExecutableType struct {
 	// The application to launch the URI with.
@@ -213,10 +355,26 @@ type ParallelExecutableType struct {
 	// The maximum number of threads to use when executing the parallel executables.
 	MaxThreads int `json:"maxThreads,omitempty" yaml:"maxThreads,omitempty" mapstructure:"maxThreads,omitempty"`
 
+	// How the output of the parallel execs is written.
+	// `interleaved` writes the output as it is received, `prefixed` prefixes each
+	// line with the exec's name,
+	// `grouped` writes the output of each exec as a block once it completes and
+	// `buffered-on-failure` only
+	// writes the output of the execs that failed.
+	//
+	OutputMode ParallelExecutableTypeOutputMode `json:"outputMode,omitempty" yaml:"outputMode,omitempty" mapstructure:"outputMode,omitempty"`
+
 	// Params corresponds to the JSON schema field "params".
 	Params ParameterList `json:"params,omitempty" yaml:"params,omitempty" mapstructure:"params,omitempty"`
 }
 
+type ParallelExecutableTypeOutputMode string
+
+const ParallelExecutableTypeOutputModeBufferedOnFailure ParallelExecutableTypeOutputMode = "buffered-on-failure"
+const ParallelExecutableTypeOutputModeGrouped ParallelExecutableTypeOutputMode = "grouped"
+const ParallelExecutableTypeOutputModeInterleaved ParallelExecutableTypeOutputMode = "interleaved"
+const ParallelExecutableTypeOutputModePrefixed ParallelExecutableTypeOutputMode = "prefixed"
+
 // Configuration for a parallel executable.
 type ParallelRefConfig struct {
 	// Arguments to pass to the executable.
@@ -261,9 +419,19 @@ type ParallelRefConfigList []ParallelRefConfig
 // Only one of `text`, `secretRef`, `prompt`, or `file` must be set. Specifying
 // more than one will result in an error.
 type Parameter struct {
+	// The default value of a `prompt` parameter. It's used when no value is entered
+	// or when running with
+	// `--no-input`.
+	//
+	Default string `json:"default,omitempty" yaml:"default,omitempty" mapstructure:"default,omitempty"`
+
 	// The name of the environment variable that will be assigned the value.
 	EnvKey string `json:"envKey,omitempty" yaml:"envKey,omitempty" mapstructure:"envKey,omitempty"`
 
+	// The values that can be selected for a `prompt` parameter. Required when the
+	// type is `select`.
+	Options []string `json:"options,omitempty" yaml:"options,omitempty" mapstructure:"options,omitempty"`
+
 	// A path where the parameter value will be temporarily written to disk.
 	// The file will be created before execution and cleaned up afterwards.
 	//
@@ -277,10 +445,27 @@ type Parameter struct {
 
 	// A static value to be passed to the executable.
 	Text string `json:"text,omitempty" yaml:"text,omitempty" mapstructure:"text,omitempty"`
+
+	// The type of input to display when collecting a value for a `prompt` parameter.
+	// `select` values must be one of the `options` and `confirm` values are either
+	// `true` or `false`.
+	//
+	Type ParameterType `json:"type,omitempty" yaml:"type,omitempty" mapstructure:"type,omitempty"`
+
+	// A regular expression to validate the value entered for a `prompt` parameter
+	// against.
+	Validate string `json:"validate,omitempty" yaml:"validate,omitempty" mapstructure:"validate,omitempty"`
 }
 
 type ParameterList []Parameter
 
+type ParameterType string
+
+const ParameterTypeConfirm ParameterType = "confirm"
+const ParameterTypeMasked ParameterType = "masked"
+const ParameterTypeSelect ParameterType = "select"
+const ParameterTypeText ParameterType = "text"
+
 // A reference to an executable.
 // The format is `<verb> <workspace>/<namespace>:<executable name>`.
 // For example, `exec ws/ns:my-workflow`.
@@ -1,6 +1,7 @@
 package executable
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -9,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/flowexec/tuikit/io"
 	"github.com/flowexec/tuikit/types"
 	"gopkg.in/yaml.v3"
 
@@ -21,6 +23,8 @@ import (
 
 const (
 	TmpDirLabel = "f:tmp"
+	// ProtectedTag marks an executable that always requires a confirmation before it is run.
+	ProtectedTag = "protected"
 )
 
 type ExecutableList []*Executable
@@ -45,8 +49,9 @@ func (e Directory) ExpandDirectory(
 }
 
 type ExecutableEnvironment struct {
-	Params ParameterList `json:"params" yaml:"params"`
-	Args   ArgumentList  `json:"args"   yaml:"args"`
+	Params   ParameterList `json:"params"   yaml:"params"`
+	Args     ArgumentList  `json:"args"     yaml:"args"`
+	EnvFiles EnvFileList   `json:"envFiles" yaml:"envFiles"`
 }
 
 func (e *ExecExecutableType) SetLogFields(fields map[string]interface{}) {
@@ -57,6 +62,24 @@ func (e *ExecExecutableType) GetLogFields() map[string]interface{} {
 	return e.logFields
 }
 
+// SetLogger sets the logger that the output of the executable is written to instead of the global logger.
+func (e *ExecExecutableType) SetLogger(logger io.Logger) {
+	e.logger = logger
+}
+
+func (e *ExecExecutableType) GetLogger() io.Logger {
+	return e.logger
+}
+
+// SetRunContext sets the context that cancels the command or file of the executable when it is done.
+func (e *ExecExecutableType) SetRunContext(ctx context.Context) {
+	e.runCtx = ctx
+}
+
+func (e *ExecExecutableType) GetRunContext() context.Context {
+	return e.runCtx
+}
+
 type enrichedExecutableList struct {
 	Executables []*enrichedExecutable `json:"executables" yaml:"executables"`
 }
@@ -95,6 +118,53 @@ func (e *Executable) SetInheritedFields(flowFile *FlowFile) {
 		}
 	}
 	e.inheritedDescription = strings.Join([]string{flowFile.Description, descFromFIle}, "\n")
+	e.inheritedEnvFile = slices.Clone(flowFile.EnvFile)
+	e.inheritedParams = flowFile.DefaultParams()
+}
+
+// EnvFiles returns the env files that should be loaded for the executable. Files inherited from the flow file are
+// returned before the executable's own files.
+func (e *Executable) EnvFiles() EnvFileList {
+	if len(e.inheritedEnvFile) == 0 && len(e.EnvFile) == 0 {
+		return nil
+	}
+	files := append(slices.Clone(e.inheritedEnvFile), e.EnvFile...)
+	files.SetContext(e.WorkspacePath(), e.FlowFilePath())
+	return files
+}
+
+// RunCopy returns a copy of the executable with copies of its exec, serial and parallel specs. Runners change the
+// copy for a single run, e.g. to set the logger of a step, without changing the executable that is shared by the
+// executable cache.
+func (e *Executable) RunCopy() *Executable {
+	c := *e
+	if e.Exec != nil {
+		spec := *e.Exec
+		c.Exec = &spec
+	}
+	if e.Serial != nil {
+		spec := *e.Serial
+		c.Serial = &spec
+	}
+	if e.Parallel != nil {
+		spec := *e.Parallel
+		c.Parallel = &spec
+	}
+	return &c
+}
+
+// Confirmation returns the confirmation that is required before running the executable. Executables with the
+// protected tag or a destructive verb require a confirmation even if none is configured. Nil is returned if no
+// confirmation is required.
+func (e *Executable) Confirmation() *Confirmation {
+	switch {
+	case e.Confirm != nil:
+		return e.Confirm
+	case common.Tags(e.Tags).HasTag(ProtectedTag), e.Verb.IsDestructive():
+		return &Confirmation{}
+	default:
+		return nil
+	}
 }
 
 func (e *Executable) enriched() *enrichedExecutable {
@@ -168,17 +238,24 @@ func (e *Executable) Env() *ExecutableEnvironment {
 		return nil
 	}
 	typeElem := v.Elem()
-	execEnv := new(ExecutableEnvironment)
+	execEnv := &ExecutableEnvironment{EnvFiles: e.EnvFiles()}
+	var params ParameterList
 	for field := 0; field < typeElem.NumField(); field++ {
 		if typeElem.Field(field).Kind() == reflect.Slice && !typeElem.Field(field).IsZero() {
 			switch typeElem.Field(field).Interface().(type) {
 			case ParameterList:
-				execEnv.Params, _ = typeElem.Field(field).Interface().(ParameterList)
+				params, _ = typeElem.Field(field).Interface().(ParameterList)
 			case ArgumentList:
-				execEnv.Args, _ = typeElem.Field(field).Interface().(ArgumentList)
+				args, _ := typeElem.Field(field).Interface().(ArgumentList)
+				// the arguments are copied so that the values set for a run aren't kept by the executable, which is
+				// shared by the executable cache
+				execEnv.Args = slices.Clone(args)
+				execEnv.Args.SetContext(e.WorkspacePath(), e.FlowFilePath())
 			}
 		}
 	}
+	// parameters defined by the executable override the defaults inherited from the flow file and workspace
+	execEnv.Params = e.inheritedParams.Merge(params)
 	return execEnv
 }
 
@@ -256,6 +333,17 @@ func (e *Executable) Validate() error {
 			return fmt.Errorf("args validation failed - %w", err)
 		}
 	}
+	if err := e.EnvFile.Validate(); err != nil {
+		return fmt.Errorf("env file validation failed - %w", err)
+	}
+	if err := e.Hooks.Validate(); err != nil {
+		return fmt.Errorf("hooks validation failed - %w", err)
+	}
+	if e.Parallel != nil {
+		if err := e.Parallel.OutputMode.Validate(); err != nil {
+			return err
+		}
+	}
 
 	err := utils.ValidateOneOf(
 		"executable type",
@@ -280,6 +368,43 @@ func (e *Executable) Validate() error {
 	return nil
 }
 
+// Validate checks that each hook step sets exactly one of `cmd` or `ref`.
+func (h *Hooks) Validate() error {
+	if h == nil {
+		return nil
+	}
+	stages := []struct {
+		name  string
+		hooks HookList
+	}{
+		{"before", h.Before},
+		{"after", h.After},
+		{"onFailure", h.OnFailure},
+		{"finally", h.Finally},
+	}
+	for _, stage := range stages {
+		for i, hook := range stage.hooks {
+			if (hook.Cmd == "") == (hook.Ref == "") {
+				return fmt.Errorf("%s hook %d must set one of cmd or ref", stage.name, i+1)
+			}
+		}
+	}
+	return nil
+}
+
+func (m ParallelExecutableTypeOutputMode) Validate() error {
+	switch m {
+	case "",
+		ParallelExecutableTypeOutputModeInterleaved,
+		ParallelExecutableTypeOutputModePrefixed,
+		ParallelExecutableTypeOutputModeGrouped,
+		ParallelExecutableTypeOutputModeBufferedOnFailure:
+		return nil
+	default:
+		return fmt.Errorf("invalid parallel output mode %s", m)
+	}
+}
+
 func (e *Executable) NameEquals(name string) bool {
 	return e.Name == name || slices.Contains(e.Aliases, name)
 }
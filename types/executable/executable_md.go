@@ -17,6 +17,9 @@ func execMarkdown(e *Executable) string {
 	if e.Timeout != nil {
 		mkdwn += fmt.Sprintf("**Timeout:** %s\n", e.Timeout.String())
 	}
+	if e.Confirmation() != nil {
+		mkdwn += "**Requires confirmation**\n"
+	}
 	if len(e.Aliases) > 0 {
 		mkdwn += "**Aliases**\n"
 		for _, alias := range e.Aliases {
@@ -33,7 +36,20 @@ func execMarkdown(e *Executable) string {
 		mkdwn += "\n"
 	}
 
+	if files := e.EnvFiles(); len(files) > 0 {
+		mkdwn += "**Env Files**\n"
+		for _, f := range files {
+			if f.Optional {
+				mkdwn += fmt.Sprintf("- `%s` (optional)\n", f.Path)
+			} else {
+				mkdwn += fmt.Sprintf("- `%s`\n", f.Path)
+			}
+		}
+		mkdwn += "\n"
+	}
+
 	mkdwn += execTypeMarkdown(e)
+	mkdwn += hooksMarkdown(e.Hooks)
 	mkdwn += fmt.Sprintf("\n\n_Executable can be found in_ [%s](%s)\n", e.flowFilePath, e.flowFilePath)
 	return mkdwn
 }
@@ -220,6 +236,36 @@ func serialExecMarkdown(e *ExecutableEnvironment, s *SerialExecutableType) strin
 	return mkdwn
 }
 
+func hooksMarkdown(h *Hooks) string {
+	if h == nil {
+		return ""
+	}
+	mkdwn := "\n## Hooks\n"
+	stages := []struct {
+		name  string
+		hooks HookList
+	}{
+		{"Before", h.Before},
+		{"After", h.After},
+		{"On Failure", h.OnFailure},
+		{"Finally", h.Finally},
+	}
+	for _, stage := range stages {
+		if len(stage.hooks) == 0 {
+			continue
+		}
+		mkdwn += fmt.Sprintf("**%s**\n", stage.name)
+		for i, hook := range stage.hooks {
+			if hook.Ref != "" {
+				mkdwn += fmt.Sprintf("%d. ref: %s\n", i+1, hook.Ref)
+			} else if hook.Cmd != "" {
+				mkdwn += fmt.Sprintf("%d. cmd: \n```sh\n%s\n```\n", i+1, hook.Cmd)
+			}
+		}
+	}
+	return mkdwn
+}
+
 func parallelExecMarkdown(e *ExecutableEnvironment, p *ParallelExecutableType) string {
 	if p == nil {
 		return ""
@@ -273,6 +319,9 @@ func execEnvTable(env *ExecutableEnvironment) string {
 				valueInput = p.SecretRef
 			case p.Prompt != "":
 				valueType = "prompt"
+				if p.Type != "" && p.Type != ParameterTypeText {
+					valueType = fmt.Sprintf("prompt (%s)", p.Type)
+				}
 				valueInput = p.Prompt
 			}
 			table += fmt.Sprintf("| `%s` | %s | %s |\n", p.EnvKey, valueType, valueInput)
@@ -290,9 +339,13 @@ func execEnvTable(env *ExecutableEnvironment) string {
 			case a.Flag != "":
 				argType = "flag"
 			}
+			inputType := string(a.Type)
+			if len(a.Options) > 0 {
+				inputType = fmt.Sprintf("%s (%s)", a.Type, strings.Join(a.Options, ", "))
+			}
 			table += fmt.Sprintf(
 				"| `%s` | %s | %s | %s | %t |\n",
-				a.EnvKey, argType, a.Type, a.Default, a.Required,
+				a.EnvKey, argType, inputType, a.Default, a.Required,
 			)
 		}
 	}
@@ -301,6 +354,9 @@ func execEnvTable(env *ExecutableEnvironment) string {
 
 func templateMarkdown(t *Template) string {
 	mkdwn := fmt.Sprintf("# [Template] %s\n", t.Name())
+	if t.Extends != "" {
+		mkdwn += fmt.Sprintf("**Extends:** %s\n\n", t.Extends)
+	}
 	mkdwn += templateFormMarkdown(t)
 	mkdwn += templateArtifactsMarkdown(t)
 	if len(t.PreRun) > 0 {
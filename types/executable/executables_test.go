@@ -157,6 +157,107 @@ var _ = Describe("Executable", func() {
 	)
 })
 
+var _ = Describe("Env", func() {
+	It("should inherit the default params of the workspace and flow file", func() {
+		flowFile := &executable.FlowFile{
+			Env:    map[string]string{"FLOWFILE_ENV": "flowfile", "SHARED": "flowfile-env"},
+			Params: executable.ParameterList{{EnvKey: "SHARED", Text: "flowfile-param"}},
+			Executables: executable.ExecutableList{
+				{
+					Verb: "run",
+					Name: "test",
+					Exec: &executable.ExecExecutableType{
+						Cmd:    "echo $SHARED",
+						Params: executable.ParameterList{{EnvKey: "OWN", Text: "exec"}},
+					},
+				},
+				{
+					Verb: "run",
+					Name: "override",
+					Exec: &executable.ExecExecutableType{
+						Cmd:    "echo $SHARED",
+						Params: executable.ParameterList{{EnvKey: "WS_ENV", Text: "exec"}},
+					},
+				},
+			},
+		}
+		flowFile.SetWorkspaceDefaults(executable.ParameterList{
+			{EnvKey: "WS_ENV", Text: "workspace"},
+			{EnvKey: "SHARED", Text: "workspace"},
+		})
+		flowFile.SetContext("ws", "/ws", "/ws/test.flow")
+
+		Expect(flowFile.Executables[0].Env().Params).To(Equal(executable.ParameterList{
+			{EnvKey: "WS_ENV", Text: "workspace"},
+			{EnvKey: "FLOWFILE_ENV", Text: "flowfile"},
+			{EnvKey: "SHARED", Text: "flowfile-param"},
+			{EnvKey: "OWN", Text: "exec"},
+		}))
+		Expect(flowFile.Executables[1].Env().Params).To(Equal(executable.ParameterList{
+			{EnvKey: "FLOWFILE_ENV", Text: "flowfile"},
+			{EnvKey: "SHARED", Text: "flowfile-param"},
+			{EnvKey: "WS_ENV", Text: "exec"},
+		}))
+	})
+})
+
+var _ = Describe("Confirmation", func() {
+	It("should return the configured confirmation", func() {
+		e := &executable.Executable{Verb: "deploy", Confirm: &executable.Confirmation{Phrase: "prod"}}
+		Expect(e.Confirmation()).To(Equal(&executable.Confirmation{Phrase: "prod"}))
+	})
+
+	It("should require a confirmation for protected executables", func() {
+		e := &executable.Executable{Verb: "deploy", Tags: []string{executable.ProtectedTag}}
+		Expect(e.Confirmation()).NotTo(BeNil())
+	})
+
+	It("should require a confirmation for destructive verbs", func() {
+		e := &executable.Executable{Verb: executable.VerbDestroy}
+		Expect(e.Confirmation()).NotTo(BeNil())
+	})
+
+	It("should not require a confirmation by default", func() {
+		e := &executable.Executable{Verb: "deploy"}
+		Expect(e.Confirmation()).To(BeNil())
+	})
+})
+
+var _ = Describe("RunCopy", func() {
+	It("should not change the executable when the copy is changed", func() {
+		e := &executable.Executable{Verb: "run", Name: "step", Exec: &executable.ExecExecutableType{Cmd: "echo"}}
+		e.SetContext("ws", "/ws", "ns", "/ws/ns.flow")
+		c := e.RunCopy()
+		c.Exec.Dir = "/tmp"
+		c.Exec.SetLogFields(map[string]interface{}{"step": 1})
+
+		Expect(c.Ref()).To(Equal(e.Ref()))
+		Expect(c.FlowFilePath()).To(Equal(e.FlowFilePath()))
+		Expect(e.Exec.Dir).To(BeEmpty())
+		Expect(e.Exec.GetLogFields()).To(BeNil())
+	})
+})
+
+var _ = Describe("Hooks", func() {
+	It("should accept hooks with a cmd or ref", func() {
+		h := &executable.Hooks{
+			Before:  executable.HookList{{Cmd: "echo before"}},
+			Finally: executable.HookList{{Ref: "exec ws/ns:cleanup"}},
+		}
+		Expect(h.Validate()).To(Succeed())
+	})
+
+	It("should reject hooks without a cmd or ref", func() {
+		h := &executable.Hooks{OnFailure: executable.HookList{{Args: []string{"x=1"}}}}
+		Expect(h.Validate()).To(MatchError("onFailure hook 1 must set one of cmd or ref"))
+	})
+
+	It("should reject hooks with both a cmd and ref", func() {
+		h := &executable.Hooks{After: executable.HookList{{Cmd: "echo", Ref: "exec ws/ns:cleanup"}}}
+		Expect(h.Validate()).To(HaveOccurred())
+	})
+})
+
 var _ = Describe("ExecutableList", func() {
 	const (
 		exec1Ws = "ws1"
@@ -24,6 +24,20 @@ type FlowFile struct {
 	// defined within the flow file.
 	DescriptionFile string `json:"descriptionFile,omitempty" yaml:"descriptionFile,omitempty" mapstructure:"descriptionFile,omitempty"`
 
+	// Default environment variables for all executables defined within the flow
+	// file. Each entry is the equivalent
+	// of a `text` parameter and is overridden by a parameter with the same `envKey`
+	// defined by the flow file or
+	// an executable.
+	//
+	Env map[string]string `json:"env,omitempty" yaml:"env,omitempty" mapstructure:"env,omitempty"`
+
+	// A list of dotenv files to load into the environment of all executables defined
+	// within the flow file.
+	// These files are loaded before the files defined by the executables.
+	//
+	EnvFile EnvFileList `json:"envFile,omitempty" yaml:"envFile,omitempty" mapstructure:"envFile,omitempty"`
+
 	// Executables corresponds to the JSON schema field "executables".
 	Executables ExecutableList `json:"executables,omitempty" yaml:"executables,omitempty" mapstructure:"executables,omitempty"`
 
@@ -43,6 +57,13 @@ type FlowFile struct {
 	//
 	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty" mapstructure:"namespace,omitempty"`
 
+	// Default parameters for all executables defined within the flow file. They take
+	// precedence over the workspace
+	// defaults and are overridden by parameters with the same `envKey` defined by an
+	// executable.
+	//
+	Params ParameterList `json:"params,omitempty" yaml:"params,omitempty" mapstructure:"params,omitempty"`
+
 	// Tags to be applied to all executables defined within the flow file.
 	Tags []string `json:"tags,omitempty" yaml:"tags,omitempty" mapstructure:"tags,omitempty"`
 
@@ -54,6 +75,9 @@ type FlowFile struct {
 
 	// workspacePath corresponds to the JSON schema field "workspacePath".
 	workspacePath string `json:"workspacePath,omitempty" yaml:"workspacePath,omitempty" mapstructure:"workspacePath,omitempty"`
+
+	// workspaceParams corresponds to the JSON schema field "workspaceParams".
+	workspaceParams ParameterList `json:"workspaceParams,omitempty" yaml:"workspaceParams,omitempty" mapstructure:"workspaceParams,omitempty"`
 }
 
 type FlowFileVisibility common.Visibility
@@ -32,6 +32,18 @@ func (f *FlowFile) SetContext(workspaceName, workspacePath, configPath string) {
 	}
 }
 
+// SetWorkspaceDefaults sets the default parameters inherited from the workspace. It must be called before
+// SetContext for the executables of the flow file to inherit them.
+func (f *FlowFile) SetWorkspaceDefaults(params ParameterList) {
+	f.workspaceParams = params
+}
+
+// DefaultParams returns the parameters inherited by every executable in the flow file. The flow file's params
+// override its env and both override the workspace defaults.
+func (f *FlowFile) DefaultParams() ParameterList {
+	return f.workspaceParams.Merge(EnvParams(f.Env)).Merge(f.Params)
+}
+
 func (f *FlowFile) SetDefaults() {
 	if f.Visibility == nil || *f.Visibility == "" {
 		v := FlowFileVisibility(common.VisibilityPrivate)
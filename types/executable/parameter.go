@@ -3,7 +3,10 @@ package executable
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/flowexec/flow/internal/utils"
@@ -13,7 +16,7 @@ const (
 	ReservedEnvVarPrefix = "FLOW_"
 )
 
-func (p *Parameter) Validate() error {
+func (p *Parameter) ValidateConfig() error {
 	if err := utils.ValidateOneOf("parameter type", p.Text, p.SecretRef, p.Prompt); err != nil {
 		return err
 	}
@@ -32,5 +35,91 @@ func (p *Parameter) Validate() error {
 		}
 	}
 
+	if p.Type == ParameterTypeSelect && len(p.Options) == 0 {
+		return errors.New("options must be set for select parameters")
+	}
+	if p.Validate != "" {
+		if _, err := regexp.Compile(p.Validate); err != nil {
+			return fmt.Errorf("invalid validation expression: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ValidatePromptValue validates a value collected for a prompt parameter against its type, options and
+// validation expression.
+func (p *Parameter) ValidatePromptValue(val string) error {
+	if p.Prompt == "" {
+		return nil
+	}
+	switch p.Type {
+	case ParameterTypeSelect:
+		if !slices.Contains(p.Options, val) {
+			return fmt.Errorf("value (%s) must be one of [%s]", val, strings.Join(p.Options, ", "))
+		}
+	case ParameterTypeConfirm:
+		if _, err := strconv.ParseBool(val); err != nil {
+			return fmt.Errorf("value (%s) is not a boolean", val)
+		}
+	case ParameterTypeText, ParameterTypeMasked, "":
+		// no-op
+	}
+	if p.Validate != "" {
+		r, err := regexp.Compile(p.Validate)
+		if err != nil {
+			return fmt.Errorf("invalid validation expression: %w", err)
+		}
+		if !r.MatchString(val) {
+			return fmt.Errorf("value does not match %s", p.Validate)
+		}
+	}
 	return nil
 }
+
+// PromptDefault returns the value to use for a prompt parameter when no input can be collected.
+// The second return value is false if the parameter has no usable default.
+func (p *Parameter) PromptDefault() (string, bool) {
+	switch {
+	case p.Default != "":
+		return p.Default, true
+	case p.Type == ParameterTypeConfirm:
+		return "false", true
+	default:
+		return "", false
+	}
+}
+
+// EnvParams converts a map of environment variables into a list of text parameters sorted by env key.
+func EnvParams(env map[string]string) ParameterList {
+	if len(env) == 0 {
+		return nil
+	}
+	keys := slices.Sorted(maps.Keys(env))
+	params := make(ParameterList, 0, len(keys))
+	for _, key := range keys {
+		params = append(params, Parameter{EnvKey: key, Text: env[key]})
+	}
+	return params
+}
+
+// Merge returns the parameters of the list with the overrides applied on top. An override replaces the parameter
+// with the same env key.
+func (pl ParameterList) Merge(overrides ParameterList) ParameterList {
+	if len(pl) == 0 {
+		return slices.Clone(overrides)
+	}
+	overridden := make(map[string]bool, len(overrides))
+	for _, p := range overrides {
+		if p.EnvKey != "" {
+			overridden[p.EnvKey] = true
+		}
+	}
+	merged := make(ParameterList, 0, len(pl)+len(overrides))
+	for _, p := range pl {
+		if p.EnvKey == "" || !overridden[p.EnvKey] {
+			merged = append(merged, p)
+		}
+	}
+	return append(merged, overrides...)
+}
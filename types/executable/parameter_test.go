@@ -0,0 +1,84 @@
+package executable_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/flowexec/flow/types/executable"
+)
+
+var _ = Describe("Parameter", func() {
+	Describe("ValidateConfig", func() {
+		It("should require options for select prompts", func() {
+			p := executable.Parameter{EnvKey: "ENV", Prompt: "Env?", Type: executable.ParameterTypeSelect}
+			Expect(p.ValidateConfig()).To(MatchError(ContainSubstring("options must be set")))
+		})
+	})
+
+	DescribeTable("ValidatePromptValue",
+		func(p executable.Parameter, value string, valid bool) {
+			p.EnvKey = "KEY"
+			p.Prompt = "Value?"
+			if valid {
+				Expect(p.ValidatePromptValue(value)).To(Succeed())
+			} else {
+				Expect(p.ValidatePromptValue(value)).ToNot(Succeed())
+			}
+		},
+		Entry("text", executable.Parameter{}, "anything", true),
+		Entry("selected option",
+			executable.Parameter{Type: executable.ParameterTypeSelect, Options: []string{"a", "b"}}, "a", true),
+		Entry("unknown option",
+			executable.Parameter{Type: executable.ParameterTypeSelect, Options: []string{"a", "b"}}, "c", false),
+		Entry("confirmed", executable.Parameter{Type: executable.ParameterTypeConfirm}, "true", true),
+		Entry("invalid confirm", executable.Parameter{Type: executable.ParameterTypeConfirm}, "maybe", false),
+		Entry("matching validation", executable.Parameter{Validate: `^\d+$`}, "123", true),
+		Entry("non-matching validation", executable.Parameter{Validate: `^\d+$`}, "abc", false),
+	)
+
+	Describe("PromptDefault", func() {
+		It("should return the default value", func() {
+			p := executable.Parameter{Prompt: "Value?", Default: "x"}
+			val, ok := p.PromptDefault()
+			Expect(ok).To(BeTrue())
+			Expect(val).To(Equal("x"))
+		})
+
+		It("should default confirm prompts to false", func() {
+			p := executable.Parameter{Prompt: "Sure?", Type: executable.ParameterTypeConfirm}
+			val, ok := p.PromptDefault()
+			Expect(ok).To(BeTrue())
+			Expect(val).To(Equal("false"))
+		})
+
+		It("should report a missing default", func() {
+			p := executable.Parameter{Prompt: "Value?"}
+			_, ok := p.PromptDefault()
+			Expect(ok).To(BeFalse())
+		})
+	})
+	Describe("Merge", func() {
+		It("should replace parameters with the same env key", func() {
+			base := executable.ParameterList{{EnvKey: "A", Text: "base"}, {EnvKey: "B", Text: "base"}}
+			merged := base.Merge(executable.ParameterList{{EnvKey: "B", Text: "override"}, {EnvKey: "C", Text: "new"}})
+			Expect(merged).To(Equal(executable.ParameterList{
+				{EnvKey: "A", Text: "base"},
+				{EnvKey: "B", Text: "override"},
+				{EnvKey: "C", Text: "new"},
+			}))
+		})
+
+		It("should keep output file parameters without an env key", func() {
+			base := executable.ParameterList{{OutputFile: "out.txt", Text: "base"}}
+			merged := base.Merge(executable.ParameterList{{OutputFile: "out.txt", Text: "override"}})
+			Expect(merged).To(HaveLen(2))
+		})
+	})
+
+	Describe("EnvParams", func() {
+		It("should convert the env map into text parameters sorted by key", func() {
+			params := executable.EnvParams(map[string]string{"B": "2", "A": "1"})
+			Expect(params).To(Equal(executable.ParameterList{{EnvKey: "A", Text: "1"}, {EnvKey: "B", Text: "2"}}))
+		})
+	})
+})
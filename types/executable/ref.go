@@ -166,6 +166,15 @@ func ValidVerbs() []Verb {
 	}
 }
 
+// DestructiveVerbs returns the verbs of executables that always require a confirmation before they are run.
+func DestructiveVerbs() []Verb {
+	return []Verb{VerbDestroy, VerbPurge, VerbUndeploy}
+}
+
+func (v Verb) IsDestructive() bool {
+	return slices.Contains(DestructiveVerbs(), v)
+}
+
 func SortedValidVerbs() []string {
 	verbs := make([]string, 0)
 	for _, v := range ValidVerbs() {
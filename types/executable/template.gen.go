@@ -95,6 +95,18 @@ type Template struct {
 	// assignedName corresponds to the JSON schema field "assignedName".
 	assignedName *string `json:"assignedName,omitempty" yaml:"assignedName,omitempty" mapstructure:"assignedName,omitempty"`
 
+	// The registered name or path of a template to extend. Relative paths are
+	// resolved from this template's directory.
+	// The parent's form fields, artifacts and partials are inherited unless this
+	// template defines a field with the
+	// same key, an artifact with the same destination or a partial with the same
+	// name. The parent's pre-run and
+	// post-run executables run before this template's own, and the parent's flow
+	// file template is used if this
+	// template does not define one.
+	//
+	Extends string `json:"extends,omitempty" yaml:"extends,omitempty" mapstructure:"extends,omitempty"`
+
 	// Form fields to be displayed to the user when generating a flow file from a
 	// template.
 	// The form will be rendered first, and the user's input can be used to render the
@@ -107,6 +119,13 @@ type Template struct {
 	// location corresponds to the JSON schema field "location".
 	location *string `json:"location,omitempty" yaml:"location,omitempty" mapstructure:"location,omitempty"`
 
+	// A map of partial names to the paths of files, relative to this template's
+	// directory, that can be included in
+	// the flow file template and in artifacts rendered as templates with `{{ template
+	// "name" }}`.
+	//
+	Partials TemplatePartials `json:"partials,omitempty" yaml:"partials,omitempty" mapstructure:"partials,omitempty"`
+
 	// A list of exec executables to run after generating the flow file.
 	PostRun []TemplateRefConfig `json:"postRun,omitempty" yaml:"postRun,omitempty" mapstructure:"postRun,omitempty"`
 
@@ -115,7 +134,63 @@ type Template struct {
 
 	// The flow file template to generate. The template must be a valid flow file
 	// after rendering.
-	Template string `json:"template" yaml:"template" mapstructure:"template"`
+	// Required unless the template extends another template.
+	//
+	Template string `json:"template,omitempty" yaml:"template,omitempty" mapstructure:"template,omitempty"`
+}
+
+// A map of partial names to the paths of files, relative to this template's
+// directory, that can be included in
+// the flow file template and in artifacts rendered as templates with `{{ template
+// "name" }}`.
+type TemplatePartials map[string]string
+
+// Metadata distributed alongside a template that is installed from a git
+// repository or archive.
+// The manifest must be named `flow.template.yaml` (or `flow.template.yml`) and be
+// located in the template's directory.
+type TemplateManifest struct {
+	// A description of the template.
+	Description string `json:"description,omitempty" yaml:"description,omitempty" mapstructure:"description,omitempty"`
+
+	// The field schema of the template. The template's form must define each field
+	// with the same type
+	// and required setting.
+	//
+	Fields []TemplateManifestField `json:"fields,omitempty" yaml:"fields,omitempty" mapstructure:"fields,omitempty"`
+
+	// A semantic version constraint (e.g. `>=1.2.0`) of the flow versions that the
+	// template supports.
+	// Installing the template fails if the running flow version does not satisfy the
+	// constraint.
+	//
+	FlowVersion string `json:"flowVersion,omitempty" yaml:"flowVersion,omitempty" mapstructure:"flowVersion,omitempty"`
+
+	// The name of the template.
+	Name string `json:"name,omitempty" yaml:"name,omitempty" mapstructure:"name,omitempty"`
+
+	// The path to the template file, relative to the manifest.
+	// If not set, the directory must contain exactly one template file.
+	//
+	Template string `json:"template,omitempty" yaml:"template,omitempty" mapstructure:"template,omitempty"`
+
+	// The version of the template.
+	Version string `json:"version,omitempty" yaml:"version,omitempty" mapstructure:"version,omitempty"`
+}
+
+// A form field that is declared in a template manifest.
+type TemplateManifestField struct {
+	// A description of the form field.
+	Description string `json:"description,omitempty" yaml:"description,omitempty" mapstructure:"description,omitempty"`
+
+	// The key of the form field.
+	Key string `json:"key" yaml:"key" mapstructure:"key"`
+
+	// Whether the form field is required.
+	Required bool `json:"required,omitempty" yaml:"required,omitempty" mapstructure:"required,omitempty"`
+
+	// The type of the form field.
+	Type FieldType `json:"type,omitempty" yaml:"type,omitempty" mapstructure:"type,omitempty"`
 }
 
 // Configuration for a template executable.
@@ -128,6 +203,12 @@ type TemplateRefConfig struct {
 	//
 	Cmd string `json:"cmd,omitempty" yaml:"cmd,omitempty" mapstructure:"cmd,omitempty"`
 
+	// If true, generation continues with the next executable when this executable
+	// fails.
+	// Otherwise, the remaining executables are not run and generation fails.
+	//
+	ContinueOnError bool `json:"continueOnError,omitempty" yaml:"continueOnError,omitempty" mapstructure:"continueOnError,omitempty"`
+
 	// An expression that determines whether the executable should be run, using the
 	// Expr language syntax.
 	// The expression is evaluated at runtime and must resolve to a boolean value. If
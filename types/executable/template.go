@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/flowexec/tuikit/types"
@@ -42,6 +43,27 @@ func (f *Field) ValidateConfig() error {
 	return nil
 }
 
+// ValidateValue validates the field's current value against its required setting and validation expression.
+func (f *Field) ValidateValue() error {
+	val := f.Value()
+	if val == "" {
+		if f.Required {
+			return fmt.Errorf("field %s is required", f.Key)
+		}
+		return nil
+	}
+	if f.Validate != "" {
+		r, err := regexp.Compile(f.Validate)
+		if err != nil {
+			return fmt.Errorf("field %s has an invalid validation expression: %w", f.Key, err)
+		}
+		if !r.MatchString(val) {
+			return fmt.Errorf("field %s value (%s) does not match %s", f.Key, val, f.Validate)
+		}
+	}
+	return nil
+}
+
 type FormFields []*Field
 
 func (f FormFields) Set(key, value string) {
@@ -53,6 +75,16 @@ func (f FormFields) Set(key, value string) {
 	}
 }
 
+// Find returns the field with the given key, or nil if it does not exist.
+func (f FormFields) Find(key string) *Field {
+	for _, entry := range f {
+		if entry.Key == key {
+			return entry
+		}
+	}
+	return nil
+}
+
 func (f FormFields) ValueMap() map[string]string {
 	data := map[string]string{}
 	for _, entry := range f {
@@ -70,6 +102,17 @@ func (f FormFields) Validate() error {
 	return nil
 }
 
+// ValidateValues validates the current value of all fields.
+func (f FormFields) ValidateValues() error {
+	var errs []error
+	for _, field := range f {
+		if err := field.ValidateValue(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
 func (t *Template) SetContext(name, location string) {
 	if t == nil {
 		return
@@ -105,6 +148,88 @@ func (t *Template) Validate() error {
 	return t.Form.Validate()
 }
 
+// Extend merges the parent template into the template. Form fields, artifacts and partials that are defined by the
+// template override the parent's entries with the same key, destination or name. The parent's artifact sources and
+// partial paths are made absolute so that they continue to resolve from the parent's directory.
+func (t *Template) Extend(parent *Template) {
+	if parent == nil {
+		return
+	}
+	parentDir := filepath.Dir(parent.Location())
+
+	form := make(FormFields, 0, len(parent.Form)+len(t.Form))
+	for _, f := range parent.Form {
+		if override := t.Form.Find(f.Key); override != nil {
+			form = append(form, override)
+		} else {
+			form = append(form, f)
+		}
+	}
+	for _, f := range t.Form {
+		if parent.Form.Find(f.Key) == nil {
+			form = append(form, f)
+		}
+	}
+	t.Form = form
+
+	overridden := make(map[string]bool, len(t.Artifacts))
+	for _, a := range t.Artifacts {
+		overridden[a.destination()] = true
+	}
+	artifacts := make([]Artifact, 0, len(parent.Artifacts)+len(t.Artifacts))
+	for _, a := range parent.Artifacts {
+		if overridden[a.destination()] {
+			continue
+		}
+		a.SrcDir = inheritedDir(a.SrcDir, parentDir)
+		artifacts = append(artifacts, a)
+	}
+	t.Artifacts = append(artifacts, t.Artifacts...)
+
+	if len(parent.Partials) > 0 {
+		partials := make(TemplatePartials, len(parent.Partials)+len(t.Partials))
+		for name, path := range parent.Partials {
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(parentDir, path)
+			}
+			partials[name] = path
+		}
+		for name, path := range t.Partials {
+			partials[name] = path
+		}
+		t.Partials = partials
+	}
+
+	t.PreRun = append(slices.Clone(parent.PreRun), t.PreRun...)
+	t.PostRun = append(slices.Clone(parent.PostRun), t.PostRun...)
+	if t.Template == "" {
+		t.Template = parent.Template
+	}
+}
+
+// destination returns the destination of the artifact, relative to the flow file directory.
+func (a Artifact) destination() string {
+	name := a.DstName
+	if name == "" {
+		name = a.SrcName
+	}
+	return filepath.Join(a.DstDir, name)
+}
+
+// inheritedDir returns the source directory of an inherited artifact as it would be resolved by the parent template.
+func inheritedDir(dir, parentDir string) string {
+	switch {
+	case dir == "":
+		return parentDir
+	case filepath.IsAbs(dir), strings.HasPrefix(dir, "//"), strings.HasPrefix(dir, "./"), dir == ".",
+		strings.HasPrefix(dir, "~/"), strings.HasPrefix(dir, "$"):
+		return dir
+	default:
+		// relative source directories are resolved from the parent of the template's directory
+		return filepath.Join(filepath.Dir(parentDir), dir)
+	}
+}
+
 func (t *Template) YAML() (string, error) {
 	yamlBytes, err := yaml.Marshal(t)
 	if err != nil {
@@ -0,0 +1,58 @@
+package executable
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+var TemplateManifestFileNames = []string{"flow.template.yaml", "flow.template.yml"}
+
+// ValidateTemplate checks that the template's form satisfies the field schema declared in the manifest.
+func (m *TemplateManifest) ValidateTemplate(t *Template) error {
+	var errs []error
+	for _, mf := range m.Fields {
+		f := t.Form.Find(mf.Key)
+		if f == nil {
+			errs = append(errs, fmt.Errorf("field %s is declared in the manifest but not in the template form", mf.Key))
+			continue
+		}
+		if fieldType(f.Type) != fieldType(mf.Type) {
+			errs = append(errs, fmt.Errorf("field %s type (%s) does not match the manifest (%s)",
+				mf.Key, fieldType(f.Type), fieldType(mf.Type)))
+		}
+		if f.Required != mf.Required {
+			errs = append(errs, fmt.Errorf("field %s required setting does not match the manifest", mf.Key))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// CheckFlowVersion returns an error if the flow version does not satisfy the manifest's flow version constraint.
+// Versions that are not valid semantic versions (e.g. development builds) are not checked.
+func (m *TemplateManifest) CheckFlowVersion(flowVersion string) error {
+	if m.FlowVersion == "" {
+		return nil
+	}
+	constraint, err := semver.NewConstraint(m.FlowVersion)
+	if err != nil {
+		return fmt.Errorf("invalid flow version constraint %s: %w", m.FlowVersion, err)
+	}
+	v, err := semver.NewVersion(strings.TrimPrefix(strings.TrimSpace(flowVersion), "v"))
+	if err != nil {
+		return nil //nolint:nilerr
+	}
+	if !constraint.Check(v) {
+		return fmt.Errorf("template requires flow %s but the current version is %s", m.FlowVersion, v)
+	}
+	return nil
+}
+
+func fieldType(t FieldType) FieldType {
+	if t == "" {
+		return FieldTypeText
+	}
+	return t
+}
@@ -58,6 +58,73 @@ tags: [test]
 		})
 	})
 
+	Describe("ValidateValues", func() {
+		It("should use the default value when a field is not set", func() {
+			template.Form[0].Required = true
+			Expect(template.Form.ValidateValues()).To(Succeed())
+		})
+
+		It("should error when a required field has no value", func() {
+			template.Form = append(template.Form, &executable.Field{Key: "required", Prompt: "?", Required: true})
+			Expect(template.Form.ValidateValues()).To(MatchError(ContainSubstring("field required is required")))
+		})
+
+		It("should validate the value against the validation expression", func() {
+			template.Form[0].Validate = "^[a-z]+$"
+			template.Form.Set("testKey", "valid")
+			Expect(template.Form.ValidateValues()).To(Succeed())
+			template.Form.Set("testKey", "Invalid1")
+			Expect(template.Form.ValidateValues()).To(MatchError(ContainSubstring("does not match")))
+		})
+	})
+
+	Describe("Extend", func() {
+		var parent *executable.Template
+
+		BeforeEach(func() {
+			parent = &executable.Template{
+				Artifacts: []executable.Artifact{
+					{SrcName: "main.go"},
+					{SrcName: "Makefile"},
+					{SrcName: "ci.yaml", SrcDir: "shared"},
+				},
+				Form: executable.FormFields{
+					&executable.Field{Key: "testKey", Prompt: "parentPrompt"},
+					&executable.Field{Key: "parentKey", Prompt: "parentPrompt"},
+				},
+				Partials: executable.TemplatePartials{"ci": "partials/ci.yaml"},
+				PreRun:   []executable.TemplateRefConfig{{Cmd: "echo parent"}},
+				Template: "namespace: parent",
+			}
+			parent.SetContext("parent", "/templates/base/parent.flow.tmpl")
+			template.PreRun = []executable.TemplateRefConfig{{Cmd: "echo child"}}
+		})
+
+		It("should merge the form fields with the template's fields taking precedence", func() {
+			template.Extend(parent)
+			Expect(template.Form).To(HaveLen(2))
+			Expect(template.Form[0].Prompt).To(Equal("testPrompt"))
+			Expect(template.Form[1].Key).To(Equal("parentKey"))
+		})
+
+		It("should inherit the parent's artifacts that are not overridden", func() {
+			template.Extend(parent)
+			Expect(template.Artifacts).To(HaveLen(4))
+			Expect(template.Artifacts[0]).To(Equal(executable.Artifact{SrcName: "Makefile", SrcDir: "/templates/base"}))
+			Expect(template.Artifacts[1]).To(Equal(executable.Artifact{SrcName: "ci.yaml", SrcDir: "/templates/shared"}))
+			Expect(template.Artifacts[2]).To(Equal(executable.Artifact{SrcName: "main.go"}))
+		})
+
+		It("should merge partials, executables and the flow file template", func() {
+			template.Template = ""
+			template.Extend(parent)
+			Expect(template.Partials).To(HaveKeyWithValue("ci", "/templates/base/partials/ci.yaml"))
+			Expect(template.PreRun).To(HaveLen(2))
+			Expect(template.PreRun[0].Cmd).To(Equal("echo parent"))
+			Expect(template.Template).To(Equal("namespace: parent"))
+		})
+	})
+
 	Describe("Format Methods", func() {
 		It("JSON should return the JSON representation of the template", func() {
 			str, err := template.JSON()
@@ -76,6 +143,54 @@ tags: [test]
 	})
 })
 
+var _ = Describe("TemplateManifest", func() {
+	var template *executable.Template
+
+	BeforeEach(func() {
+		template = &executable.Template{
+			Form: executable.FormFields{
+				&executable.Field{Key: "name", Prompt: "Name?", Required: true},
+				&executable.Field{Key: "token", Prompt: "Token?", Type: executable.FieldTypeMasked},
+			},
+		}
+	})
+
+	Describe("ValidateTemplate", func() {
+		It("should succeed when the form matches the field schema", func() {
+			m := &executable.TemplateManifest{Fields: []executable.TemplateManifestField{
+				{Key: "name", Required: true},
+				{Key: "token", Type: executable.FieldTypeMasked},
+			}}
+			Expect(m.ValidateTemplate(template)).To(Succeed())
+		})
+
+		It("should error when the form does not match the field schema", func() {
+			m := &executable.TemplateManifest{Fields: []executable.TemplateManifestField{
+				{Key: "name"},
+				{Key: "token"},
+				{Key: "missing"},
+			}}
+			err := m.ValidateTemplate(template)
+			Expect(err).To(MatchError(ContainSubstring("field name required setting")))
+			Expect(err).To(MatchError(ContainSubstring("field token type (masked)")))
+			Expect(err).To(MatchError(ContainSubstring("field missing is declared")))
+		})
+	})
+
+	Describe("CheckFlowVersion", func() {
+		It("should check the flow version against the constraint", func() {
+			m := &executable.TemplateManifest{FlowVersion: ">=1.2.0"}
+			Expect(m.CheckFlowVersion("v1.2.1")).To(Succeed())
+			Expect(m.CheckFlowVersion("1.1.0")).To(HaveOccurred())
+		})
+
+		It("should not check unknown flow versions", func() {
+			m := &executable.TemplateManifest{FlowVersion: ">=1.2.0"}
+			Expect(m.CheckFlowVersion("unknown")).To(Succeed())
+		})
+	})
+})
+
 var _ = Describe("TemplateList", func() {
 	var (
 		templates executable.TemplateList
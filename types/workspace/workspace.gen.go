@@ -2,7 +2,10 @@
 
 package workspace
 
-import "github.com/flowexec/flow/types/common"
+import (
+	"github.com/flowexec/flow/types/common"
+	"github.com/flowexec/flow/types/executable"
+)
 
 type ExecutableFilter struct {
 	// A list of directories or file patterns to exclude from the executable search.
@@ -20,6 +23,39 @@ type ExecutableFilter struct {
 	Included []string `json:"included,omitempty" yaml:"included,omitempty" mapstructure:"included,omitempty"`
 }
 
+// A named set of values that is applied when an executable in the workspace is run
+// with the profile selected.
+// Profiles are selected with the `--profile` flag of the exec command or the
+// `currentProfile` config setting.
+type Profile struct {
+	// If true, a confirmation is required before an executable is run with the
+	// profile.
+	Confirm bool `json:"confirm,omitempty" yaml:"confirm,omitempty" mapstructure:"confirm,omitempty"`
+
+	// Environment variables for executables run with the profile. Each entry is the
+	// equivalent of a `text`
+	// parameter and is overridden by a parameter with the same `envKey` defined by
+	// the profile.
+	//
+	Env map[string]string `json:"env,omitempty" yaml:"env,omitempty" mapstructure:"env,omitempty"`
+
+	// A list of dotenv files to load for executables run with the profile. Relative
+	// paths are resolved from the
+	// workspace root. Values from `env` and `params` take precedence over the files.
+	//
+	EnvFile executable.EnvFileList `json:"envFile,omitempty" yaml:"envFile,omitempty" mapstructure:"envFile,omitempty"`
+
+	// Parameter values for executables run with the profile. They override the
+	// parameters with the same `envKey`
+	// defined by the executable, flow file or workspace. Only `text` and `secretRef`
+	// parameters are supported.
+	//
+	Params executable.ParameterList `json:"params,omitempty" yaml:"params,omitempty" mapstructure:"params,omitempty"`
+
+	// The name of the vault to resolve secrets from when running with the profile.
+	Vault string `json:"vault,omitempty" yaml:"vault,omitempty" mapstructure:"vault,omitempty"`
+}
+
 // A map of executable verbs to valid aliases. This allows you to use custom
 // aliases for exec commands in the workspace.
 // Setting this will override all of the default flow command aliases. The verbs
@@ -46,12 +82,39 @@ type Workspace struct {
 	// The display name of the workspace. This is used in the interactive UI.
 	DisplayName string `json:"displayName,omitempty" yaml:"displayName,omitempty" mapstructure:"displayName,omitempty"`
 
+	// Default environment variables for all executables in the workspace. Each entry
+	// is the equivalent of a `text`
+	// parameter and is overridden by a parameter with the same `envKey` defined by
+	// the workspace, a flow file or
+	// an executable.
+	//
+	Env map[string]string `json:"env,omitempty" yaml:"env,omitempty" mapstructure:"env,omitempty"`
+
 	// Executables corresponds to the JSON schema field "executables".
 	Executables *ExecutableFilter `json:"executables,omitempty" yaml:"executables,omitempty" mapstructure:"executables,omitempty"`
 
+	// Hooks that are run around every executable in the workspace. Hook commands are
+	// run from the workspace root.
+	// The executable reference, its result status and duration are available to the
+	// hooks in the
+	// `FLOW_EXECUTABLE_REF`, `FLOW_EXECUTABLE_STATUS` and `FLOW_EXECUTABLE_DURATION`
+	// environment variables.
+	//
+	Hooks *executable.Hooks `json:"hooks,omitempty" yaml:"hooks,omitempty" mapstructure:"hooks,omitempty"`
+
 	// location corresponds to the JSON schema field "location".
 	location string `json:"location,omitempty" yaml:"location,omitempty" mapstructure:"location,omitempty"`
 
+	// Default parameters for all executables in the workspace. They are overridden by
+	// the parameters with the same
+	// `envKey` defined by a flow file or an executable.
+	//
+	Params executable.ParameterList `json:"params,omitempty" yaml:"params,omitempty" mapstructure:"params,omitempty"`
+
+	// A map of profile names to the values applied when an executable is run with
+	// the profile.
+	Profiles WorkspaceProfiles `json:"profiles,omitempty" yaml:"profiles,omitempty" mapstructure:"profiles,omitempty"`
+
 	// Tags corresponds to the JSON schema field "tags".
 	Tags WorkspaceTags `json:"tags,omitempty" yaml:"tags,omitempty" mapstructure:"tags,omitempty"`
 
@@ -59,6 +122,10 @@ type Workspace struct {
 	VerbAliases *WorkspaceVerbAliases `json:"verbAliases,omitempty" yaml:"verbAliases,omitempty" mapstructure:"verbAliases,omitempty"`
 }
 
+// A map of profile names to the values applied when an executable is run with
+// the profile.
+type WorkspaceProfiles map[string]Profile
+
 type WorkspaceTags common.Tags
 
 type WorkspaceVerbAliases map[string][]string
@@ -10,6 +10,7 @@ import (
 
 	"github.com/flowexec/flow/internal/utils"
 	"github.com/flowexec/flow/types/common"
+	"github.com/flowexec/flow/types/executable"
 )
 
 //go:generate go run github.com/atombender/go-jsonschema@v0.17.0 -et --only-models -p workspace -o workspace.gen.go schema.yaml
@@ -49,6 +50,42 @@ func (w *Workspace) SetContext(name, location string) {
 	w.location = location
 }
 
+// DefaultParams returns the parameters inherited by every executable in the workspace. The workspace's params
+// override its env.
+func (w *Workspace) DefaultParams() executable.ParameterList {
+	return executable.EnvParams(w.Env).Merge(w.Params)
+}
+
+// Profile returns the profile with the given name. An error is returned if the workspace doesn't define the profile
+// or if the profile is invalid.
+func (w *Workspace) Profile(name string) (*Profile, error) {
+	p, found := w.Profiles[name]
+	if !found {
+		return nil, fmt.Errorf("profile %s not found in workspace %s", name, w.AssignedName())
+	}
+	if err := p.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid profile %s - %w", name, err)
+	}
+	return &p, nil
+}
+
+func (p *Profile) Validate() error {
+	for _, param := range p.Params {
+		if param.Prompt != "" {
+			return fmt.Errorf("prompt parameter %s is not supported in profiles", param.EnvKey)
+		}
+		if err := param.ValidateConfig(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MergedParams returns the profile's params with its env applied beneath them as text parameters.
+func (p *Profile) MergedParams() executable.ParameterList {
+	return executable.EnvParams(p.Env).Merge(p.Params)
+}
+
 func (w *Workspace) YAML() (string, error) {
 	yamlBytes, err := yaml.Marshal(w.enriched())
 	if err != nil {
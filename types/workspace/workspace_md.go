@@ -2,8 +2,10 @@ package workspace
 
 import (
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 )
 
@@ -36,6 +38,12 @@ func workspaceMarkdown(w *Workspace) string {
 			}
 		}
 	}
+	if len(w.Profiles) > 0 {
+		mkdwn += "**Profiles**\n"
+		for _, name := range slices.Sorted(maps.Keys(w.Profiles)) {
+			mkdwn += fmt.Sprintf("- %s\n", name)
+		}
+	}
 	mkdwn += fmt.Sprintf("\n\n_Workspace can be found in_ [%s](%s)\n", w.Location(), w.Location())
 	return mkdwn
 }